@@ -18,12 +18,10 @@ import (
 	"security-proof/internal/middleware"
 	"security-proof/pkg/auth"
 	dbmanage "security-proof/pkg/manage/db"
-	elasticmanage "security-proof/pkg/manage/elastic"
 )
 
 func main() {
 	tokenConfig := dbmanage.TokenConfig{}
-	elaConfig := elasticmanage.Config{}
 	readConfig := dbmanage.ReadConfig{}
 	userConfig := usermanage.Config{}
 	baseAddr := "127.0.0.3:8082"
@@ -44,10 +42,9 @@ func main() {
 	queryRepo := repository.NewDashboardQuery(readDB)
 
 	token := auth.NewToken(tokenRepo)
-	elastic := elasticmanage.NewElastic(elaConfig.FromEnv())
 	user := usermanage.NewUser(userConfig.FromEnv())
 
-	queryService := service.NewDashboardService(token, queryRepo, elastic, user)
+	queryService := service.NewDashboardService(token, queryRepo, user)
 
 	dashboardController := controller.NewDashboardController(queryService)
 
@@ -55,6 +52,8 @@ func main() {
 	path, handler := apiv1connect.NewDashboardServiceHandler(dashboardController)
 
 	mux.Handle(path, handler)
+	mux.HandleFunc("GET /apiv1/readOverdueProofs", dashboardController.ReadOverdueProofs)
+	mux.HandleFunc("GET /apiv1/readIntegrity", dashboardController.ReadIntegrity)
 
 	server := &http.Server{
 		Addr:              baseAddr,
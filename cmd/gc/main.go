@@ -0,0 +1,68 @@
+// Package main is the command for collecting evidence files no proof references anymore.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"time"
+
+	"security-proof/internal/proof/repository"
+	"security-proof/internal/proof/service"
+	"security-proof/pkg/constants"
+	dbmanage "security-proof/pkg/manage/db"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report orphaned files without removing them")
+	flag.Parse()
+
+	writeConfig := dbmanage.WriteConfig{}
+	readConfig := dbmanage.ReadConfig{}
+	storageConfig := storagemanage.Config{}
+	gcConfig := storagemanage.GCConfig{}
+
+	writeDB, err := dbmanage.NewDB(context.Background(), writeConfig.Dsn())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	readDB, err := dbmanage.NewDB(context.Background(), readConfig.Dsn())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	storage, err := storagemanage.NewStorage(storageConfig.FromEnv())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	collector := service.NewStorageCollector(
+		repository.NewProofCommand(writeDB),
+		repository.NewProofQuery(readDB),
+		storage,
+		storageConfig.Path,
+		gcConfig.FromEnv(),
+	)
+
+	report, err := collector.CollectLocked(context.Background(), time.Now(), *dryRun)
+	if errors.Is(err, constants.ErrLockHeld) {
+		log.Print("another instance is collecting, skipped")
+		return
+	}
+	if report != nil {
+		log.Printf("scanned %d files, found %d orphaned files of %d bytes", report.Scanned, report.Orphaned, report.OrphanedBytes)
+		if !*dryRun {
+			log.Printf("removed %d files by %s, reclaimed %d bytes and removed %d blobs", report.Removed, gcConfig.Mode, report.ReclaimedBytes, report.Blobs)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+}
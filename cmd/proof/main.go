@@ -18,6 +18,11 @@ import (
 	"security-proof/pkg/auth"
 	chainmanage "security-proof/pkg/manage/chain"
 	dbmanage "security-proof/pkg/manage/db"
+	filemanage "security-proof/pkg/manage/file"
+	notifymanage "security-proof/pkg/manage/notify"
+	scanmanage "security-proof/pkg/manage/scan"
+	signmanage "security-proof/pkg/manage/sign"
+	storagemanage "security-proof/pkg/manage/storage"
 	usermanage "security-proof/pkg/manage/user"
 )
 
@@ -27,6 +32,15 @@ func main() {
 	readConfig := dbmanage.ReadConfig{}
 	chainConfig := chainmanage.Config{}
 	userConfig := usermanage.Config{}
+	notifyConfig := notifymanage.Config{}
+	signConfig := signmanage.Config{}
+	storageConfig := storagemanage.Config{}
+	uploadConfig := filemanage.Config{}
+	gcConfig := storagemanage.GCConfig{}
+	retentionConfig := storagemanage.RetentionConfig{}
+	integrityConfig := storagemanage.IntegrityConfig{}
+	urlConfig := auth.URLConfig{}
+	scanConfig := scanmanage.Config{}
 	baseAddr := "127.0.0.2:8081"
 
 	tokenDB, err := dbmanage.NewRedis(tokenConfig.Dsn())
@@ -47,6 +61,12 @@ func main() {
 		return
 	}
 
+	storage, err := storagemanage.NewStorage(storageConfig.FromEnv())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
 	tokenRepo := auth.NewTokenRepo(tokenDB)
 	commandRepo := repository.NewProofCommand(writeDB)
 	queryRepo := repository.NewProofQuery(readDB)
@@ -55,18 +75,89 @@ func main() {
 	chain := chainmanage.NewChain(chainConfig.FromEnv())
 	user := usermanage.NewUser(userConfig.FromEnv())
 
-	commandService := service.NewProofCommand(token, commandRepo, queryRepo, chain)
-	queryService := service.NewProofQuery(token, queryRepo, user)
+	scanner, err := scanmanage.NewScanner(scanConfig.FromEnv())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+	commandService := service.NewProofCommand(token, commandRepo, queryRepo, chain, user, storage, filemanage.NewValidator(uploadConfig.FromEnv()), scanner)
+
+	// 서명된 다운로드 주소의 일회용 여부는 토큰과 같은 Redis에 기록합니다.
+	urlSigner, err := auth.NewURLSigner(urlConfig.FromEnv(), auth.NewNonceRepo(tokenDB))
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+	queryService := service.NewProofQuery(token, queryRepo, user, storage, urlSigner)
+
+	signer, err := signmanage.NewSigner(signConfig.FromEnv())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+	exportService := service.NewProofExport(token, queryRepo, user, signer, storage)
+
+	proofController := controller.NewProofController(commandService, queryService, exportService)
+
+	// 기한이 임박하거나 지난 증적을 주기적으로 담당자에게 알립니다.
+	scheduler := service.NewReminderScheduler(commandRepo, queryRepo, notifymanage.NewLogNotifier(nil), notifyConfig.FromEnv())
+	go scheduler.Run(context.Background())
+
+	// 어떤 증적도 참조하지 않는 파일을 유예 기간이 지나면 격리하거나 지웁니다.
+	collector := service.NewStorageCollector(commandRepo, queryRepo, storage, storageConfig.Path, gcConfig.FromEnv())
+	go collector.Run(context.Background())
+
+	// 보관 기간이 지난 증적 파일을 지우고 해시와 체인 기록만 남깁니다.
+	purger := service.NewRetentionPurger(commandRepo, queryRepo, storage, retentionConfig.FromEnv())
+	go purger.Run(context.Background())
 
-	proofController := controller.NewProofController(commandService, queryService)
+	// 확정된 증적 파일을 주기적으로 다시 해시해 확정할 때의 해시, 체인 기록과 비교합니다.
+	integrityScanner := service.NewIntegrityScanner(commandRepo, queryRepo, chain, storage, integrityConfig.FromEnv())
+	go integrityScanner.Run(context.Background())
 
 	mux := http.NewServeMux()
 	path, handler := apiv1connect.NewProofServiceHandler(proofController)
 
 	mux.Handle(path, handler)
 	// 이미지 부분은 grpc를 사용하지 않고 이미지를 전달합니다.
-	mux.HandleFunc("/apiv1/readFirstImage/", proofController.ReadFirstImage)
-	mux.HandleFunc("/apiv1/readSecondImage/", proofController.ReadSecondImage)
+	mux.HandleFunc("GET /apiv1/readFirstImage/{idx}", proofController.ReadFirstImage)
+	mux.HandleFunc("GET /apiv1/readSecondImage/{idx}", proofController.ReadSecondImage)
+	mux.HandleFunc("GET /apiv1/readAttachment/{idx}/{position}", proofController.ReadAttachment)
+	mux.HandleFunc("GET /apiv1/readAttachments/{idx}", proofController.ReadAttachments)
+	mux.HandleFunc("GET /apiv1/readThumbnail/{idx}/{position}", proofController.ReadThumbnail)
+	mux.HandleFunc("GET /apiv1/readLog/{idx}", proofController.ReadProofLog)
+	mux.HandleFunc("POST /apiv1/createDownloadURL/{idx}/{position}", proofController.CreateDownloadURL)
+	mux.HandleFunc("GET /apiv1/download/{idx}/{position}", proofController.ReadSignedDownload)
+	mux.HandleFunc("POST /apiv1/uploadAttachments/{idx}", proofController.UploadAttachments)
+	mux.HandleFunc("POST /apiv1/reviewProof/{idx}", proofController.ReviewProof)
+	mux.HandleFunc("POST /apiv1/rejectProof/{idx}", proofController.RejectProof)
+	mux.HandleFunc("GET /apiv1/readProofRejects/{idx}", proofController.ReadProofRejects)
+	mux.HandleFunc("POST /apiv1/expireProof/{idx}", proofController.ExpireProof)
+	mux.HandleFunc("POST /apiv1/reopenProof/{idx}", proofController.ReopenProof)
+	mux.HandleFunc("GET /apiv1/readProofHistory/{idx}", proofController.ReadProofHistory)
+	mux.HandleFunc("GET /apiv1/readProofRevisions/{idx}", proofController.ReadProofRevisions)
+	mux.HandleFunc("POST /apiv1/startCycle", proofController.StartCycle)
+	mux.HandleFunc("GET /apiv1/readCycles", proofController.ReadCycles)
+	mux.HandleFunc("GET /apiv1/readCycleProofs/{idx}", proofController.ReadCycleProofs)
+	mux.HandleFunc("GET /apiv1/readProofs", proofController.ReadProofs)
+	mux.HandleFunc("POST /apiv1/importControls", proofController.ImportControls)
+	mux.HandleFunc("GET /apiv1/readControls", proofController.ReadControls)
+	mux.HandleFunc("GET /apiv1/readUncoveredControls", proofController.ReadUncoveredControls)
+	mux.HandleFunc("POST /apiv1/mapProofControls/{idx}", proofController.MapProofControls)
+	mux.HandleFunc("GET /apiv1/readProofControls/{idx}", proofController.ReadProofControls)
+	mux.HandleFunc("POST /apiv1/setProofDueDate/{idx}", proofController.SetProofDueDate)
+	mux.HandleFunc("GET /apiv1/readOverdueProofs", proofController.ReadOverdueProofs)
+	mux.HandleFunc("POST /apiv1/importProofs", proofController.ImportProofs)
+	mux.HandleFunc("GET /apiv1/exportEvidence", proofController.ExportEvidence)
+	mux.HandleFunc("POST /apiv1/createProofComment/{idx}", proofController.CreateProofComment)
+	mux.HandleFunc("GET /apiv1/readProofComments/{idx}", proofController.ReadProofComments)
+	mux.HandleFunc("POST /apiv1/editProofComment/{idx}", proofController.EditProofComment)
+	mux.HandleFunc("POST /apiv1/deleteProofComment/{idx}", proofController.DeleteProofComment)
+	mux.HandleFunc("POST /apiv1/setRetentionPolicy", proofController.SetRetentionPolicy)
+	mux.HandleFunc("POST /apiv1/deleteRetentionPolicy/{idx}", proofController.DeleteRetentionPolicy)
+	mux.HandleFunc("GET /apiv1/readRetentionPolicies", proofController.ReadRetentionPolicies)
+	mux.HandleFunc("POST /apiv1/setLegalHold/{idx}", proofController.SetLegalHold)
+	mux.HandleFunc("GET /apiv1/readPurgedEvidence", proofController.ReadPurgedEvidence)
 
 	server := &http.Server{
 		Addr:              baseAddr,
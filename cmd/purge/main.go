@@ -0,0 +1,70 @@
+// Package main is the command for purging evidence whose retention period expired.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"time"
+
+	"security-proof/internal/proof/repository"
+	"security-proof/internal/proof/service"
+	"security-proof/pkg/constants"
+	dbmanage "security-proof/pkg/manage/db"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report expired proofs without purging them")
+	flag.Parse()
+
+	writeConfig := dbmanage.WriteConfig{}
+	readConfig := dbmanage.ReadConfig{}
+	storageConfig := storagemanage.Config{}
+	retentionConfig := storagemanage.RetentionConfig{}
+
+	writeDB, err := dbmanage.NewDB(context.Background(), writeConfig.Dsn())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	readDB, err := dbmanage.NewDB(context.Background(), readConfig.Dsn())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	storage, err := storagemanage.NewStorage(storageConfig.FromEnv())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	purger := service.NewRetentionPurger(
+		repository.NewProofCommand(writeDB),
+		repository.NewProofQuery(readDB),
+		storage,
+		retentionConfig.FromEnv(),
+	)
+
+	report, err := purger.PurgeLocked(context.Background(), time.Now(), *dryRun)
+	if errors.Is(err, constants.ErrLockHeld) {
+		log.Print("another instance is purging, skipped")
+		return
+	}
+	if report != nil {
+		for _, proof := range report.Proofs {
+			log.Printf("proof %d (%s, %s) expired at %s with %d attachments", proof.Idx, proof.Num, proof.Category, proof.ExpiredAt.Format(time.RFC3339), proof.Attachments)
+		}
+		log.Printf("found %d expired proofs, kept %d on legal hold", len(report.Proofs), report.Held)
+		if !*dryRun {
+			log.Printf("removed %d files and reclaimed %d bytes", report.Removed, report.ReclaimedBytes)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+}
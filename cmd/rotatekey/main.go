@@ -0,0 +1,33 @@
+// Package main is the command for re-wrapping the data keys of stored evidence with the primary master key.
+package main
+
+import (
+	"context"
+	"log"
+
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+func main() {
+	storageConfig := storagemanage.Config{}
+
+	storage, err := storagemanage.NewStorage(storageConfig.FromEnv())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	// 마스터 키가 설정되지 않았다면 교체할 데이터 키도 없습니다.
+	encrypted, ok := storage.(*storagemanage.Encrypted)
+	if !ok {
+		log.Fatal("storage encryption is not configured")
+		return
+	}
+
+	count, err := encrypted.Rotate(context.Background())
+	if err != nil {
+		log.Fatalf("rotated %d data keys before failing: %v", count, err)
+		return
+	}
+	log.Printf("rotated %d data keys", count)
+}
@@ -0,0 +1,60 @@
+// Package main is the command for creating the thumbnails of image evidence uploaded before thumbnails existed,
+// and for recording the hashes of evidence migrated before hashes were recorded.
+package main
+
+import (
+	"context"
+	"log"
+
+	"security-proof/internal/proof/repository"
+	"security-proof/internal/proof/service"
+	dbmanage "security-proof/pkg/manage/db"
+	filemanage "security-proof/pkg/manage/file"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+func main() {
+	writeConfig := dbmanage.WriteConfig{}
+	readConfig := dbmanage.ReadConfig{}
+	storageConfig := storagemanage.Config{}
+	uploadConfig := filemanage.Config{}
+
+	writeDB, err := dbmanage.NewDB(context.Background(), writeConfig.Dsn())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	readDB, err := dbmanage.NewDB(context.Background(), readConfig.Dsn())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	storage, err := storagemanage.NewStorage(storageConfig.FromEnv())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	backfill := service.NewThumbnailBackfill(
+		repository.NewProofCommand(writeDB),
+		repository.NewProofQuery(readDB),
+		storage,
+		filemanage.NewValidator(uploadConfig.FromEnv()),
+	)
+
+	count, err := backfill.Backfill(context.Background())
+	if err != nil {
+		log.Fatalf("created %d thumbnails before failing: %v", count, err)
+		return
+	}
+	log.Printf("created %d thumbnails", count)
+
+	count, err = backfill.BackfillHashes(context.Background())
+	if err != nil {
+		log.Fatalf("recorded %d hashes before failing: %v", count, err)
+		return
+	}
+	log.Printf("recorded %d hashes", count)
+}
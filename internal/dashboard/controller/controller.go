@@ -3,11 +3,17 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
 
 	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
 	"connectrpc.com/connect"
 
 	"security-proof/internal/dashboard/service"
+	"security-proof/pkg/constants"
 )
 
 // DashboardController struct is composed of query from the service layer.
@@ -37,3 +43,112 @@ func (c *DashboardController) ReadDashboard(ctx context.Context, req *connect.Re
 
 	return res, nil
 }
+
+// overdueProof struct is the JSON representation of an overdue proof.
+type overdueProof struct {
+	Idx             int32     `json:"idx"`
+	Num             string    `json:"num"`
+	Category        string    `json:"category"`
+	Description     string    `json:"description"`
+	UploadedUserIdx int32     `json:"uploadedUserIdx"`
+	DueAt           time.Time `json:"dueAt"`
+}
+
+// ReadOverdueProofs method is returning the proofs of the active cycle not confirmed by their due date.
+func (c *DashboardController) ReadOverdueProofs(w http.ResponseWriter, r *http.Request) {
+	proofs, err := c.dashboardQuery.ReadOverdueProofs(r.Context(), r.Header.Get("accessToken"))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrTokenValidate) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	result := make([]*overdueProof, len(proofs))
+	for i, proof := range proofs {
+		result[i] = &overdueProof{
+			Idx:         proof.Idx,
+			Category:    proof.Category,
+			Description: proof.Description,
+		}
+		if proof.Num != nil {
+			result[i].Num = *proof.Num
+		}
+		if proof.UploadedUserIdx != nil {
+			result[i].UploadedUserIdx = *proof.UploadedUserIdx
+		}
+		if proof.DueAt != nil {
+			result[i].DueAt = *proof.DueAt
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// integrityFailure struct is the JSON representation of an integrity check result that is not ok.
+type integrityFailure struct {
+	ProofIdx     int32   `json:"proofIdx"`
+	Num          string  `json:"num"`
+	Revision     int32   `json:"revision"`
+	Target       string  `json:"target"`
+	Position     *int32  `json:"position,omitempty"`
+	Status       string  `json:"status"`
+	ExpectedHash string  `json:"expectedHash"`
+	ActualHash   *string `json:"actualHash,omitempty"`
+}
+
+// integritySummary struct is the JSON representation of the latest integrity check.
+type integritySummary struct {
+	CheckedAt *time.Time          `json:"checkedAt,omitempty"`
+	OK        int                 `json:"ok"`
+	Mismatch  int                 `json:"mismatch"`
+	Missing   int                 `json:"missing"`
+	Failures  []*integrityFailure `json:"failures"`
+}
+
+// ReadIntegrity method is returning the summary of the latest integrity check of confirmed proofs.
+func (c *DashboardController) ReadIntegrity(w http.ResponseWriter, r *http.Request) {
+	summary, err := c.dashboardQuery.ReadIntegrity(r.Context(), r.Header.Get("accessToken"))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrTokenValidate) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	result := &integritySummary{
+		CheckedAt: summary.CheckedAt,
+		OK:        summary.OK,
+		Mismatch:  summary.Mismatch,
+		Missing:   summary.Missing,
+		Failures:  make([]*integrityFailure, len(summary.Failures)),
+	}
+	for i, check := range summary.Failures {
+		result.Failures[i] = &integrityFailure{
+			ProofIdx:     check.ProofIdx,
+			Revision:     check.Revision,
+			Target:       check.Target,
+			Position:     check.Position,
+			Status:       check.Status,
+			ExpectedHash: check.ExpectedHash,
+			ActualHash:   check.ActualHash,
+		}
+		if check.Num != nil {
+			result.Failures[i].Num = *check.Num
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
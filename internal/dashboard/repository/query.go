@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"security-proof/pkg/constants"
+	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
@@ -18,16 +19,47 @@ import (
 type DashboardQuerier interface {
 	ProofNotConfirmer
 	ProofNotUploader
+	ActiveCycleReader
+	ProofOverduer
+	ProofCounter
+	IntegrityReader
 }
 
 // ProofNotConfirmer interface is defining data related to querying unconfirmed items.
 type ProofNotConfirmer interface {
-	NotConfirmProof(ctx context.Context) (proofs []*model.Proof, err error)
+	NotConfirmProof(ctx context.Context, cycleIdx int32) (proofs []*model.Proof, err error)
 }
 
 // ProofNotUploader interface is defining data related to querying uploaded items.
 type ProofNotUploader interface {
-	NotUploadProof(ctx context.Context) (proofs []*model.Proof, err error)
+	NotUploadProof(ctx context.Context, cycleIdx int32) (proofs []*model.Proof, err error)
+}
+
+// ActiveCycleReader interface is defining data related to querying the active assessment cycle.
+type ActiveCycleReader interface {
+	ReadActiveCycle(ctx context.Context) (cycle *model.AssessmentCycle, err error)
+}
+
+// ProofOverduer interface is defining data related to querying overdue items.
+type ProofOverduer interface {
+	OverdueProof(ctx context.Context, cycleIdx int32, now time.Time) (proofs []*model.Proof, err error)
+}
+
+// ProofCounter interface is defining data related to counting uploaded and all items.
+type ProofCounter interface {
+	CountProofs(ctx context.Context, cycleIdx int32) (uploaded int32, all int32, err error)
+}
+
+// IntegrityReader interface is defining data related to querying the results of the latest integrity check.
+type IntegrityReader interface {
+	LatestIntegrityChecks(ctx context.Context) (checks []*IntegrityResult, err error)
+}
+
+// IntegrityResult struct is composed of an IntegrityCheck, and the number of its proof and the revision checked.
+type IntegrityResult struct {
+	model.IntegrityCheck
+	Num      *string
+	Revision int32
 }
 
 type dashboardQuery struct {
@@ -39,7 +71,7 @@ func NewDashboardQuery(db *sql.DB) DashboardQuerier {
 	return &dashboardQuery{db: db}
 }
 
-func (q *dashboardQuery) NotConfirmProof(ctx context.Context) ([]*model.Proof, error) {
+func (q *dashboardQuery) NotConfirmProof(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
 	listStmt := table.Proof.
 		SELECT(
 			table.Proof.Idx,
@@ -53,8 +85,15 @@ func (q *dashboardQuery) NotConfirmProof(ctx context.Context) ([]*model.Proof, e
 			table.Proof.UploadedUserIdx,
 			table.Proof.UploadedAt,
 			table.Proof.Confirm,
+			table.Proof.State,
+		).
+		WHERE(
+			table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)).
+				AND(table.Proof.State.IN(
+					postgres.Int32(constants.StateUploaded),
+					postgres.Int32(constants.StateInReview),
+				)),
 		).
-		WHERE(table.Proof.Confirm.EQ(postgres.Int32(constants.NotConfirm))).
 		LIMIT(10)
 
 	dest := make([]*model.Proof, 0)
@@ -68,7 +107,7 @@ func (q *dashboardQuery) NotConfirmProof(ctx context.Context) ([]*model.Proof, e
 	return dest, nil
 }
 
-func (q *dashboardQuery) NotUploadProof(ctx context.Context) ([]*model.Proof, error) {
+func (q *dashboardQuery) NotUploadProof(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
 	listStmt := table.Proof.
 		SELECT(
 			table.Proof.Idx,
@@ -82,8 +121,17 @@ func (q *dashboardQuery) NotUploadProof(ctx context.Context) ([]*model.Proof, er
 			table.Proof.UploadedUserIdx,
 			table.Proof.UploadedAt,
 			table.Proof.Confirm,
+			table.Proof.State,
+		).
+		WHERE(
+			table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)).
+				AND(table.Proof.State.IN(
+					postgres.Int32(constants.StateDraft),
+					postgres.Int32(constants.StateAssigned),
+					postgres.Int32(constants.StateRejected),
+					postgres.Int32(constants.StateReopened),
+				)),
 		).
-		WHERE(table.Proof.UploadedAt.IS_NULL()).
 		LIMIT(10)
 
 	dest := make([]*model.Proof, 0)
@@ -96,3 +144,102 @@ func (q *dashboardQuery) NotUploadProof(ctx context.Context) ([]*model.Proof, er
 
 	return dest, nil
 }
+
+func (q *dashboardQuery) ReadActiveCycle(ctx context.Context) (*model.AssessmentCycle, error) {
+	readStmt := table.AssessmentCycle.
+		SELECT(table.AssessmentCycle.AllColumns).
+		WHERE(table.AssessmentCycle.EndedAt.IS_NULL()).
+		LIMIT(1)
+
+	dest := &model.AssessmentCycle{}
+	err := readStmt.QueryContext(ctx, q.db, dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *dashboardQuery) OverdueProof(ctx context.Context, cycleIdx int32, now time.Time) ([]*model.Proof, error) {
+	listStmt := table.Proof.
+		SELECT(
+			table.Proof.Idx,
+			table.Proof.Num,
+			table.Proof.Category,
+			table.Proof.Description,
+			table.Proof.CreatedUserIdx,
+			table.Proof.UploadedUserIdx,
+			table.Proof.State,
+			table.Proof.DueAt,
+		).
+		WHERE(
+			table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)).
+				AND(table.Proof.DueAt.LT(postgres.TimestampzT(now))).
+				AND(table.Proof.State.NOT_IN(
+					postgres.Int32(constants.StateConfirmed),
+					postgres.Int32(constants.StateExpired),
+				)),
+		).
+		ORDER_BY(table.Proof.DueAt.ASC()).
+		LIMIT(10)
+
+	dest := make([]*model.Proof, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// CountProofs method counts the proofs of a cycle that have been uploaded and all the proofs of the cycle.
+func (q *dashboardQuery) CountProofs(ctx context.Context, cycleIdx int32) (int32, int32, error) {
+	countStmt := table.Proof.
+		SELECT(
+			postgres.COUNT(table.Proof.UploadedAt).AS("proof_count.uploaded"),
+			postgres.COUNT(table.Proof.Idx).AS("proof_count.all"),
+		).
+		WHERE(table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)))
+
+	type proofCount struct {
+		Uploaded int64
+		All      int64
+	}
+
+	dest := &proofCount{}
+	err := countStmt.QueryContext(ctx, q.db, dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return 0, 0, errors.Join(constants.ErrQuery, err)
+	}
+
+	return int32(dest.Uploaded), int32(dest.All), nil
+}
+
+// LatestIntegrityChecks method lists every result of the latest integrity check, which share the same check time.
+func (q *dashboardQuery) LatestIntegrityChecks(ctx context.Context) ([]*IntegrityResult, error) {
+	latest := table.IntegrityCheck.
+		SELECT(postgres.MAX(table.IntegrityCheck.CheckedAt))
+
+	listStmt := table.IntegrityCheck.
+		INNER_JOIN(table.Proof, table.Proof.Idx.EQ(table.IntegrityCheck.ProofIdx)).
+		INNER_JOIN(table.ProofRevision, table.ProofRevision.Idx.EQ(table.IntegrityCheck.RevisionIdx)).
+		SELECT(
+			table.IntegrityCheck.AllColumns,
+			table.Proof.Num.AS("integrity_result.num"),
+			table.ProofRevision.Revision.AS("integrity_result.revision"),
+		).
+		WHERE(table.IntegrityCheck.CheckedAt.EQ(postgres.TimestampzExp(latest))).
+		ORDER_BY(table.IntegrityCheck.ProofIdx.ASC(), table.IntegrityCheck.Idx.ASC())
+
+	dest := make([]*IntegrityResult, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
@@ -2,22 +2,47 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"security-proof/internal/db/security_proof/proof/model"
 )
 
 // MockDashboardQuery struct is used for testing the dashboardQuery structure.
 type MockDashboardQuery struct {
-	NotConfirmProofFn func(ctx context.Context) ([]*model.Proof, error)
-	NotUploadProofFn  func(ctx context.Context) ([]*model.Proof, error)
+	NotConfirmProofFn       func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error)
+	NotUploadProofFn        func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error)
+	ReadActiveCycleFn       func(ctx context.Context) (*model.AssessmentCycle, error)
+	OverdueProofFn          func(ctx context.Context, cycleIdx int32, now time.Time) ([]*model.Proof, error)
+	CountProofsFn           func(ctx context.Context, cycleIdx int32) (int32, int32, error)
+	LatestIntegrityChecksFn func(ctx context.Context) ([]*IntegrityResult, error)
 }
 
 // NotConfirmProof method is the mock test function for NotConfirmProof.
-func (m *MockDashboardQuery) NotConfirmProof(ctx context.Context) ([]*model.Proof, error) {
-	return m.NotConfirmProofFn(ctx)
+func (m *MockDashboardQuery) NotConfirmProof(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+	return m.NotConfirmProofFn(ctx, cycleIdx)
 }
 
 // NotUploadProof method is the mock test function for NotUploadProof.
-func (m *MockDashboardQuery) NotUploadProof(ctx context.Context) ([]*model.Proof, error) {
-	return m.NotUploadProofFn(ctx)
+func (m *MockDashboardQuery) NotUploadProof(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+	return m.NotUploadProofFn(ctx, cycleIdx)
+}
+
+// ReadActiveCycle method is the mock test function for ReadActiveCycle.
+func (m *MockDashboardQuery) ReadActiveCycle(ctx context.Context) (*model.AssessmentCycle, error) {
+	return m.ReadActiveCycleFn(ctx)
+}
+
+// OverdueProof method is the mock test function for OverdueProof.
+func (m *MockDashboardQuery) OverdueProof(ctx context.Context, cycleIdx int32, now time.Time) ([]*model.Proof, error) {
+	return m.OverdueProofFn(ctx, cycleIdx, now)
+}
+
+// CountProofs method is the mock test function for CountProofs.
+func (m *MockDashboardQuery) CountProofs(ctx context.Context, cycleIdx int32) (int32, int32, error) {
+	return m.CountProofsFn(ctx, cycleIdx)
+}
+
+// LatestIntegrityChecks method is the mock test function for LatestIntegrityChecks.
+func (m *MockDashboardQuery) LatestIntegrityChecks(ctx context.Context) ([]*IntegrityResult, error) {
+	return m.LatestIntegrityChecksFn(ctx)
 }
@@ -4,6 +4,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"buf.build/gen/go/wanho/security-proof-api/connectrpc/go/api/v1/apiv1connect"
 	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
@@ -12,35 +13,38 @@ import (
 	"security-proof/internal/dashboard/convert"
 	"security-proof/internal/dashboard/repository"
 	"security-proof/internal/db/security_proof/proof/model"
-	"security-proof/internal/db/security_proof/proof/table"
 	"security-proof/pkg/auth"
 	"security-proof/pkg/constants"
-	elasticmanage "security-proof/pkg/manage/elastic"
 )
 
 var conv = convert.ServiceConverterImpl{}
 
-// DashboardQuery struct is composed of a token, a DashboardQuerier, a user client and an elastic.
+// DashboardQuery struct is composed of a token, a DashboardQuerier and a user client.
 type DashboardQuery struct {
 	token          *auth.Token
 	dashboardQuery repository.DashboardQuerier
-	elastic        elasticmanage.Elastic
 	user           apiv1connect.UserServiceClient
 }
 
-// NewDashboardService function is returning a DashboardQuery interface accepting a token, a DashboardQuerier, a user client and an Elastic.
-func NewDashboardService(token *auth.Token, dashboardQuery repository.DashboardQuerier, elastic elasticmanage.Elastic, user apiv1connect.UserServiceClient) *DashboardQuery {
-	return &DashboardQuery{token: token, dashboardQuery: dashboardQuery, elastic: elastic, user: user}
+// NewDashboardService function is returning a DashboardQuery interface accepting a token, a DashboardQuerier and a user client.
+func NewDashboardService(token *auth.Token, dashboardQuery repository.DashboardQuerier, user apiv1connect.UserServiceClient) *DashboardQuery {
+	return &DashboardQuery{token: token, dashboardQuery: dashboardQuery, user: user}
 }
 
 // ReadDashboard method is returning a NotConfirmProofs, a NotUploadProofs, a CountUploadProofs, an error, accepting a context and access token.
+// Every item is scoped to the active assessment cycle.
 func (q *DashboardQuery) ReadDashboard(ctx context.Context, accessToken string) ([]*apiv1.NotConfirmProof, []*apiv1.NotUploadProof, []*apiv1.CountUploadProof, error) {
 	_, _, err := q.token.ValidateToken(accessToken)
 	if err != nil {
 		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
 	}
 
-	notConfirmProof, err := q.dashboardQuery.NotConfirmProof(ctx)
+	cycle, err := q.dashboardQuery.ReadActiveCycle(ctx)
+	if err != nil {
+		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
+	}
+
+	notConfirmProof, err := q.dashboardQuery.NotConfirmProof(ctx, cycle.Idx)
 	if err != nil {
 		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
 	}
@@ -50,7 +54,7 @@ func (q *DashboardQuery) ReadDashboard(ctx context.Context, accessToken string)
 		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
 	}
 
-	notUploadProofs, err := q.dashboardQuery.NotUploadProof(ctx)
+	notUploadProofs, err := q.dashboardQuery.NotUploadProof(ctx, cycle.Idx)
 	if err != nil {
 		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
 	}
@@ -60,7 +64,8 @@ func (q *DashboardQuery) ReadDashboard(ctx context.Context, accessToken string)
 		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
 	}
 
-	uploadProofs, err := q.elastic.CountExist(ctx, table.Proof.UploadedAt.Name())
+	// 검색 색인에는 평가 주기가 없으므로 증적 수도 다른 항목처럼 데이터베이스에서 주기별로 셉니다.
+	uploadProofs, allProofs, err := q.dashboardQuery.CountProofs(ctx, cycle.Idx)
 	if err != nil {
 		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
 	}
@@ -71,10 +76,6 @@ func (q *DashboardQuery) ReadDashboard(ctx context.Context, accessToken string)
 		Count: uploadProofs,
 	})
 
-	allProofs, err := q.elastic.CountAll(ctx)
-	if err != nil {
-		return nil, nil, nil, errors.Join(constants.ErrDashboardRead, err)
-	}
 	countUploadProofs = append(countUploadProofs, &apiv1.CountUploadProof{
 		Title: "모든 증적",
 		Count: allProofs,
@@ -83,29 +84,87 @@ func (q *DashboardQuery) ReadDashboard(ctx context.Context, accessToken string)
 	return notConfirmResult, notUploadResult, countUploadProofs, nil
 }
 
+// ReadOverdueProofs method is returning proofs and an error, accepting a context and an access token.
+// The proofs of the active cycle not confirmed by their due date are listed, the earliest due date first.
+func (q *DashboardQuery) ReadOverdueProofs(ctx context.Context, accessToken string) ([]*model.Proof, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrDashboardRead, err)
+	}
+
+	cycle, err := q.dashboardQuery.ReadActiveCycle(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrDashboardRead, err)
+	}
+
+	proofs, err := q.dashboardQuery.OverdueProof(ctx, cycle.Idx, time.Now())
+	if err != nil {
+		return nil, errors.Join(constants.ErrDashboardRead, err)
+	}
+
+	return proofs, nil
+}
+
+// IntegritySummary struct is composed of the time of the latest integrity check, which is nil before the first check,
+// the number of results of each status and the results that are not ok.
+type IntegritySummary struct {
+	CheckedAt *time.Time
+	OK        int
+	Mismatch  int
+	Missing   int
+	Failures  []*repository.IntegrityResult
+}
+
+// ReadIntegrity method is returning an IntegritySummary and an error, accepting a context and an access token.
+// Only the results of the latest integrity check of confirmed or expired evidence are summarised.
+func (q *DashboardQuery) ReadIntegrity(ctx context.Context, accessToken string) (*IntegritySummary, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrDashboardRead, err)
+	}
+
+	checks, err := q.dashboardQuery.LatestIntegrityChecks(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrDashboardRead, constants.ErrIntegrityRead, err)
+	}
+
+	summary := &IntegritySummary{Failures: make([]*repository.IntegrityResult, 0)}
+	for _, check := range checks {
+		summary.CheckedAt = &check.CheckedAt
+		switch check.Status {
+		case constants.IntegrityOK:
+			summary.OK++
+			continue
+		case constants.IntegrityMismatch:
+			summary.Mismatch++
+		case constants.IntegrityMissing:
+			summary.Missing++
+		}
+		summary.Failures = append(summary.Failures, check)
+	}
+
+	return summary, nil
+}
+
 func (q *DashboardQuery) readNotConfirmUser(ctx context.Context, accessToken string, notConfirmProofs []*model.Proof) ([]*apiv1.NotConfirmProof, error) {
 	notConfirmResult := make([]*apiv1.NotConfirmProof, len(notConfirmProofs))
 	for i, proof := range notConfirmProofs {
 
 		notConfirmResult[i] = conv.ModelToNotConfirmProto(proof)
 
-		UploadedUserReq := connect.NewRequest(&apiv1.ReadUserRequest{Idx: *proof.UploadedUserIdx})
-		UploadedUserReq.Header().Set("accessToken", accessToken)
-		UploadedUser, err := q.user.ReadUser(ctx, UploadedUserReq)
+		uploadedUserID, err := q.readUserID(ctx, accessToken, proof.UploadedUserIdx)
 		if err != nil {
 			return nil, errors.Join(constants.ErrDashboardRead, err)
 		}
 
-		notConfirmResult[i].UploadedUserId = UploadedUser.Msg.User.Id
+		notConfirmResult[i].UploadedUserId = uploadedUserID
 
-		CreatedUserReq := connect.NewRequest(&apiv1.ReadUserRequest{Idx: *proof.CreatedUserIdx})
-		CreatedUserReq.Header().Set("accessToken", accessToken)
-		CreatedUser, err := q.user.ReadUser(ctx, CreatedUserReq)
+		createdUserID, err := q.readUserID(ctx, accessToken, proof.CreatedUserIdx)
 		if err != nil {
 			return nil, errors.Join(constants.ErrDashboardRead, err)
 		}
 
-		notConfirmResult[i].CreatedUserId = CreatedUser.Msg.User.Id
+		notConfirmResult[i].CreatedUserId = createdUserID
 	}
 
 	return notConfirmResult, nil
@@ -116,24 +175,37 @@ func (q *DashboardQuery) readNotUploadUser(ctx context.Context, accessToken stri
 	for i, proof := range notUploadProofs {
 		notUploadResult[i] = conv.ModelToNotUploadProto(proof)
 
-		UploadedUserReq := connect.NewRequest(&apiv1.ReadUserRequest{Idx: *proof.UploadedUserIdx})
-		UploadedUserReq.Header().Set("accessToken", accessToken)
-		UploadedUser, err := q.user.ReadUser(ctx, UploadedUserReq)
+		uploadedUserID, err := q.readUserID(ctx, accessToken, proof.UploadedUserIdx)
 		if err != nil {
 			return nil, errors.Join(constants.ErrDashboardRead, err)
 		}
 
-		notUploadResult[i].UploadedUserId = UploadedUser.Msg.User.Id
+		notUploadResult[i].UploadedUserId = uploadedUserID
 
-		CreatedUserReq := connect.NewRequest(&apiv1.ReadUserRequest{Idx: *proof.CreatedUserIdx})
-		CreatedUserReq.Header().Set("accessToken", accessToken)
-		CreatedUser, err := q.user.ReadUser(ctx, CreatedUserReq)
+		createdUserID, err := q.readUserID(ctx, accessToken, proof.CreatedUserIdx)
 		if err != nil {
 			return nil, errors.Join(constants.ErrDashboardRead, err)
 		}
 
-		notUploadResult[i].CreatedUserId = CreatedUser.Msg.User.Id
+		notUploadResult[i].CreatedUserId = createdUserID
 	}
 
 	return notUploadResult, nil
 }
+
+// readUserID method is returning a user ID and an error, accepting a context, an access token and a user index.
+// The ID is empty when no user is recorded, such as a draft proof without an assignee.
+func (q *DashboardQuery) readUserID(ctx context.Context, accessToken string, idx *int32) (string, error) {
+	if idx == nil {
+		return "", nil
+	}
+
+	req := connect.NewRequest(&apiv1.ReadUserRequest{Idx: *idx})
+	req.Header().Set("accessToken", accessToken)
+	user, err := q.user.ReadUser(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return user.Msg.User.Id, nil
+}
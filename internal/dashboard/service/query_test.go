@@ -1 +1,251 @@
 package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/dashboard/repository"
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/auth"
+	"security-proof/pkg/constants"
+	usermanage "security-proof/pkg/manage/user"
+)
+
+// mockCycleIdx is the index of the active cycle returned by the mock DashboardQuerier.
+const mockCycleIdx int32 = 2
+
+func TestDashboardQuery_ReadDashboard(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("진행 중인 심사 주기 대시보드 조회 케이스", func(t *testing.T) {
+		scoped := make([]int32, 0)
+		querier := *mockQuery
+		querier.NotConfirmProofFn = func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+			scoped = append(scoped, cycleIdx)
+			return []*model.Proof{mockProof(1, constants.StateUploaded)}, nil
+		}
+		querier.NotUploadProofFn = func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+			scoped = append(scoped, cycleIdx)
+			return []*model.Proof{mockProof(2, constants.StateAssigned)}, nil
+		}
+		querier.CountProofsFn = func(ctx context.Context, cycleIdx int32) (int32, int32, error) {
+			scoped = append(scoped, cycleIdx)
+			return 3, 5, nil
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		notConfirm, notUpload, counts, err := query.ReadDashboard(context.Background(), accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []int32{mockCycleIdx, mockCycleIdx, mockCycleIdx}, scoped, "모든 항목이 진행 중인 심사 주기로 조회되었습니다.")
+
+		assert.Len(t, notConfirm, 1)
+		assert.Equal(t, "user3", notConfirm[0].UploadedUserId, "담당자 아이디가 채워졌습니다.")
+		assert.Equal(t, "user1", notConfirm[0].CreatedUserId, "생성자 아이디가 채워졌습니다.")
+		assert.Len(t, notUpload, 1)
+
+		assert.Equal(t, []int32{3, 5}, []int32{counts[0].Count, counts[1].Count}, "업로드된 증적과 모든 증적 수가 조회되었습니다.")
+	})
+
+	t.Run("진행 중인 심사 주기가 없는 케이스", func(t *testing.T) {
+		querier := *mockQuery
+		querier.ReadActiveCycleFn = func(ctx context.Context) (*model.AssessmentCycle, error) {
+			return nil, constants.ErrItemNotFound
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		_, _, _, err := query.ReadDashboard(context.Background(), accessToken)
+		assert.ErrorIs(t, err, constants.ErrDashboardRead)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
+
+	t.Run("담당자가 없는 초안 증적 케이스", func(t *testing.T) {
+		draft := mockProof(2, constants.StateDraft)
+		draft.UploadedUserIdx = nil
+		querier := *mockQuery
+		querier.NotUploadProofFn = func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+			return []*model.Proof{draft}, nil
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		_, notUpload, _, err := query.ReadDashboard(context.Background(), accessToken)
+		assert.NoError(t, err, "담당자가 없는 증적도 에러 없이 조회되었습니다.")
+		assert.Empty(t, notUpload[0].UploadedUserId, "담당자 아이디는 비어 있습니다.")
+		assert.Equal(t, "user1", notUpload[0].CreatedUserId)
+	})
+
+	t.Run("증적 수 조회 실패 케이스", func(t *testing.T) {
+		querier := *mockQuery
+		querier.CountProofsFn = func(ctx context.Context, cycleIdx int32) (int32, int32, error) {
+			return 0, 0, constants.ErrQuery
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		_, _, _, err := query.ReadDashboard(context.Background(), accessToken)
+		assert.ErrorIs(t, err, constants.ErrQuery)
+	})
+}
+
+func TestDashboardQuery_ReadOverdueProofs(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("기한이 지난 증적 조회 케이스", func(t *testing.T) {
+		var scopedCycle int32
+		var scopedNow time.Time
+		querier := *mockQuery
+		querier.OverdueProofFn = func(ctx context.Context, cycleIdx int32, now time.Time) ([]*model.Proof, error) {
+			scopedCycle, scopedNow = cycleIdx, now
+			return []*model.Proof{mockProof(1, constants.StateAssigned)}, nil
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		before := time.Now()
+		proofs, err := query.ReadOverdueProofs(context.Background(), accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, proofs, 1, "기한이 지난 증적이 조회되었습니다.")
+		assert.Equal(t, mockCycleIdx, scopedCycle, "진행 중인 심사 주기의 증적만 조회되었습니다.")
+		assert.False(t, scopedNow.Before(before), "현재 시각을 기준으로 기한을 비교했습니다.")
+	})
+
+	t.Run("진행 중인 심사 주기가 없는 케이스", func(t *testing.T) {
+		querier := *mockQuery
+		querier.ReadActiveCycleFn = func(ctx context.Context) (*model.AssessmentCycle, error) {
+			return nil, constants.ErrItemNotFound
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		_, err := query.ReadOverdueProofs(context.Background(), accessToken)
+		assert.ErrorIs(t, err, constants.ErrDashboardRead)
+	})
+
+	t.Run("유효하지 않은 토큰 케이스", func(t *testing.T) {
+		query := NewDashboardService(mockToken, mockQuery, mockUserClient)
+
+		_, err := query.ReadOverdueProofs(context.Background(), "invalid")
+		assert.ErrorIs(t, err, constants.ErrDashboardRead)
+	})
+}
+
+func TestDashboardQuery_ReadIntegrity(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("최근 무결성 검사 요약 케이스", func(t *testing.T) {
+		checkedAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		querier := *mockQuery
+		querier.LatestIntegrityChecksFn = func(ctx context.Context) ([]*repository.IntegrityResult, error) {
+			return []*repository.IntegrityResult{
+				mockIntegrityResult(1, constants.IntegrityOK, checkedAt),
+				mockIntegrityResult(2, constants.IntegrityMismatch, checkedAt),
+				mockIntegrityResult(3, constants.IntegrityMissing, checkedAt),
+				mockIntegrityResult(4, constants.IntegrityOK, checkedAt),
+			}, nil
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		summary, err := query.ReadIntegrity(context.Background(), accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []int{2, 1, 1}, []int{summary.OK, summary.Mismatch, summary.Missing}, "상태별 검사 결과 수가 집계되었습니다.")
+		assert.Equal(t, checkedAt, *summary.CheckedAt, "최근 검사 시각이 조회되었습니다.")
+		assert.Len(t, summary.Failures, 2, "실패한 검사 결과만 목록에 담겼습니다.")
+		assert.Equal(t, int32(2), summary.Failures[0].ProofIdx)
+	})
+
+	t.Run("검사 결과가 없는 케이스", func(t *testing.T) {
+		query := NewDashboardService(mockToken, mockQuery, mockUserClient)
+
+		summary, err := query.ReadIntegrity(context.Background(), accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Nil(t, summary.CheckedAt, "검사한 적이 없으면 검사 시각이 비어 있습니다.")
+		assert.Empty(t, summary.Failures)
+	})
+
+	t.Run("검사 결과 조회 실패 케이스", func(t *testing.T) {
+		querier := *mockQuery
+		querier.LatestIntegrityChecksFn = func(ctx context.Context) ([]*repository.IntegrityResult, error) {
+			return nil, constants.ErrQuery
+		}
+		query := NewDashboardService(mockToken, &querier, mockUserClient)
+
+		_, err := query.ReadIntegrity(context.Background(), accessToken)
+		assert.ErrorIs(t, err, constants.ErrIntegrityRead)
+		assert.ErrorIs(t, err, constants.ErrQuery)
+	})
+}
+
+// mockProof function is returning a Proof of the active cycle, accepting an index and a state.
+func mockProof(idx int32, state int32) *model.Proof {
+	createdUserIdx, uploadedUserIdx := int32(1), int32(3)
+	num := fmt.Sprintf("P-%d", idx)
+	return &model.Proof{
+		Idx:             idx,
+		Num:             &num,
+		CreatedUserIdx:  &createdUserIdx,
+		CreatedAt:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		UploadedUserIdx: &uploadedUserIdx,
+		State:           state,
+		CycleIdx:        mockCycleIdx,
+	}
+}
+
+// mockIntegrityResult function is returning an IntegrityResult of the latest revision of a proof, accepting a proof index, a status and a check time.
+func mockIntegrityResult(proofIdx int32, status string, checkedAt time.Time) *repository.IntegrityResult {
+	num := fmt.Sprintf("P-%d", proofIdx)
+	return &repository.IntegrityResult{
+		IntegrityCheck: model.IntegrityCheck{
+			Idx:          proofIdx,
+			ProofIdx:     proofIdx,
+			RevisionIdx:  proofIdx,
+			Target:       constants.IntegrityFile,
+			Status:       status,
+			ExpectedHash: "aaaa",
+			CheckedAt:    checkedAt,
+		},
+		Num:      &num,
+		Revision: 1,
+	}
+}
+
+var mockQuery = &repository.MockDashboardQuery{
+	ReadActiveCycleFn: func(ctx context.Context) (*model.AssessmentCycle, error) {
+		return &model.AssessmentCycle{Idx: mockCycleIdx, Name: "2026"}, nil
+	},
+	NotConfirmProofFn: func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+		return []*model.Proof{}, nil
+	},
+	NotUploadProofFn: func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+		return []*model.Proof{}, nil
+	},
+	CountProofsFn: func(ctx context.Context, cycleIdx int32) (int32, int32, error) {
+		return 0, 0, nil
+	},
+	OverdueProofFn: func(ctx context.Context, cycleIdx int32, now time.Time) ([]*model.Proof, error) {
+		return []*model.Proof{}, nil
+	},
+	LatestIntegrityChecksFn: func(ctx context.Context) ([]*repository.IntegrityResult, error) {
+		return []*repository.IntegrityResult{}, nil
+	},
+}
+
+var mockTokenRepo = &auth.MockTokenRepo{
+	SaveTokenFn:      func(ctx context.Context, token string) error { return nil },
+	ReadTokenByIdxFn: func(ctx context.Context, idx string) (string, error) { return "", nil },
+	DeleteTokenFn:    func(ctx context.Context, idx string) error { return nil },
+}
+
+var mockToken = auth.NewToken(mockTokenRepo)
+
+var mockUserClient = &usermanage.MockUser{
+	ReadUserFn: func(ctx context.Context, req *connect.Request[apiv1.ReadUserRequest]) (*connect.Response[apiv1.ReadUserResponse], error) {
+		return connect.NewResponse(&apiv1.ReadUserResponse{
+			User: &apiv1.User{Idx: req.Msg.Idx, Id: fmt.Sprintf("user%d", req.Msg.Idx)},
+		}), nil
+	},
+}
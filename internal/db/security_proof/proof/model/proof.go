@@ -12,19 +12,29 @@ import (
 )
 
 type Proof struct {
-	Idx             int32
-	Category        string
-	Description     string
-	FirstImagePath  *string
-	SecondImagePath *string
-	LogPath         *string
-	CreatedUserIdx  *int32
-	CreatedAt       time.Time
-	UpdatedUserIdx  *int32
-	UpdatedAt       *time.Time
-	UploadedUserIdx *int32
-	UploadedAt      *time.Time
-	Confirm         int32
-	Num             *string
-	TokenID         *int32
+	Idx              int32
+	Category         string
+	Description      string
+	FirstImagePath   *string
+	SecondImagePath  *string
+	LogPath          *string
+	CreatedUserIdx   *int32
+	CreatedAt        time.Time
+	UpdatedUserIdx   *int32
+	UpdatedAt        *time.Time
+	UploadedUserIdx  *int32
+	UploadedAt       *time.Time
+	Confirm          int32
+	Num              *string
+	TokenID          *int32
+	State            int32
+	CycleIdx         int32
+	DueAt            *time.Time
+	RemindedAt       *time.Time
+	LegalHold        bool
+	LegalHoldReason  *string
+	LegalHoldUserIdx *int32
+	LegalHoldAt      *time.Time
+	PurgedAt         *time.Time
+	SourceProofIdx   *int32
 }
@@ -17,21 +17,31 @@ type proofTable struct {
 	postgres.Table
 
 	// Columns
-	Idx             postgres.ColumnInteger
-	Category        postgres.ColumnString
-	Description     postgres.ColumnString
-	FirstImagePath  postgres.ColumnString
-	SecondImagePath postgres.ColumnString
-	LogPath         postgres.ColumnString
-	CreatedUserIdx  postgres.ColumnInteger
-	CreatedAt       postgres.ColumnTimestampz
-	UpdatedUserIdx  postgres.ColumnInteger
-	UpdatedAt       postgres.ColumnTimestampz
-	UploadedUserIdx postgres.ColumnInteger
-	UploadedAt      postgres.ColumnTimestampz
-	Confirm         postgres.ColumnInteger
-	Num             postgres.ColumnString
-	TokenID         postgres.ColumnInteger
+	Idx              postgres.ColumnInteger
+	Category         postgres.ColumnString
+	Description      postgres.ColumnString
+	FirstImagePath   postgres.ColumnString
+	SecondImagePath  postgres.ColumnString
+	LogPath          postgres.ColumnString
+	CreatedUserIdx   postgres.ColumnInteger
+	CreatedAt        postgres.ColumnTimestampz
+	UpdatedUserIdx   postgres.ColumnInteger
+	UpdatedAt        postgres.ColumnTimestampz
+	UploadedUserIdx  postgres.ColumnInteger
+	UploadedAt       postgres.ColumnTimestampz
+	Confirm          postgres.ColumnInteger
+	Num              postgres.ColumnString
+	TokenID          postgres.ColumnInteger
+	State            postgres.ColumnInteger
+	CycleIdx         postgres.ColumnInteger
+	DueAt            postgres.ColumnTimestampz
+	RemindedAt       postgres.ColumnTimestampz
+	LegalHold        postgres.ColumnBool
+	LegalHoldReason  postgres.ColumnString
+	LegalHoldUserIdx postgres.ColumnInteger
+	LegalHoldAt      postgres.ColumnTimestampz
+	PurgedAt         postgres.ColumnTimestampz
+	SourceProofIdx   postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -72,44 +82,64 @@ func newProofTable(schemaName, tableName, alias string) *ProofTable {
 
 func newProofTableImpl(schemaName, tableName, alias string) proofTable {
 	var (
-		IdxColumn             = postgres.IntegerColumn("idx")
-		CategoryColumn        = postgres.StringColumn("category")
-		DescriptionColumn     = postgres.StringColumn("description")
-		FirstImagePathColumn  = postgres.StringColumn("first_image_path")
-		SecondImagePathColumn = postgres.StringColumn("second_image_path")
-		LogPathColumn         = postgres.StringColumn("log_path")
-		CreatedUserIdxColumn  = postgres.IntegerColumn("created_user_idx")
-		CreatedAtColumn       = postgres.TimestampzColumn("created_at")
-		UpdatedUserIdxColumn  = postgres.IntegerColumn("updated_user_idx")
-		UpdatedAtColumn       = postgres.TimestampzColumn("updated_at")
-		UploadedUserIdxColumn = postgres.IntegerColumn("uploaded_user_idx")
-		UploadedAtColumn      = postgres.TimestampzColumn("uploaded_at")
-		ConfirmColumn         = postgres.IntegerColumn("confirm")
-		NumColumn             = postgres.StringColumn("num")
-		TokenIDColumn         = postgres.IntegerColumn("token_id")
-		allColumns            = postgres.ColumnList{IdxColumn, CategoryColumn, DescriptionColumn, FirstImagePathColumn, SecondImagePathColumn, LogPathColumn, CreatedUserIdxColumn, CreatedAtColumn, UpdatedUserIdxColumn, UpdatedAtColumn, UploadedUserIdxColumn, UploadedAtColumn, ConfirmColumn, NumColumn, TokenIDColumn}
-		mutableColumns        = postgres.ColumnList{IdxColumn, CategoryColumn, DescriptionColumn, FirstImagePathColumn, SecondImagePathColumn, LogPathColumn, CreatedUserIdxColumn, CreatedAtColumn, UpdatedUserIdxColumn, UpdatedAtColumn, UploadedUserIdxColumn, UploadedAtColumn, ConfirmColumn, NumColumn, TokenIDColumn}
+		IdxColumn              = postgres.IntegerColumn("idx")
+		CategoryColumn         = postgres.StringColumn("category")
+		DescriptionColumn      = postgres.StringColumn("description")
+		FirstImagePathColumn   = postgres.StringColumn("first_image_path")
+		SecondImagePathColumn  = postgres.StringColumn("second_image_path")
+		LogPathColumn          = postgres.StringColumn("log_path")
+		CreatedUserIdxColumn   = postgres.IntegerColumn("created_user_idx")
+		CreatedAtColumn        = postgres.TimestampzColumn("created_at")
+		UpdatedUserIdxColumn   = postgres.IntegerColumn("updated_user_idx")
+		UpdatedAtColumn        = postgres.TimestampzColumn("updated_at")
+		UploadedUserIdxColumn  = postgres.IntegerColumn("uploaded_user_idx")
+		UploadedAtColumn       = postgres.TimestampzColumn("uploaded_at")
+		ConfirmColumn          = postgres.IntegerColumn("confirm")
+		NumColumn              = postgres.StringColumn("num")
+		TokenIDColumn          = postgres.IntegerColumn("token_id")
+		StateColumn            = postgres.IntegerColumn("state")
+		CycleIdxColumn         = postgres.IntegerColumn("cycle_idx")
+		DueAtColumn            = postgres.TimestampzColumn("due_at")
+		RemindedAtColumn       = postgres.TimestampzColumn("reminded_at")
+		LegalHoldColumn        = postgres.BoolColumn("legal_hold")
+		LegalHoldReasonColumn  = postgres.StringColumn("legal_hold_reason")
+		LegalHoldUserIdxColumn = postgres.IntegerColumn("legal_hold_user_idx")
+		LegalHoldAtColumn      = postgres.TimestampzColumn("legal_hold_at")
+		PurgedAtColumn         = postgres.TimestampzColumn("purged_at")
+		SourceProofIdxColumn   = postgres.IntegerColumn("source_proof_idx")
+		allColumns             = postgres.ColumnList{IdxColumn, CategoryColumn, DescriptionColumn, FirstImagePathColumn, SecondImagePathColumn, LogPathColumn, CreatedUserIdxColumn, CreatedAtColumn, UpdatedUserIdxColumn, UpdatedAtColumn, UploadedUserIdxColumn, UploadedAtColumn, ConfirmColumn, NumColumn, TokenIDColumn, StateColumn, CycleIdxColumn, DueAtColumn, RemindedAtColumn, LegalHoldColumn, LegalHoldReasonColumn, LegalHoldUserIdxColumn, LegalHoldAtColumn, PurgedAtColumn, SourceProofIdxColumn}
+		mutableColumns         = postgres.ColumnList{IdxColumn, CategoryColumn, DescriptionColumn, FirstImagePathColumn, SecondImagePathColumn, LogPathColumn, CreatedUserIdxColumn, CreatedAtColumn, UpdatedUserIdxColumn, UpdatedAtColumn, UploadedUserIdxColumn, UploadedAtColumn, ConfirmColumn, NumColumn, TokenIDColumn, StateColumn, CycleIdxColumn, DueAtColumn, RemindedAtColumn, LegalHoldColumn, LegalHoldReasonColumn, LegalHoldUserIdxColumn, LegalHoldAtColumn, PurgedAtColumn, SourceProofIdxColumn}
 	)
 
 	return proofTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		Idx:             IdxColumn,
-		Category:        CategoryColumn,
-		Description:     DescriptionColumn,
-		FirstImagePath:  FirstImagePathColumn,
-		SecondImagePath: SecondImagePathColumn,
-		LogPath:         LogPathColumn,
-		CreatedUserIdx:  CreatedUserIdxColumn,
-		CreatedAt:       CreatedAtColumn,
-		UpdatedUserIdx:  UpdatedUserIdxColumn,
-		UpdatedAt:       UpdatedAtColumn,
-		UploadedUserIdx: UploadedUserIdxColumn,
-		UploadedAt:      UploadedAtColumn,
-		Confirm:         ConfirmColumn,
-		Num:             NumColumn,
-		TokenID:         TokenIDColumn,
+		Idx:              IdxColumn,
+		Category:         CategoryColumn,
+		Description:      DescriptionColumn,
+		FirstImagePath:   FirstImagePathColumn,
+		SecondImagePath:  SecondImagePathColumn,
+		LogPath:          LogPathColumn,
+		CreatedUserIdx:   CreatedUserIdxColumn,
+		CreatedAt:        CreatedAtColumn,
+		UpdatedUserIdx:   UpdatedUserIdxColumn,
+		UpdatedAt:        UpdatedAtColumn,
+		UploadedUserIdx:  UploadedUserIdxColumn,
+		UploadedAt:       UploadedAtColumn,
+		Confirm:          ConfirmColumn,
+		Num:              NumColumn,
+		TokenID:          TokenIDColumn,
+		State:            StateColumn,
+		CycleIdx:         CycleIdxColumn,
+		DueAt:            DueAtColumn,
+		RemindedAt:       RemindedAtColumn,
+		LegalHold:        LegalHoldColumn,
+		LegalHoldReason:  LegalHoldReasonColumn,
+		LegalHoldUserIdx: LegalHoldUserIdxColumn,
+		LegalHoldAt:      LegalHoldAtColumn,
+		PurgedAt:         PurgedAtColumn,
+		SourceProofIdx:   SourceProofIdxColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
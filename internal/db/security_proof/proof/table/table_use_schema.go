@@ -11,4 +11,16 @@ package table
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
 	Proof = Proof.FromSchema(schema)
+	ProofStateHistory = ProofStateHistory.FromSchema(schema)
+	ProofReject = ProofReject.FromSchema(schema)
+	ProofRevision = ProofRevision.FromSchema(schema)
+	ProofAttachment = ProofAttachment.FromSchema(schema)
+	AssessmentCycle = AssessmentCycle.FromSchema(schema)
+	Control = Control.FromSchema(schema)
+	ProofControl = ProofControl.FromSchema(schema)
+	ProofComment = ProofComment.FromSchema(schema)
+	EvidenceBlob = EvidenceBlob.FromSchema(schema)
+	RetentionPolicy = RetentionPolicy.FromSchema(schema)
+	PurgedEvidence = PurgedEvidence.FromSchema(schema)
+	IntegrityCheck = IntegrityCheck.FromSchema(schema)
 }
@@ -11,12 +11,15 @@ import (
 // WithCORS function is returning an HTTP Handler with configured CORS settings.
 func WithCORS(h http.Handler) http.Handler {
 	var token = []string{"accessToken", "refreshToken"}
+	// 첨부 파일 다운로드의 조건부 요청과 범위 요청에 쓰이는 헤더입니다.
+	var download = []string{"If-None-Match", "If-Range", "Range"}
+	var downloadExposed = []string{"ETag", "Content-Disposition", "X-Evidence-Verified"}
 
 	middleware := cors.New(cors.Options{
 		AllowedOrigins: []string{"http://localhost:3000", "http://localhost:8080", "http://localhost:8081", "http://localhost:8082"},
 		AllowedMethods: connectcors.AllowedMethods(),
-		AllowedHeaders: append(connectcors.AllowedHeaders(), token...),
-		ExposedHeaders: connectcors.ExposedHeaders(),
+		AllowedHeaders: append(append(connectcors.AllowedHeaders(), token...), download...),
+		ExposedHeaders: append(connectcors.ExposedHeaders(), downloadExposed...),
 	})
 	return middleware.Handler(h)
 }
@@ -3,30 +3,38 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
 	"connectrpc.com/connect"
 
+	"security-proof/internal/db/security_proof/proof/model"
 	goverter "security-proof/internal/proof/convert"
 	"security-proof/internal/proof/service"
+	"security-proof/pkg/catalog"
 	"security-proof/pkg/constants"
 )
 
 var conv = goverter.ControllerConverterImpl{}
 
-// ProofController struct is composed of command and query from the service layer.
+// ProofController struct is composed of command, query and export from the service layer.
 type ProofController struct {
 	proofCommand *service.ProofCommand
 	proofQuery   *service.ProofQuery
+	proofExport  *service.ProofExport
 }
 
-// NewProofController function is returning a ProofController struct that accept command and query from the service layer.
-func NewProofController(proofCommand *service.ProofCommand, proofQuery *service.ProofQuery) *ProofController {
-	return &ProofController{proofCommand: proofCommand, proofQuery: proofQuery}
+// NewProofController function is returning a ProofController struct that accept command, query and export from the service layer.
+func NewProofController(proofCommand *service.ProofCommand, proofQuery *service.ProofQuery, proofExport *service.ProofExport) *ProofController {
+	return &ProofController{proofCommand: proofCommand, proofQuery: proofQuery, proofExport: proofExport}
 }
 
 // CreateProof method is returning a CreateProofResponse and an error, accepting a CreateProofRequest and a context.
@@ -44,6 +52,7 @@ func (c *ProofController) CreateProof(ctx context.Context, req *connect.Request[
 }
 
 // ReadProof method is returning a ReadProofResponse and an error, accepting a ReadProofRequest and a context.
+// The published Proof message has no reject reasons or extracted metadata, which are served by ReadProofRejects and ReadAttachments.
 func (c *ProofController) ReadProof(ctx context.Context, req *connect.Request[apiv1.ReadProofRequest]) (*connect.Response[apiv1.ReadProofResponse], error) {
 	accessToken := req.Header().Get("accessToken")
 
@@ -86,6 +95,7 @@ func (c *ProofController) DeleteProof(ctx context.Context, req *connect.Request[
 }
 
 // ListProof method is returning a ListProofResponse and an error, accepting a ListProofRequest and a context.
+// The published Proof message has no comment count, which is served by ReadProofs.
 func (c *ProofController) ListProof(ctx context.Context, req *connect.Request[apiv1.ListProofRequest]) (*connect.Response[apiv1.ListProofResponse], error) {
 	// TODO : 서버 페이징 작업 필요
 	accessToken := req.Header().Get("accessToken")
@@ -97,8 +107,13 @@ func (c *ProofController) ListProof(ctx context.Context, req *connect.Request[ap
 		return nil, connect.NewError(connect.CodeUnknown, err)
 	}
 
+	result := make([]*apiv1.Proof, len(proofs))
+	for i, proof := range proofs {
+		result[i] = proof.Proof
+	}
+
 	res := connect.NewResponse(&apiv1.ListProofResponse{
-		Proofs: proofs,
+		Proofs: result,
 	})
 
 	return res, nil
@@ -146,67 +161,1169 @@ func (c *ProofController) ConfirmUpdateProof(ctx context.Context, req *connect.R
 }
 
 // ReadLog method is returning a ReadLogResponse and an error, accepting a ReadLogRequest and a context.
+// Only the first page of the log of the latest revision is returned, and the rest is read with ReadProofLog.
 func (c *ProofController) ReadLog(ctx context.Context, req *connect.Request[apiv1.ReadLogRequest]) (*connect.Response[apiv1.ReadLogResponse], error) {
 	accessToken := req.Header().Get("accessToken")
 
-	proof, err := c.proofQuery.ReadProofLog(ctx, req.Msg.Idx, accessToken)
+	proofLog, err := c.proofQuery.ReadProofLog(ctx, req.Msg.Idx, 0, 0, 0, accessToken)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
 
 	res := connect.NewResponse(&apiv1.ReadLogResponse{
-		Log: proof.LogPath,
+		Log: strings.Join(proofLog.Lines, "\n"),
 	})
 	return res, nil
 }
 
-// ReadFirstImage method is returning an image file, accepting a proof index.
-func (c *ProofController) ReadFirstImage(w http.ResponseWriter, r *http.Request) {
-	pathParts := strings.Split(r.URL.Path, "/")
+// ReadProofLog method is returning a page of the log of a proof, accepting a proof index and optional revision, offset and limit queries.
+func (c *ProofController) ReadProofLog(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	if len(pathParts) != 4 || pathParts[2] != "readFirstImage" {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	revision, err := queryRevision(r)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	accessToken := r.Header.Get("accessToken")
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	idxInt64, err := strconv.ParseInt(pathParts[3], 10, 32)
+	limit, err := queryInt(r, "limit")
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		writeError(w, err)
+		return
 	}
 
-	path, err := c.proofQuery.ReadFirstProofImage(r.Context(), int32(idxInt64), accessToken)
+	proofLog, err := c.proofQuery.ReadProofLog(r.Context(), idx, revision, offset, limit, r.Header.Get("accessToken"))
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		writeError(w, err)
+		return
 	}
 
-	filePath := path
-	http.ServeFile(w, r, filePath)
+	writeJSON(w, http.StatusOK, proofLog)
+}
+
+// ReadFirstImage method is returning the first attachment file, accepting a proof index and an optional revision query.
+func (c *ProofController) ReadFirstImage(w http.ResponseWriter, r *http.Request) {
+	r.SetPathValue("position", "1")
+	c.ReadAttachment(w, r)
 }
 
-// ReadSecondImage method is returning an image file, accepting a proof index.
+// ReadSecondImage method is returning the second attachment file, accepting a proof index and an optional revision query.
 func (c *ProofController) ReadSecondImage(w http.ResponseWriter, r *http.Request) {
+	r.SetPathValue("position", "2")
+	c.ReadAttachment(w, r)
+}
+
+// ReadAttachment method is returning an attachment file, accepting a proof index, a position and an optional revision query.
+func (c *ProofController) ReadAttachment(w http.ResponseWriter, r *http.Request) {
+	idx, revision, position, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	download, err := c.proofQuery.ReadAttachmentDownload(r.Context(), idx, revision, position, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	c.serveDownload(w, r, download)
+}
+
+// ReadThumbnail method is returning the JPEG thumbnail of an image attachment, accepting a proof index, a position and an optional revision query.
+func (c *ProofController) ReadThumbnail(w http.ResponseWriter, r *http.Request) {
+	idx, revision, position, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	download, err := c.proofQuery.ReadThumbnailDownload(r.Context(), idx, revision, position, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	c.serveDownload(w, r, download)
+}
+
+// CreateDownloadURL method is returning a signed url of an attachment file, accepting a proof index, a position,
+// an optional revision query, an optional thumbnail query and an optional single query for a single-use url.
+// The url can be used without the access token header until it expires, so that an image tag can load it.
+func (c *ProofController) CreateDownloadURL(w http.ResponseWriter, r *http.Request) {
+	idx, revision, position, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	thumbnail := r.URL.Query().Get("thumbnail") == "true"
+	singleUse := r.URL.Query().Get("single") == "true"
+	values, expiresAt, err := c.proofQuery.CreateDownloadURL(r.Context(), idx, revision, position, thumbnail, singleUse, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, downloadURL{
+		URL:       fmt.Sprintf("/apiv1/download/%d/%d?%s", idx, position, values.Encode()),
+		ExpiresAt: expiresAt,
+	})
+}
+
+// downloadURL struct is the JSON representation of a signed download url.
+type downloadURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ReadSignedDownload method is returning an attachment file, accepting a proof index, a position and the query of a signed url.
+func (c *ProofController) ReadSignedDownload(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	position, err := pathPosition(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	download, err := c.proofQuery.ReadSignedDownload(r.Context(), idx, position, r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	c.serveDownload(w, r, download)
+}
+
+// attachmentPath function is returning a proof index, a revision, a position and whether they are valid, accepting a request.
+// The error response is written when they are not valid.
+func attachmentPath(w http.ResponseWriter, r *http.Request) (int32, int32, int32, bool) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return 0, 0, 0, false
+	}
+
+	position, err := pathPosition(r)
+	if err != nil {
+		writeError(w, err)
+		return 0, 0, 0, false
+	}
+
+	revision, err := queryRevision(r)
+	if err != nil {
+		writeError(w, err)
+		return 0, 0, 0, false
+	}
+
+	return idx, revision, position, true
+}
+
+// serveDownload method is writing a downloaded file, accepting a response writer, a request and a Download.
+// A file of a given revision never changes, so it is cached until it expires, while the latest revision is revalidated by its entity tag.
+// X-Evidence-Verified tells whether the file was checked against its recorded hash, which is not the case for partial or HEAD responses
+// and for attachments without a hash.
+func (c *ProofController) serveDownload(w http.ResponseWriter, r *http.Request, download *service.Download) {
+	// 브라우저가 형식을 다시 추측하지 않도록 합니다.
+	w.Header().Set("Content-Type", download.MimeType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", contentDisposition(download))
+	if download.Immutable {
+		w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "private, no-cache")
+	}
+	if download.ETag != "" {
+		w.Header().Set("ETag", download.ETag)
+	}
+
+	// 캐시가 최신이면 저장소에서 파일을 읽지 않습니다.
+	if download.ETag != "" && etagMatch(r.Header.Get("If-None-Match"), download.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	whole := wholeDownload(r, download)
+	reader, err := c.proofQuery.OpenDownload(r.Context(), download, whole)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("X-Evidence-Verified", strconv.FormatBool(whole && download.Hash != ""))
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Printf("Failed to close attachment: %v", closeErr)
+		}
+	}()
+
+	// 탐색할 수 있는 저장소는 범위 요청과 If-Range를 그대로 지원합니다.
+	seeker, ok := reader.(io.ReadSeeker)
+	if ok {
+		http.ServeContent(w, r, "", download.ModTime, seeker)
+		return
+	}
+
+	// 탐색할 수 없는 저장소는 범위 요청을 무시하고 전체 파일을 내려줍니다.
+	w.Header().Set("Accept-Ranges", "none")
+	w.Header().Set("Last-Modified", download.ModTime.UTC().Format(http.TimeFormat))
+	if download.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(download.Size, 10))
+	}
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, err = io.Copy(w, reader)
+	if err != nil {
+		log.Printf("Failed to write attachment: %v", err)
+	}
+}
+
+// proofAttachment struct is the JSON representation of a proof attachment.
+// The capture time and the device fields are read from the metadata embedded in an image, so that stale screenshots stand out.
+type proofAttachment struct {
+	Idx             int32      `json:"idx"`
+	Position        int32      `json:"position"`
+	Label           string     `json:"label"`
+	MimeType        string     `json:"mimeType"`
+	Hash            string     `json:"hash"`
+	Size            int64      `json:"size"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	Thumbnail       bool       `json:"thumbnail"`
+	CapturedAt      *time.Time `json:"capturedAt,omitempty"`
+	DeviceMake      *string    `json:"deviceMake,omitempty"`
+	DeviceModel     *string    `json:"deviceModel,omitempty"`
+	Software        *string    `json:"software,omitempty"`
+	LocationRemoved bool       `json:"locationRemoved"`
+	Log             bool       `json:"log"`
+	LineCount       *int32     `json:"lineCount,omitempty"`
+}
+
+// ReadAttachments method is returning the ordered attachments of a proof, accepting a proof index and an optional revision query.
+func (c *ProofController) ReadAttachments(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	revision, err := queryRevision(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	attachments, err := c.proofQuery.ListProofAttachments(r.Context(), idx, revision, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*proofAttachment, len(attachments))
+	for i, attachment := range attachments {
+		result[i] = &proofAttachment{
+			Idx:             attachment.Idx,
+			Position:        attachment.Position,
+			Label:           attachment.Label,
+			MimeType:        attachment.MimeType,
+			Hash:            attachment.Hash,
+			Size:            attachment.Size,
+			CreatedAt:       attachment.CreatedAt,
+			Thumbnail:       attachment.ThumbnailPath != nil,
+			CapturedAt:      attachment.CapturedAt,
+			DeviceMake:      attachment.DeviceMake,
+			DeviceModel:     attachment.DeviceModel,
+			Software:        attachment.Software,
+			LocationRemoved: attachment.LocationRemoved,
+			Log:             attachment.IsLog,
+			LineCount:       attachment.LineCount,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// UploadAttachments method is uploading any number of attachment files as a new revision, accepting a proof index and a multipart form.
+// Files are streamed from the "attachments" fields in order without being held in memory, and the preceding "labels" fields give their labels.
+func (c *ProofController) UploadAttachments(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// 큰 첨부 파일은 서버의 쓰기 제한 시간보다 오래 걸릴 수 있으므로 제한을 해제합니다.
+	err = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	if err != nil {
+		log.Printf("Failed to clear write deadline: %v", err)
+	}
+
+	// 본문 크기는 첨부 파일마다 허용된 크기를 기준으로 제한하며, 각 파일은 서비스에서 한 번 더 확인합니다.
+	maxBodySize := c.proofCommand.MaxAttachmentSize()*maxAttachmentCount + maxMultipartOverhead
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err = c.proofCommand.UploadAttachmentStreams(r.Context(), idx, multipartAttachments(reader), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rejectProofRequest struct is the JSON body of a reject request.
+type rejectProofRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RejectProof method is sending a proof in review back to the engineer, accepting a proof index and a reason.
+func (c *ProofController) RejectProof(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	req := &rejectProofRequest{}
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.proofCommand.RejectProof(r.Context(), idx, req.Reason, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// proofReject struct is the JSON representation of a proof reject.
+type proofReject struct {
+	Idx             int32      `json:"idx"`
+	ProofIdx        int32      `json:"proofIdx"`
+	Reason          string     `json:"reason"`
+	RejectedUserIdx int32      `json:"rejectedUserIdx"`
+	RejectedAt      time.Time  `json:"rejectedAt"`
+	ResolvedAt      *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// ReadProofRejects method is returning the reject reasons of a proof, accepting a proof index.
+func (c *ProofController) ReadProofRejects(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	rejects, err := c.proofQuery.ListProofRejects(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*proofReject, len(rejects))
+	for i, reject := range rejects {
+		result[i] = &proofReject{
+			Idx:             reject.Idx,
+			ProofIdx:        reject.ProofIdx,
+			Reason:          reject.Reason,
+			RejectedUserIdx: reject.RejectedUserIdx,
+			RejectedAt:      reject.RejectedAt,
+			ResolvedAt:      reject.ResolvedAt,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// ReviewProof method is moving an uploaded proof into review, accepting a proof index.
+func (c *ProofController) ReviewProof(w http.ResponseWriter, r *http.Request) {
+	c.changeState(w, r, c.proofCommand.ReviewProof)
+}
+
+// ExpireProof method is expiring a confirmed proof, accepting a proof index.
+func (c *ProofController) ExpireProof(w http.ResponseWriter, r *http.Request) {
+	c.changeState(w, r, c.proofCommand.ExpireProof)
+}
+
+// ReopenProof method is reopening a confirmed or expired proof, accepting a proof index.
+func (c *ProofController) ReopenProof(w http.ResponseWriter, r *http.Request) {
+	c.changeState(w, r, c.proofCommand.ReopenProof)
+}
+
+func (c *ProofController) changeState(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, idx int32, accessToken string) error) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = change(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// proofStateHistory struct is the JSON representation of a proof state transition.
+type proofStateHistory struct {
+	Idx       int32     `json:"idx"`
+	ProofIdx  int32     `json:"proofIdx"`
+	FromState string    `json:"fromState"`
+	ToState   string    `json:"toState"`
+	UserIdx   int32     `json:"userIdx"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ReadProofHistory method is returning the state transitions of a proof, accepting a proof index.
+func (c *ProofController) ReadProofHistory(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	histories, err := c.proofQuery.ListProofStateHistory(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*proofStateHistory, len(histories))
+	for i, history := range histories {
+		result[i] = &proofStateHistory{
+			Idx:       history.Idx,
+			ProofIdx:  history.ProofIdx,
+			FromState: service.StateName(history.FromState),
+			ToState:   service.StateName(history.ToState),
+			UserIdx:   history.UserIdx,
+			CreatedAt: history.CreatedAt,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// proofRevision struct is the JSON representation of a proof revision.
+type proofRevision struct {
+	Idx             int32     `json:"idx"`
+	ProofIdx        int32     `json:"proofIdx"`
+	Revision        int32     `json:"revision"`
+	UploadedUserIdx int32     `json:"uploadedUserIdx"`
+	UploadedAt      time.Time `json:"uploadedAt"`
+	TokenID         *int32    `json:"tokenId,omitempty"`
+	Digest          *string   `json:"digest,omitempty"`
+}
+
+// ReadProofRevisions method is returning the uploaded revisions of a proof, accepting a proof index.
+func (c *ProofController) ReadProofRevisions(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	revisions, err := c.proofQuery.ListProofRevisions(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*proofRevision, len(revisions))
+	for i, revision := range revisions {
+		result[i] = &proofRevision{
+			Idx:             revision.Idx,
+			ProofIdx:        revision.ProofIdx,
+			Revision:        revision.Revision,
+			UploadedUserIdx: revision.UploadedUserIdx,
+			UploadedAt:      revision.UploadedAt,
+			TokenID:         revision.TokenID,
+			Digest:          revision.Digest,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// startCycleRequest struct is the JSON body of a start cycle request.
+type startCycleRequest struct {
+	Name string `json:"name"`
+}
+
+// startCycleResponse struct is the JSON response of a start cycle request.
+type startCycleResponse struct {
+	Idx int32 `json:"idx"`
+}
+
+// StartCycle method is starting a new assessment cycle carrying forward the proofs of the active one, accepting a cycle name.
+func (c *ProofController) StartCycle(w http.ResponseWriter, r *http.Request) {
+	req := &startCycleRequest{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	idx, err := c.proofCommand.StartCycle(r.Context(), req.Name, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, &startCycleResponse{Idx: idx})
+}
+
+// assessmentCycle struct is the JSON representation of an assessment cycle.
+type assessmentCycle struct {
+	Idx            int32      `json:"idx"`
+	Name           string     `json:"name"`
+	StartedUserIdx int32      `json:"startedUserIdx"`
+	StartedAt      time.Time  `json:"startedAt"`
+	EndedAt        *time.Time `json:"endedAt,omitempty"`
+}
+
+// ReadCycles method is returning every assessment cycle.
+func (c *ProofController) ReadCycles(w http.ResponseWriter, r *http.Request) {
+	cycles, err := c.proofQuery.ListCycles(r.Context(), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*assessmentCycle, len(cycles))
+	for i, cycle := range cycles {
+		result[i] = &assessmentCycle{
+			Idx:            cycle.Idx,
+			Name:           cycle.Name,
+			StartedUserIdx: cycle.StartedUserIdx,
+			StartedAt:      cycle.StartedAt,
+			EndedAt:        cycle.EndedAt,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// cycleProof struct is the JSON representation of a proof listed in a cycle.
+type cycleProof struct {
+	Idx          int32      `json:"idx"`
+	Num          string     `json:"num"`
+	Category     string     `json:"category"`
+	Description  string     `json:"description"`
+	UploadedAt   *time.Time `json:"uploadedAt,omitempty"`
+	Confirm      int32      `json:"confirm"`
+	CommentCount int32      `json:"commentCount"`
+}
+
+// ReadProofs method is returning the proofs of the active cycle, accepting an optional category query.
+func (c *ProofController) ReadProofs(w http.ResponseWriter, r *http.Request) {
+	proofs, err := c.proofQuery.ListProofs(r.Context(), r.URL.Query().Get("category"), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCycleProofs(proofs))
+}
+
+// ReadCycleProofs method is returning the proofs of a cycle, accepting a cycle index and an optional category query.
+func (c *ProofController) ReadCycleProofs(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	proofs, err := c.proofQuery.ListCycleProofs(r.Context(), idx, r.URL.Query().Get("category"), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCycleProofs(proofs))
+}
+
+// toCycleProofs function is returning JSON representations, accepting listed proofs.
+func toCycleProofs(proofs []*service.ListedProof) []*cycleProof {
+	result := make([]*cycleProof, len(proofs))
+	for i, proof := range proofs {
+		result[i] = &cycleProof{
+			Idx:          proof.Proof.Idx,
+			Num:          proof.Proof.Num,
+			Category:     proof.Proof.Category,
+			Description:  proof.Proof.Description,
+			Confirm:      proof.Proof.Confirm,
+			CommentCount: proof.CommentCount,
+		}
+		if proof.Proof.UploadedAt != nil {
+			uploadedAt := proof.Proof.UploadedAt.AsTime()
+			result[i].UploadedAt = &uploadedAt
+		}
+	}
+	return result
+}
+
+// importControlsResponse struct is the JSON body of an import controls response.
+type importControlsResponse struct {
+	Count int64 `json:"count"`
+}
+
+// ImportControls method is loading a control catalog file, accepting a JSON or CSV body and a format query.
+// The format is JSON when the query is not given.
+func (c *ProofController) ImportControls(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = catalog.FormatJSON
+	}
+
+	controls, err := catalog.Parse(http.MaxBytesReader(w, r.Body, maxCatalogBodySize), format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	count, err := c.proofCommand.ImportControls(r.Context(), controls, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &importControlsResponse{Count: count})
+}
+
+// mapProofControlsRequest struct is the JSON body of a map proof controls request.
+type mapProofControlsRequest struct {
+	ControlIdxs []int32 `json:"controlIdxs"`
+}
+
+// MapProofControls method is replacing the controls a proof satisfies, accepting a proof index and control indexes.
+func (c *ProofController) MapProofControls(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	req := &mapProofControlsRequest{}
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.proofCommand.MapProofControls(r.Context(), idx, req.ControlIdxs, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// control struct is the JSON representation of a catalog control.
+type control struct {
+	Idx             int32  `json:"idx"`
+	Framework       string `json:"framework"`
+	ControlID       string `json:"controlId"`
+	Title           string `json:"title"`
+	ParentControlID string `json:"parentControlId,omitempty"`
+}
+
+// ReadControls method is returning the control catalog, accepting an optional framework query.
+func (c *ProofController) ReadControls(w http.ResponseWriter, r *http.Request) {
+	controls, err := c.proofQuery.ListControls(r.Context(), r.URL.Query().Get("framework"), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toControls(controls))
+}
+
+// ReadUncoveredControls method is returning the controls without confirmed evidence in the active cycle, accepting an optional framework query.
+func (c *ProofController) ReadUncoveredControls(w http.ResponseWriter, r *http.Request) {
+	controls, err := c.proofQuery.ListUncoveredControls(r.Context(), r.URL.Query().Get("framework"), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toControls(controls))
+}
 
-	pathParts := strings.Split(r.URL.Path, "/")
+// ReadProofControls method is returning the controls a proof is mapped to, accepting a proof index.
+func (c *ProofController) ReadProofControls(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	controls, err := c.proofQuery.ListProofControls(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toControls(controls))
+}
+
+// toControls function is returning JSON controls, accepting Control models.
+func toControls(controls []*model.Control) []*control {
+	result := make([]*control, len(controls))
+	for i, item := range controls {
+		result[i] = &control{
+			Idx:       item.Idx,
+			Framework: item.Framework,
+			ControlID: item.ControlID,
+			Title:     item.Title,
+		}
+		if item.ParentControlID != nil {
+			result[i].ParentControlID = *item.ParentControlID
+		}
+	}
+	return result
+}
+
+// setProofDueDateRequest struct is the JSON body of a set due date request.
+type setProofDueDateRequest struct {
+	DueAt *time.Time `json:"dueAt"`
+}
+
+// SetProofDueDate method is setting or clearing the due date of a proof, accepting a proof index and a due date.
+func (c *ProofController) SetProofDueDate(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	req := &setProofDueDateRequest{}
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.proofCommand.SetProofDueDate(r.Context(), idx, req.DueAt, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dueProof struct is the JSON representation of a proof with a due date.
+type dueProof struct {
+	Idx             int32     `json:"idx"`
+	Num             string    `json:"num"`
+	Category        string    `json:"category"`
+	Description     string    `json:"description"`
+	UploadedUserIdx int32     `json:"uploadedUserIdx"`
+	State           string    `json:"state"`
+	DueAt           time.Time `json:"dueAt"`
+}
 
-	if len(pathParts) != 4 || pathParts[2] != "readSecondImage" {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+// ReadOverdueProofs method is returning the proofs of the active cycle not confirmed by their due date.
+func (c *ProofController) ReadOverdueProofs(w http.ResponseWriter, r *http.Request) {
+	proofs, err := c.proofQuery.ListOverdueProofs(r.Context(), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	accessToken := r.Header.Get("accessToken")
+	result := make([]*dueProof, len(proofs))
+	for i, proof := range proofs {
+		result[i] = &dueProof{
+			Idx:             proof.Idx,
+			Num:             goverter.PStringToString(proof.Num),
+			Category:        proof.Category,
+			Description:     proof.Description,
+			UploadedUserIdx: goverter.Pint32ToInt32(proof.UploadedUserIdx),
+			State:           service.StateName(proof.State),
+		}
+		if proof.DueAt != nil {
+			result[i].DueAt = *proof.DueAt
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// importedProof struct is the JSON representation of a proof created by an import.
+type importedProof struct {
+	Line int    `json:"line"`
+	Idx  int32  `json:"idx"`
+	Num  string `json:"num"`
+}
 
-	idxInt64, err := strconv.ParseInt(pathParts[3], 10, 32)
+// rejectedProof struct is the JSON representation of an import row that was not created.
+type rejectedProof struct {
+	Line  int    `json:"line"`
+	Num   string `json:"num"`
+	Error string `json:"error"`
+}
+
+// importProofsResponse struct is the JSON body of an import proofs response.
+type importProofsResponse struct {
+	Created []*importedProof `json:"created"`
+	Errors  []*rejectedProof `json:"errors"`
+}
+
+// ImportProofs method is creating proofs in bulk, accepting a CSV body.
+// Rows with errors are reported in the response while every other row is created.
+func (c *ProofController) ImportProofs(w http.ResponseWriter, r *http.Request) {
+	result, err := c.proofCommand.ImportProofs(r.Context(), http.MaxBytesReader(w, r.Body, maxCatalogBodySize), r.Header.Get("accessToken"))
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		writeError(w, err)
+		return
 	}
 
-	path, err := c.proofQuery.ReadSecondProofImage(r.Context(), int32(idxInt64), accessToken)
+	res := &importProofsResponse{
+		Created: make([]*importedProof, len(result.Created)),
+		Errors:  make([]*rejectedProof, len(result.Errors)),
+	}
+	for i, row := range result.Created {
+		res.Created[i] = &importedProof{Line: row.Line, Idx: row.Idx, Num: row.Num}
+	}
+	for i, rowErr := range result.Errors {
+		res.Errors[i] = &rejectedProof{Line: rowErr.Line, Num: rowErr.Num, Error: rowErr.Err.Error()}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// ExportEvidence method is streaming a signed ZIP bundle of the confirmed evidence, accepting an optional cycle query.
+// The active cycle is exported when the cycle query is not given.
+func (c *ProofController) ExportEvidence(w http.ResponseWriter, r *http.Request) {
+	var cycleIdx int32
+	if value := r.URL.Query().Get("cycle"); value != "" {
+		idx, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			writeError(w, errors.Join(constants.ErrItemNotFound, err))
+			return
+		}
+		cycleIdx = int32(idx)
+	}
+
+	// 큰 주기는 서버의 쓰기 제한 시간보다 오래 걸릴 수 있으므로 제한을 해제합니다.
+	err := http.NewResponseController(w).SetWriteDeadline(time.Time{})
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		log.Printf("Failed to clear write deadline: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="evidence.zip"`)
+
+	stream := &countingWriter{w: w}
+	err = c.proofExport.ExportEvidence(r.Context(), stream, cycleIdx, r.Header.Get("accessToken"))
+	if err == nil {
+		return
+	}
+
+	if stream.n == 0 {
+		w.Header().Del("Content-Disposition")
+		writeError(w, err)
+		return
+	}
+	// 이미 전송을 시작했으므로 상태 코드를 바꿀 수 없어 로그만 남깁니다.
+	log.Printf("Failed to export evidence: %v", err)
+}
+
+// proofCommentRequest struct is the JSON body of a create or edit comment request.
+type proofCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// createProofCommentResponse struct is the JSON response of a create comment request.
+type createProofCommentResponse struct {
+	Idx int32 `json:"idx"`
+}
+
+// CreateProofComment method is adding a comment to a proof, accepting a proof index and a body.
+func (c *ProofController) CreateProofComment(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	req := &proofCommentRequest{}
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	commentIdx, err := c.proofCommand.CreateProofComment(r.Context(), idx, req.Body, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, &createProofCommentResponse{Idx: commentIdx})
+}
+
+// EditProofComment method is changing the body of a comment, accepting a comment index and a body.
+func (c *ProofController) EditProofComment(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	req := &proofCommentRequest{}
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.proofCommand.EditProofComment(r.Context(), idx, req.Body, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteProofComment method is deleting a comment, accepting a comment index.
+func (c *ProofController) DeleteProofComment(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = c.proofCommand.DeleteProofComment(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// proofComment struct is the JSON representation of a proof comment.
+type proofComment struct {
+	Idx       int32      `json:"idx"`
+	ProofIdx  int32      `json:"proofIdx"`
+	UserIdx   int32      `json:"userIdx"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+}
+
+// ReadProofComments method is returning the comments of a proof, accepting a proof index.
+func (c *ProofController) ReadProofComments(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	comments, err := c.proofQuery.ListProofComments(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*proofComment, len(comments))
+	for i, comment := range comments {
+		result[i] = &proofComment{
+			Idx:       comment.Idx,
+			ProofIdx:  comment.ProofIdx,
+			UserIdx:   comment.UserIdx,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			EditedAt:  comment.EditedAt,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// setRetentionPolicyRequest struct is the JSON body of a set retention policy request.
+// The policy is the default policy when neither a category nor a cycle is given.
+type setRetentionPolicyRequest struct {
+	Category      string `json:"category"`
+	CycleIdx      int32  `json:"cycleIdx"`
+	RetentionDays int32  `json:"retentionDays"`
+}
+
+// setRetentionPolicyResponse struct is the JSON response of a set retention policy request.
+type setRetentionPolicyResponse struct {
+	Idx int32 `json:"idx"`
+}
+
+// SetRetentionPolicy method is setting how long the evidence of a category, a cycle or every proof is kept after its cycle ended.
+func (c *ProofController) SetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	req := &setRetentionPolicyRequest{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	idx, err := c.proofCommand.SetRetentionPolicy(r.Context(), req.Category, req.CycleIdx, req.RetentionDays, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, &setRetentionPolicyResponse{Idx: idx})
+}
+
+// DeleteRetentionPolicy method is deleting a retention policy, accepting a policy index.
+func (c *ProofController) DeleteRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = c.proofCommand.DeleteRetentionPolicy(r.Context(), idx, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// retentionPolicy struct is the JSON representation of a retention policy.
+type retentionPolicy struct {
+	Idx            int32     `json:"idx"`
+	Category       *string   `json:"category,omitempty"`
+	CycleIdx       *int32    `json:"cycleIdx,omitempty"`
+	RetentionDays  int32     `json:"retentionDays"`
+	CreatedUserIdx int32     `json:"createdUserIdx"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// ReadRetentionPolicies method is returning every retention policy.
+func (c *ProofController) ReadRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := c.proofQuery.ListRetentionPolicies(r.Context(), r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*retentionPolicy, len(policies))
+	for i, policy := range policies {
+		result[i] = &retentionPolicy{
+			Idx:            policy.Idx,
+			Category:       policy.Category,
+			CycleIdx:       policy.CycleIdx,
+			RetentionDays:  policy.RetentionDays,
+			CreatedUserIdx: policy.CreatedUserIdx,
+			CreatedAt:      policy.CreatedAt,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// setLegalHoldRequest struct is the JSON body of a set legal hold request.
+type setLegalHoldRequest struct {
+	Hold   bool   `json:"hold"`
+	Reason string `json:"reason"`
+}
+
+// SetLegalHold method is putting a proof on legal hold or releasing it, accepting a proof index, whether it is held and a reason.
+func (c *ProofController) SetLegalHold(w http.ResponseWriter, r *http.Request) {
+	idx, err := pathIdx(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	req := &setLegalHoldRequest{}
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.proofCommand.SetLegalHold(r.Context(), idx, req.Hold, req.Reason, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// purgedEvidence struct is the JSON representation of a purged attachment.
+type purgedEvidence struct {
+	ProofIdx   int32     `json:"proofIdx"`
+	Revision   int32     `json:"revision"`
+	Position   int32     `json:"position"`
+	Label      string    `json:"label"`
+	MimeType   string    `json:"mimeType"`
+	Hash       string    `json:"hash"`
+	Size       int64     `json:"size"`
+	TokenID    *int32    `json:"tokenId,omitempty"`
+	UploadedAt time.Time `json:"uploadedAt"`
+	PurgedAt   time.Time `json:"purgedAt"`
+}
+
+// ReadPurgedEvidence method is returning the attachments purged since the optional since query, which is an RFC 3339 time.
+func (c *ProofController) ReadPurgedEvidence(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	evidence, err := c.proofQuery.ListPurgedEvidence(r.Context(), since, r.Header.Get("accessToken"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]*purgedEvidence, len(evidence))
+	for i, purged := range evidence {
+		result[i] = &purgedEvidence{
+			ProofIdx:   purged.ProofIdx,
+			Revision:   purged.Revision,
+			Position:   purged.Position,
+			Label:      purged.Label,
+			MimeType:   purged.MimeType,
+			Hash:       purged.Hash,
+			Size:       purged.Size,
+			TokenID:    purged.TokenID,
+			UploadedAt: purged.UploadedAt,
+			PurgedAt:   purged.PurgedAt,
+		}
 	}
 
-	filePath := path
-	http.ServeFile(w, r, filePath)
+	writeJSON(w, http.StatusOK, result)
 }
@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"security-proof/internal/proof/service"
+	"security-proof/pkg/constants"
+	filemanage "security-proof/pkg/manage/file"
+)
+
+// maxJSONBodySize is the largest JSON request body accepted by the plain HTTP handlers.
+const maxJSONBodySize = 1 << 20
+
+// maxAttachmentCount is the number of largest attachments a multipart body may hold when uploading attachments.
+const maxAttachmentCount = 10
+
+// maxMultipartOverhead is the room left in a multipart body for the labels and the part headers.
+const maxMultipartOverhead = 1 << 20
+
+// maxLabelSize is the largest attachment label read from a multipart body.
+const maxLabelSize = 1 << 10
+
+// maxCatalogBodySize is the largest CSV or JSON file accepted when importing controls or proofs.
+const maxCatalogBodySize = 8 << 20
+
+// writeJSON function is writing a JSON response, accepting a ResponseWriter, a status code and a value.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// writeError function is writing an error response, accepting a ResponseWriter and an error from the service layer.
+func writeError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), errorStatus(err))
+}
+
+// errorStatus function is returning an HTTP status code, accepting an error from the service layer.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrTokenValidate):
+		return http.StatusUnauthorized
+	case errors.Is(err, constants.ErrTokenRoleAuth), errors.Is(err, constants.ErrProofCommentAuthor):
+		return http.StatusForbidden
+	case errors.Is(err, constants.ErrSignedURLInvalid), errors.Is(err, constants.ErrSignedURLExpired), errors.Is(err, constants.ErrSignedURLUsed):
+		return http.StatusForbidden
+	case errors.Is(err, constants.ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrProofTransition), errors.Is(err, constants.ErrStateConflict):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrProofLegalHold), errors.Is(err, constants.ErrProofRetained), errors.Is(err, constants.ErrProofPurged):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrFileTampered), errors.Is(err, constants.ErrFileRemoved):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrFileTooLarge), errors.As(err, new(*http.MaxBytesError)):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, constants.ErrFileType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, constants.ErrFileInfected):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, constants.ErrFileScan):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, constants.ErrFileEmpty), errors.Is(err, constants.ErrFileMultipart), errors.Is(err, constants.ErrProofAttachmentEmpty),
+		errors.Is(err, constants.ErrProofLogDuplicate), errors.Is(err, constants.ErrProofLogRange):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrProofRejectReason), errors.Is(err, constants.ErrProofCycleName), errors.Is(err, constants.ErrProofCommentBody),
+		errors.Is(err, constants.ErrLegalHoldReason), errors.Is(err, constants.ErrRetentionDays), errors.Is(err, constants.ErrRetentionScope):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrProofImportParse), errors.Is(err, constants.ErrProofImportRow), errors.Is(err, constants.ErrProofNumDuplicate),
+		errors.Is(err, constants.ErrProofAssignee), errors.Is(err, constants.ErrCatalogFormat), errors.Is(err, constants.ErrCatalogParse),
+		errors.Is(err, constants.ErrCatalogControl):
+		return http.StatusBadRequest
+	default:
+		// 요청 값이 잘못된 경우가 아니면 서버 에러로 응답합니다.
+		return http.StatusInternalServerError
+	}
+}
+
+// wholeDownload function is returning whether the whole file is served, accepting a request and a Download.
+// A range request is partial unless its If-Range no longer matches the entity tag, in which case the whole file is served.
+func wholeDownload(r *http.Request, download *service.Download) bool {
+	if r.Method == http.MethodHead {
+		return false
+	}
+	if r.Header.Get("Range") == "" {
+		return true
+	}
+
+	ifRange := r.Header.Get("If-Range")
+	return ifRange != "" && (download.ETag == "" || ifRange != download.ETag)
+}
+
+// contentDisposition function is returning a Content-Disposition header, accepting a Download.
+// Images are shown in the browser, and the other files are saved under their file name.
+func contentDisposition(download *service.Download) string {
+	disposition := "attachment"
+	if filemanage.IsImage(download.MimeType) {
+		disposition = "inline"
+	}
+
+	// 한글 파일 이름은 RFC 2231 형식으로 인코딩됩니다.
+	header := mime.FormatMediaType(disposition, map[string]string{"filename": download.FileName})
+	if header == "" {
+		return disposition
+	}
+	return header
+}
+
+// etagMatch function is returning whether an If-None-Match header matches an entity tag, accepting the header and the entity tag.
+// The weak comparison is used as RFC 9110 requires for If-None-Match.
+func etagMatch(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// pathIdx function is returning an index and an error, accepting a request routed with an {idx} wildcard.
+func pathIdx(r *http.Request) (int32, error) {
+	idx, err := strconv.ParseInt(r.PathValue("idx"), 10, 32)
+	if err != nil {
+		return 0, errors.Join(constants.ErrItemNotFound, err)
+	}
+	return int32(idx), nil
+}
+
+// pathPosition function is returning an attachment position and an error, accepting a request with a position path value.
+func pathPosition(r *http.Request) (int32, error) {
+	position, err := strconv.ParseInt(r.PathValue("position"), 10, 32)
+	if err != nil {
+		return 0, errors.Join(constants.ErrItemNotFound, err)
+	}
+	return int32(position), nil
+}
+
+// queryRevision function is returning a revision and an error, accepting a request with an optional revision query.
+// The revision is 0 when the query is not given.
+func queryRevision(r *http.Request) (int32, error) {
+	value := r.URL.Query().Get("revision")
+	if value == "" {
+		return 0, nil
+	}
+
+	revision, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || revision < 1 {
+		return 0, errors.Join(constants.ErrItemNotFound, err)
+	}
+	return int32(revision), nil
+}
+
+// queryInt function is returning a number and an error, accepting a request and the name of an optional query.
+// The number is 0 when the query is not given.
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// multipartAttachments function is returning AttachmentStreams, accepting a multipart reader.
+// A "labels" field names the "attachments" file in the same order, so the labels are sent before the files.
+// The file name is used when no label is given, a "log" file is a text log, and the other parts are skipped.
+func multipartAttachments(reader *multipart.Reader) service.AttachmentStreams {
+	labels := make([]string, 0)
+	files := 0
+	return func() (*service.AttachmentStream, error) {
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			if err != nil {
+				return nil, errors.Join(constants.ErrFileMultipart, err)
+			}
+
+			switch part.FormName() {
+			case "labels":
+				label, err := io.ReadAll(io.LimitReader(part, maxLabelSize))
+				if err != nil {
+					return nil, errors.Join(constants.ErrFileMultipart, err)
+				}
+				labels = append(labels, string(label))
+			case "attachments":
+				label := part.FileName()
+				if files < len(labels) {
+					label = labels[files]
+				}
+				files++
+				return &service.AttachmentStream{Label: label, Reader: part}, nil
+			case "log":
+				return &service.AttachmentStream{Label: part.FileName(), Reader: part, Log: true}, nil
+			}
+		}
+	}
+}
+
+// countingWriter struct is composed of a writer and the number of bytes written to it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write method is returning the number of written bytes and an error, accepting a data.
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
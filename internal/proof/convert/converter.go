@@ -33,6 +33,7 @@ type ControllerConverter interface {
 // goverter:extend TimeToTimestamppb TimeToPTimestamppb TimestampppbToTime TimestampppbToPTime PStringToString Pint32ToInt32
 type ServiceConverter interface {
 	// goverter:map TokenId TokenID
+	// goverter:ignore State CycleIdx DueAt RemindedAt LegalHold LegalHoldReason LegalHoldUserIdx LegalHoldAt PurgedAt SourceProofIdx
 	ProtoToModel(*apiv1.Proof) *model.Proof
 	// goverter:ignore state sizeCache unknownFields CreatedUserId UpdatedUserId UploadedUserId
 	// goverter:map TokenID TokenId
@@ -68,6 +69,14 @@ func PStringToString(s *string) string {
 	return *s
 }
 
+// StringToPString function is returning a string pointer accepting string, which is nil for an empty string.
+func StringToPString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // Pint32ToInt32 function is returning a int32 accepting int32 pointer.
 func Pint32ToInt32(i *int32) int32 {
 	if i == nil {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
@@ -20,11 +21,23 @@ type ProofCommander interface {
 	dbmanage.Beginner
 	dbmanage.Commiter
 	dbmanage.Rollbacker
+	dbmanage.Locker
 	ProofCreator
 	ProofUpdater
 	ProofDeleter
 	ProofUploader
 	ProofConfirmer
+	ProofStateTransitioner
+	ProofRejecter
+	ProofRevisioner
+	ProofAttacher
+	ProofCycler
+	ControlImporter
+	ProofControlMapper
+	ProofScheduler
+	ProofCommenter
+	ProofRetainer
+	ProofIntegrityRecorder
 }
 
 // ProofCreator interface is defining data related to commanding created item.
@@ -53,6 +66,78 @@ type ProofConfirmer interface {
 	ConfirmUpdateProof(ctx context.Context, proof *model.Proof, tx *sql.Tx) error
 }
 
+// ProofStateTransitioner interface is defining data related to commanding transited state.
+type ProofStateTransitioner interface {
+	TransitProofState(ctx context.Context, history *model.ProofStateHistory, tx *sql.Tx) error
+}
+
+// ProofRejecter interface is defining data related to commanding rejected item.
+type ProofRejecter interface {
+	CreateProofReject(ctx context.Context, reject *model.ProofReject, tx *sql.Tx) (idx int32, err error)
+	ResolveProofReject(ctx context.Context, proofIdx int32, resolvedAt time.Time, tx *sql.Tx) error
+}
+
+// ProofRevisioner interface is defining data related to commanding revision item.
+type ProofRevisioner interface {
+	CreateProofRevision(ctx context.Context, revision *model.ProofRevision, tx *sql.Tx) (idx int32, err error)
+	ConfirmProofRevision(ctx context.Context, revisionIdx int32, tokenID int32, digest string, tx *sql.Tx) error
+}
+
+// ProofAttacher interface is defining data related to commanding attachment item.
+type ProofAttacher interface {
+	CreateProofAttachments(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error
+	CreateEvidenceBlobs(ctx context.Context, blobs []*model.EvidenceBlob, tx *sql.Tx) error
+	UpdateProofAttachmentThumbnail(ctx context.Context, idx int32, thumbnailPath string, tx *sql.Tx) error
+	UpdateProofAttachmentType(ctx context.Context, idx int32, mimeType string, tx *sql.Tx) error
+	UpdateProofAttachmentHash(ctx context.Context, idx int32, hash string, tx *sql.Tx) error
+	LockEvidenceBlobs(ctx context.Context, digests []string, tx *sql.Tx) (locked []string, err error)
+	DeleteOrphanedEvidenceBlob(ctx context.Context, digest string, orphanedBefore time.Time, tx *sql.Tx) (deleted bool, err error)
+}
+
+// ProofCycler interface is defining data related to commanding assessment cycle item.
+type ProofCycler interface {
+	CreateCycle(ctx context.Context, cycle *model.AssessmentCycle, tx *sql.Tx) (idx int32, err error)
+	EndCycle(ctx context.Context, idx int32, endedAt time.Time, tx *sql.Tx) error
+	CarryForwardProofs(ctx context.Context, fromCycleIdx int32, toCycleIdx int32, userIdx int32, createdAt time.Time, tx *sql.Tx) (count int64, err error)
+}
+
+// ControlImporter interface is defining data related to commanding control catalog item.
+type ControlImporter interface {
+	UpsertControls(ctx context.Context, controls []*model.Control, tx *sql.Tx) (count int64, err error)
+}
+
+// ProofControlMapper interface is defining data related to commanding proof and control mapping item.
+type ProofControlMapper interface {
+	ReplaceProofControls(ctx context.Context, proofIdx int32, controlIdxs []int32, tx *sql.Tx) error
+}
+
+// ProofScheduler interface is defining data related to commanding due date and reminder of item.
+type ProofScheduler interface {
+	UpdateProofDueAt(ctx context.Context, idx int32, dueAt *time.Time, tx *sql.Tx) error
+	MarkProofsReminded(ctx context.Context, idxs []int32, remindedAt time.Time, tx *sql.Tx) error
+}
+
+// ProofCommenter interface is defining data related to commanding comment item.
+type ProofCommenter interface {
+	CreateProofComment(ctx context.Context, comment *model.ProofComment, tx *sql.Tx) (idx int32, err error)
+	UpdateProofComment(ctx context.Context, idx int32, body string, editedAt time.Time, tx *sql.Tx) error
+	DeleteProofComment(ctx context.Context, idx int32, deletedAt time.Time, tx *sql.Tx) error
+}
+
+// ProofRetainer interface is defining data related to commanding retention policy, legal hold and purged item.
+type ProofRetainer interface {
+	SetRetentionPolicy(ctx context.Context, policy *model.RetentionPolicy, tx *sql.Tx) (idx int32, err error)
+	DeleteRetentionPolicy(ctx context.Context, idx int32, tx *sql.Tx) error
+	UpdateProofLegalHold(ctx context.Context, proof *model.Proof, tx *sql.Tx) error
+	PurgeProofEvidence(ctx context.Context, proofIdx int32, purgedAt time.Time, tx *sql.Tx) (count int64, err error)
+	DeleteEvidenceBlob(ctx context.Context, digest string, tx *sql.Tx) (deleted bool, err error)
+}
+
+// ProofIntegrityRecorder interface is defining data related to commanding integrity check results.
+type ProofIntegrityRecorder interface {
+	CreateIntegrityChecks(ctx context.Context, checks []*model.IntegrityCheck, tx *sql.Tx) error
+}
+
 type proofCommand struct {
 	db *sql.DB
 }
@@ -64,17 +149,30 @@ func NewProofCommand(db *sql.DB) ProofCommander {
 
 func (c *proofCommand) Begin(ctx context.Context) (*sql.Tx, error) {
 	tx, err := c.db.BeginTx(ctx, nil)
-	return tx, errors.Join(constants.ErrBegin, err)
+	if err != nil {
+		return nil, errors.Join(constants.ErrBegin, err)
+	}
+	return tx, nil
 }
 
 func (c *proofCommand) Commit(_ context.Context, tx *sql.Tx) error {
 	err := tx.Commit()
-	return errors.Join(constants.ErrCommit, err)
+	if err != nil {
+		return errors.Join(constants.ErrCommit, err)
+	}
+	return nil
 }
 
 func (c *proofCommand) Rollback(_ context.Context, tx *sql.Tx) error {
 	err := tx.Rollback()
-	return errors.Join(constants.ErrRollback, err)
+	if err != nil {
+		return errors.Join(constants.ErrRollback, err)
+	}
+	return nil
+}
+
+func (c *proofCommand) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	return dbmanage.TryLock(ctx, c.db, key)
 }
 
 func (c *proofCommand) CreateProof(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error) {
@@ -88,6 +186,8 @@ func (c *proofCommand) CreateProof(ctx context.Context, proof *model.Proof, tx *
 			table.Proof.CreatedAt,
 			table.Proof.UpdatedUserIdx,
 			table.Proof.UpdatedAt,
+			table.Proof.State,
+			table.Proof.CycleIdx,
 		).
 		MODEL(proof).
 		RETURNING(table.Proof.Idx)
@@ -114,7 +214,6 @@ func (c *proofCommand) UpdateProof(ctx context.Context, proof *model.Proof, tx *
 			table.Proof.Category,
 			table.Proof.Description,
 			table.Proof.UploadedUserIdx,
-			table.Proof.Confirm,
 			table.Proof.UpdatedUserIdx,
 			table.Proof.UpdatedAt,
 		).
@@ -274,3 +373,1048 @@ func (c *proofCommand) ConfirmUpdateProof(ctx context.Context, proof *model.Proo
 
 	return nil
 }
+
+func (c *proofCommand) TransitProofState(ctx context.Context, history *model.ProofStateHistory, tx *sql.Tx) error {
+	// confirm 컬럼은 기존 API 호환을 위해 상태와 함께 갱신합니다.
+	confirm := constants.NotConfirm
+	if history.ToState == constants.StateConfirmed {
+		confirm = constants.Confirm
+	}
+
+	updateStmt := table.Proof.
+		UPDATE(table.Proof.State, table.Proof.Confirm).
+		SET(postgres.Int32(history.ToState), postgres.Int32(confirm)).
+		WHERE(
+			table.Proof.Idx.EQ(postgres.Int32(history.ProofIdx)).
+				AND(table.Proof.State.EQ(postgres.Int32(history.FromState))),
+		)
+
+	insertStmt := table.ProofStateHistory.
+		INSERT(
+			table.ProofStateHistory.ProofIdx,
+			table.ProofStateHistory.FromState,
+			table.ProofStateHistory.ToState,
+			table.ProofStateHistory.UserIdx,
+			table.ProofStateHistory.CreatedAt,
+		).
+		MODEL(history)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrStateConflict
+	}
+
+	_, err = insertStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	return nil
+}
+
+func (c *proofCommand) CreateProofReject(ctx context.Context, reject *model.ProofReject, tx *sql.Tx) (int32, error) {
+	insertStmt := table.ProofReject.
+		INSERT(
+			table.ProofReject.ProofIdx,
+			table.ProofReject.Reason,
+			table.ProofReject.RejectedUserIdx,
+			table.ProofReject.RejectedAt,
+		).
+		MODEL(reject).
+		RETURNING(table.ProofReject.Idx)
+
+	var executable qrm.Queryable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	dest := &model.ProofReject{}
+	err := insertStmt.QueryContext(ctx, executable, dest)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	return dest.Idx, nil
+}
+
+func (c *proofCommand) ResolveProofReject(ctx context.Context, proofIdx int32, resolvedAt time.Time, tx *sql.Tx) error {
+	updateStmt := table.ProofReject.
+		UPDATE(table.ProofReject.ResolvedAt).
+		SET(postgres.TimestampzT(resolvedAt)).
+		WHERE(
+			table.ProofReject.ProofIdx.EQ(postgres.Int32(proofIdx)).
+				AND(table.ProofReject.ResolvedAt.IS_NULL()),
+		)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	_, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	return nil
+}
+
+// CreateProofRevision method numbers the revision after the latest one of the proof.
+// The caller is expected to hold the proof row lock in the same transaction.
+func (c *proofCommand) CreateProofRevision(ctx context.Context, revision *model.ProofRevision, tx *sql.Tx) (int32, error) {
+	latestStmt := table.ProofRevision.
+		SELECT(table.ProofRevision.Revision).
+		WHERE(table.ProofRevision.ProofIdx.EQ(postgres.Int32(revision.ProofIdx))).
+		ORDER_BY(table.ProofRevision.Revision.DESC()).
+		LIMIT(1)
+
+	insertStmt := table.ProofRevision.
+		INSERT(
+			table.ProofRevision.ProofIdx,
+			table.ProofRevision.Revision,
+			table.ProofRevision.UploadedUserIdx,
+			table.ProofRevision.UploadedAt,
+			table.ProofRevision.Digest,
+		).
+		MODEL(revision).
+		RETURNING(table.ProofRevision.Idx)
+
+	var executable qrm.Queryable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	latest := &model.ProofRevision{}
+	err := latestStmt.QueryContext(ctx, executable, latest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return 0, errors.Join(constants.ErrQuery, err)
+	}
+	revision.Revision = latest.Revision + 1
+
+	dest := &model.ProofRevision{}
+	err = insertStmt.QueryContext(ctx, executable, dest)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	return dest.Idx, nil
+}
+
+// ConfirmProofRevision method records the chain token of a revision and the digest anchored with it.
+func (c *proofCommand) ConfirmProofRevision(ctx context.Context, revisionIdx int32, tokenID int32, digest string, tx *sql.Tx) error {
+	updateStmt := table.ProofRevision.
+		UPDATE(table.ProofRevision.TokenID, table.ProofRevision.Digest).
+		SET(postgres.Int32(tokenID), postgres.String(digest)).
+		WHERE(table.ProofRevision.Idx.EQ(postgres.Int32(revisionIdx)))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (c *proofCommand) CreateProofAttachments(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+	insertStmt := table.ProofAttachment.
+		INSERT(table.ProofAttachment.MutableColumns).
+		MODELS(attachments)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	_, err := insertStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	return nil
+}
+
+// CreateEvidenceBlobs method is registering blobs not stored yet, and the reference count is kept by the attachment trigger.
+func (c *proofCommand) CreateEvidenceBlobs(ctx context.Context, blobs []*model.EvidenceBlob, tx *sql.Tx) error {
+	insertStmt := table.EvidenceBlob.
+		INSERT(
+			table.EvidenceBlob.Digest,
+			table.EvidenceBlob.Path,
+			table.EvidenceBlob.MimeType,
+			table.EvidenceBlob.Size,
+			table.EvidenceBlob.CreatedAt,
+		).
+		MODELS(blobs).
+		ON_CONFLICT(table.EvidenceBlob.Digest).
+		DO_NOTHING()
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	_, err := insertStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	return nil
+}
+
+// UpdateProofAttachmentThumbnail method records the thumbnail made for an attachment uploaded before thumbnails existed.
+func (c *proofCommand) UpdateProofAttachmentThumbnail(ctx context.Context, idx int32, thumbnailPath string, tx *sql.Tx) error {
+	updateStmt := table.ProofAttachment.
+		UPDATE(table.ProofAttachment.ThumbnailPath).
+		SET(postgres.String(thumbnailPath)).
+		WHERE(table.ProofAttachment.Idx.EQ(postgres.Int32(idx)))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// UpdateProofAttachmentType method records the type sniffed from an attachment migrated without a detected type.
+func (c *proofCommand) UpdateProofAttachmentType(ctx context.Context, idx int32, mimeType string, tx *sql.Tx) error {
+	updateStmt := table.ProofAttachment.
+		UPDATE(table.ProofAttachment.MimeType).
+		SET(postgres.String(mimeType)).
+		WHERE(table.ProofAttachment.Idx.EQ(postgres.Int32(idx)))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// UpdateProofAttachmentHash method records the hash of an attachment migrated without a hash.
+// A hash already recorded is never replaced.
+func (c *proofCommand) UpdateProofAttachmentHash(ctx context.Context, idx int32, hash string, tx *sql.Tx) error {
+	updateStmt := table.ProofAttachment.
+		UPDATE(table.ProofAttachment.Hash).
+		SET(postgres.String(hash)).
+		WHERE(
+			table.ProofAttachment.Idx.EQ(postgres.Int32(idx)).
+				AND(table.ProofAttachment.Hash.EQ(postgres.String(""))),
+		)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// LockEvidenceBlobs method locks the blobs of the given digests until the transaction ends, and lists the digests still registered.
+// A blob locked by an upload cannot be removed until the upload references it, and a blob removed before is left out.
+func (c *proofCommand) LockEvidenceBlobs(ctx context.Context, digests []string, tx *sql.Tx) ([]string, error) {
+	if len(digests) == 0 {
+		return []string{}, nil
+	}
+
+	values := make([]postgres.Expression, len(digests))
+	for i, digest := range digests {
+		values[i] = postgres.String(digest)
+	}
+
+	lockStmt := table.EvidenceBlob.
+		SELECT(table.EvidenceBlob.Digest).
+		WHERE(table.EvidenceBlob.Digest.IN(values...)).
+		FOR(postgres.UPDATE())
+
+	var executable qrm.Queryable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	dest := make([]*model.EvidenceBlob, 0)
+	err := lockStmt.QueryContext(ctx, executable, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	locked := make([]string, len(dest))
+	for i, blob := range dest {
+		locked[i] = blob.Digest
+	}
+
+	return locked, nil
+}
+
+// DeleteOrphanedEvidenceBlob method only deletes a blob no attachment has referenced since the given time, and reports whether it was deleted.
+// The row stays locked until the transaction ends, so an upload reusing the blob waits until its file is removed.
+func (c *proofCommand) DeleteOrphanedEvidenceBlob(ctx context.Context, digest string, orphanedBefore time.Time, tx *sql.Tx) (bool, error) {
+	deleteStmt := table.EvidenceBlob.
+		DELETE().
+		WHERE(
+			table.EvidenceBlob.Digest.EQ(postgres.String(digest)).
+				AND(table.EvidenceBlob.RefCount.EQ(postgres.Int32(0))).
+				AND(postgres.TimestampzExp(postgres.COALESCE(table.EvidenceBlob.OrphanedAt, table.EvidenceBlob.CreatedAt)).LT(postgres.TimestampzT(orphanedBefore))),
+		)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := deleteStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return false, errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return false, errors.Join(constants.ErrRowResult, err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (c *proofCommand) CreateCycle(ctx context.Context, cycle *model.AssessmentCycle, tx *sql.Tx) (int32, error) {
+	insertStmt := table.AssessmentCycle.
+		INSERT(
+			table.AssessmentCycle.Name,
+			table.AssessmentCycle.StartedUserIdx,
+			table.AssessmentCycle.StartedAt,
+		).
+		MODEL(cycle).
+		RETURNING(table.AssessmentCycle.Idx)
+
+	var executable qrm.Queryable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	dest := &model.AssessmentCycle{}
+	err := insertStmt.QueryContext(ctx, executable, dest)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	return dest.Idx, nil
+}
+
+// EndCycle method only ends a cycle that is still active, so that two cycles cannot be started from the same one.
+func (c *proofCommand) EndCycle(ctx context.Context, idx int32, endedAt time.Time, tx *sql.Tx) error {
+	updateStmt := table.AssessmentCycle.
+		UPDATE(table.AssessmentCycle.EndedAt).
+		SET(postgres.TimestampzT(endedAt)).
+		WHERE(
+			table.AssessmentCycle.Idx.EQ(postgres.Int32(idx)).
+				AND(table.AssessmentCycle.EndedAt.IS_NULL()),
+		)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrStateConflict
+	}
+
+	return nil
+}
+
+// CarryForwardProofs method clones the proof definitions of a cycle into another cycle without any uploaded evidence.
+// A cloned proof with an assignee starts as assigned and the transition is recorded in the state history.
+// An assignee of 0, which older proofs stored for no assignee, is cloned as no assignee.
+// Every clone records the proof it was cloned from, and the control mappings are carried forward through that source.
+func (c *proofCommand) CarryForwardProofs(ctx context.Context, fromCycleIdx int32, toCycleIdx int32, userIdx int32, createdAt time.Time, tx *sql.Tx) (int64, error) {
+	cloneStmt := table.Proof.
+		INSERT(
+			table.Proof.Num,
+			table.Proof.Category,
+			table.Proof.Description,
+			table.Proof.UploadedUserIdx,
+			table.Proof.CreatedUserIdx,
+			table.Proof.CreatedAt,
+			table.Proof.Confirm,
+			table.Proof.State,
+			table.Proof.CycleIdx,
+			table.Proof.SourceProofIdx,
+		).
+		QUERY(
+			table.Proof.
+				SELECT(
+					table.Proof.Num,
+					table.Proof.Category,
+					table.Proof.Description,
+					postgres.NULLIF(table.Proof.UploadedUserIdx, postgres.Int32(0)),
+					postgres.Int32(userIdx),
+					postgres.TimestampzT(createdAt),
+					postgres.Int32(constants.NotConfirm),
+					postgres.CASE().
+						WHEN(postgres.IntExp(postgres.COALESCE(table.Proof.UploadedUserIdx, postgres.Int32(0))).EQ(postgres.Int32(0))).
+						THEN(postgres.Int32(constants.StateDraft)).
+						ELSE(postgres.Int32(constants.StateAssigned)),
+					postgres.Int32(toCycleIdx),
+					table.Proof.Idx,
+				).
+				WHERE(table.Proof.CycleIdx.EQ(postgres.Int32(fromCycleIdx))).
+				ORDER_BY(table.Proof.Idx.ASC()),
+		).
+		RETURNING(table.Proof.Idx, table.Proof.State)
+
+	var executable qrm.DB
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	cloned := make([]*model.Proof, 0)
+	err := cloneStmt.QueryContext(ctx, executable, &cloned)
+	if errors.Is(err, qrm.ErrNoRows) || len(cloned) == 0 {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	histories := make([]*model.ProofStateHistory, 0, len(cloned))
+	clonedIdxs := make([]postgres.Expression, len(cloned))
+	for i, proof := range cloned {
+		clonedIdxs[i] = postgres.Int32(proof.Idx)
+		if proof.State != constants.StateAssigned {
+			continue
+		}
+		histories = append(histories, &model.ProofStateHistory{
+			ProofIdx:  proof.Idx,
+			FromState: constants.StateDraft,
+			ToState:   constants.StateAssigned,
+			UserIdx:   userIdx,
+			CreatedAt: createdAt,
+		})
+	}
+
+	if len(histories) > 0 {
+		historyStmt := table.ProofStateHistory.
+			INSERT(
+				table.ProofStateHistory.ProofIdx,
+				table.ProofStateHistory.FromState,
+				table.ProofStateHistory.ToState,
+				table.ProofStateHistory.UserIdx,
+				table.ProofStateHistory.CreatedAt,
+			).
+			MODELS(histories)
+
+		_, err = historyStmt.ExecContext(ctx, executable)
+		if err != nil {
+			return 0, errors.Join(constants.ErrExecute, err)
+		}
+	}
+
+	controlStmt := table.ProofControl.
+		INSERT(table.ProofControl.ProofIdx, table.ProofControl.ControlIdx).
+		QUERY(
+			table.Proof.
+				INNER_JOIN(table.ProofControl, table.ProofControl.ProofIdx.EQ(table.Proof.SourceProofIdx)).
+				SELECT(table.Proof.Idx, table.ProofControl.ControlIdx).
+				WHERE(table.Proof.Idx.IN(clonedIdxs...)),
+		)
+
+	_, err = controlStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	return int64(len(cloned)), nil
+}
+
+// UpsertControls method inserts the controls, updating the title and the parent of a control already in the catalog.
+func (c *proofCommand) UpsertControls(ctx context.Context, controls []*model.Control, tx *sql.Tx) (int64, error) {
+	upsertStmt := table.Control.
+		INSERT(
+			table.Control.Framework,
+			table.Control.ControlID,
+			table.Control.Title,
+			table.Control.ParentControlID,
+		).
+		MODELS(controls).
+		ON_CONFLICT(table.Control.Framework, table.Control.ControlID).
+		DO_UPDATE(postgres.SET(
+			table.Control.Title.SET(table.Control.EXCLUDED.Title),
+			table.Control.ParentControlID.SET(table.Control.EXCLUDED.ParentControlID),
+		))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := upsertStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	count, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(constants.ErrRowResult, err)
+	}
+
+	return count, nil
+}
+
+// ReplaceProofControls method replaces every control mapped to a proof with the given controls.
+func (c *proofCommand) ReplaceProofControls(ctx context.Context, proofIdx int32, controlIdxs []int32, tx *sql.Tx) error {
+	deleteStmt := table.ProofControl.
+		DELETE().
+		WHERE(table.ProofControl.ProofIdx.EQ(postgres.Int32(proofIdx)))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	_, err := deleteStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	if len(controlIdxs) == 0 {
+		return nil
+	}
+
+	mappings := make([]*model.ProofControl, len(controlIdxs))
+	for i, controlIdx := range controlIdxs {
+		mappings[i] = &model.ProofControl{ProofIdx: proofIdx, ControlIdx: controlIdx}
+	}
+
+	insertStmt := table.ProofControl.
+		INSERT(table.ProofControl.ProofIdx, table.ProofControl.ControlIdx).
+		MODELS(mappings)
+
+	_, err = insertStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	return nil
+}
+
+// UpdateProofDueAt method sets or clears the due date of a proof, so that reminders for the new due date start over.
+func (c *proofCommand) UpdateProofDueAt(ctx context.Context, idx int32, dueAt *time.Time, tx *sql.Tx) error {
+	updateStmt := table.Proof.
+		UPDATE(table.Proof.DueAt, table.Proof.RemindedAt).
+		MODEL(&model.Proof{DueAt: dueAt}).
+		WHERE(table.Proof.Idx.EQ(postgres.Int32(idx)))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (c *proofCommand) MarkProofsReminded(ctx context.Context, idxs []int32, remindedAt time.Time, tx *sql.Tx) error {
+	if len(idxs) == 0 {
+		return nil
+	}
+
+	expressions := make([]postgres.Expression, len(idxs))
+	for i, idx := range idxs {
+		expressions[i] = postgres.Int32(idx)
+	}
+
+	updateStmt := table.Proof.
+		UPDATE(table.Proof.RemindedAt).
+		SET(postgres.TimestampzT(remindedAt)).
+		WHERE(table.Proof.Idx.IN(expressions...))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	_, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	return nil
+}
+
+func (c *proofCommand) CreateProofComment(ctx context.Context, comment *model.ProofComment, tx *sql.Tx) (int32, error) {
+	insertStmt := table.ProofComment.
+		INSERT(
+			table.ProofComment.ProofIdx,
+			table.ProofComment.UserIdx,
+			table.ProofComment.Body,
+			table.ProofComment.CreatedAt,
+		).
+		MODEL(comment).
+		RETURNING(table.ProofComment.Idx)
+
+	var executable qrm.Queryable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	dest := &model.ProofComment{}
+	err := insertStmt.QueryContext(ctx, executable, dest)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	return dest.Idx, nil
+}
+
+func (c *proofCommand) UpdateProofComment(ctx context.Context, idx int32, body string, editedAt time.Time, tx *sql.Tx) error {
+	updateStmt := table.ProofComment.
+		UPDATE(table.ProofComment.Body, table.ProofComment.EditedAt).
+		SET(postgres.String(body), postgres.TimestampzT(editedAt)).
+		WHERE(
+			table.ProofComment.Idx.EQ(postgres.Int32(idx)).
+				AND(table.ProofComment.DeletedAt.IS_NULL()),
+		)
+
+	return c.execCommentStmt(ctx, updateStmt, tx)
+}
+
+func (c *proofCommand) DeleteProofComment(ctx context.Context, idx int32, deletedAt time.Time, tx *sql.Tx) error {
+	updateStmt := table.ProofComment.
+		UPDATE(table.ProofComment.DeletedAt).
+		SET(postgres.TimestampzT(deletedAt)).
+		WHERE(
+			table.ProofComment.Idx.EQ(postgres.Int32(idx)).
+				AND(table.ProofComment.DeletedAt.IS_NULL()),
+		)
+
+	return c.execCommentStmt(ctx, updateStmt, tx)
+}
+
+// execCommentStmt method is returning an error, accepting a context, a comment update statement and a transaction.
+// A deleted or missing comment affects no row and is reported as ErrItemNotFound.
+func (c *proofCommand) execCommentStmt(ctx context.Context, updateStmt postgres.UpdateStatement, tx *sql.Tx) error {
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// SetRetentionPolicy method replaces the policy of the same category, cycle or default scope with the given one.
+func (c *proofCommand) SetRetentionPolicy(ctx context.Context, policy *model.RetentionPolicy, tx *sql.Tx) (int32, error) {
+	scope := table.RetentionPolicy.Category.IS_NULL()
+	if policy.Category != nil {
+		scope = table.RetentionPolicy.Category.EQ(postgres.String(*policy.Category))
+	}
+	if policy.CycleIdx != nil {
+		scope = scope.AND(table.RetentionPolicy.CycleIdx.EQ(postgres.Int32(*policy.CycleIdx)))
+	} else {
+		scope = scope.AND(table.RetentionPolicy.CycleIdx.IS_NULL())
+	}
+
+	deleteStmt := table.RetentionPolicy.
+		DELETE().
+		WHERE(scope)
+
+	insertStmt := table.RetentionPolicy.
+		INSERT(
+			table.RetentionPolicy.Category,
+			table.RetentionPolicy.CycleIdx,
+			table.RetentionPolicy.RetentionDays,
+			table.RetentionPolicy.CreatedUserIdx,
+			table.RetentionPolicy.CreatedAt,
+		).
+		MODEL(policy).
+		RETURNING(table.RetentionPolicy.Idx)
+
+	var executable qrm.DB
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	_, err := deleteStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	dest := &model.RetentionPolicy{}
+	err = insertStmt.QueryContext(ctx, executable, dest)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	return dest.Idx, nil
+}
+
+func (c *proofCommand) DeleteRetentionPolicy(ctx context.Context, idx int32, tx *sql.Tx) error {
+	deleteStmt := table.RetentionPolicy.
+		DELETE().
+		WHERE(table.RetentionPolicy.Idx.EQ(postgres.Int32(idx)))
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := deleteStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// UpdateProofLegalHold method only updates a proof whose evidence is not purged yet.
+func (c *proofCommand) UpdateProofLegalHold(ctx context.Context, proof *model.Proof, tx *sql.Tx) error {
+	updateStmt := table.Proof.
+		UPDATE(
+			table.Proof.LegalHold,
+			table.Proof.LegalHoldReason,
+			table.Proof.LegalHoldUserIdx,
+			table.Proof.LegalHoldAt,
+		).
+		MODEL(proof).
+		WHERE(
+			table.Proof.Idx.EQ(postgres.Int32(proof.Idx)).
+				AND(table.Proof.PurgedAt.IS_NULL()),
+		)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return constants.ErrStateConflict
+	}
+
+	return nil
+}
+
+// PurgeProofEvidence method records the hashes and chain tokens of the attachments of every revision of a proof and deletes the attachments.
+// The proof is marked purged first, so that a proof put on legal hold in the meantime is reported as ErrProofLegalHold and left as it is,
+// and a proof purged or deleted in the meantime is reported as ErrProofPurged or ErrItemNotFound.
+// The revisions are kept, and the blobs the attachments referenced are released by the reference count trigger.
+func (c *proofCommand) PurgeProofEvidence(ctx context.Context, proofIdx int32, purgedAt time.Time, tx *sql.Tx) (int64, error) {
+	updateStmt := table.Proof.
+		UPDATE(table.Proof.PurgedAt, table.Proof.FirstImagePath, table.Proof.SecondImagePath, table.Proof.LogPath).
+		SET(postgres.TimestampzT(purgedAt), postgres.NULL, postgres.NULL, postgres.NULL).
+		WHERE(
+			table.Proof.Idx.EQ(postgres.Int32(proofIdx)).
+				AND(table.Proof.LegalHold.IS_FALSE()).
+				AND(table.Proof.PurgedAt.IS_NULL()),
+		)
+
+	insertStmt := table.PurgedEvidence.
+		INSERT(
+			table.PurgedEvidence.ProofIdx,
+			table.PurgedEvidence.Revision,
+			table.PurgedEvidence.Position,
+			table.PurgedEvidence.Label,
+			table.PurgedEvidence.MimeType,
+			table.PurgedEvidence.Hash,
+			table.PurgedEvidence.Size,
+			table.PurgedEvidence.TokenID,
+			table.PurgedEvidence.UploadedAt,
+			table.PurgedEvidence.PurgedAt,
+		).
+		QUERY(
+			postgres.SELECT(
+				table.ProofAttachment.ProofIdx,
+				table.ProofRevision.Revision,
+				table.ProofAttachment.Position,
+				table.ProofAttachment.Label,
+				table.ProofAttachment.MimeType,
+				table.ProofAttachment.Hash,
+				table.ProofAttachment.Size,
+				table.ProofRevision.TokenID,
+				table.ProofRevision.UploadedAt,
+				postgres.TimestampzT(purgedAt),
+			).
+				FROM(
+					table.ProofAttachment.
+						INNER_JOIN(table.ProofRevision, table.ProofRevision.Idx.EQ(table.ProofAttachment.RevisionIdx)),
+				).
+				WHERE(table.ProofAttachment.ProofIdx.EQ(postgres.Int32(proofIdx))),
+		)
+
+	deleteStmt := table.ProofAttachment.
+		DELETE().
+		WHERE(table.ProofAttachment.ProofIdx.EQ(postgres.Int32(proofIdx)))
+
+	var executable qrm.DB
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := updateStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(constants.ErrRowResult, err)
+	}
+	if rowsAffected == 0 {
+		return 0, c.unpurgedReason(ctx, proofIdx, executable)
+	}
+
+	_, err = insertStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	sqlResult, err = deleteStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return 0, errors.Join(constants.ErrExecute, err)
+	}
+
+	count, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(constants.ErrRowResult, err)
+	}
+
+	return count, nil
+}
+
+// unpurgedReason method is returning why a proof was not purged, accepting a context, a proof index and a queryable.
+// A proof already purged is reported as ErrProofPurged, a deleted proof as ErrItemNotFound and a held proof as ErrProofLegalHold.
+func (c *proofCommand) unpurgedReason(ctx context.Context, proofIdx int32, queryable qrm.Queryable) error {
+	stmt := postgres.
+		SELECT(table.Proof.LegalHold, table.Proof.PurgedAt).
+		FROM(table.Proof).
+		WHERE(table.Proof.Idx.EQ(postgres.Int32(proofIdx)))
+
+	dest := &model.Proof{}
+	err := stmt.QueryContext(ctx, queryable, dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return constants.ErrItemNotFound
+	}
+	if err != nil {
+		return errors.Join(constants.ErrQuery, err)
+	}
+	if dest.PurgedAt != nil {
+		return constants.ErrProofPurged
+	}
+	return constants.ErrProofLegalHold
+}
+
+// DeleteEvidenceBlob method only deletes a blob no attachment references, and reports whether it was deleted.
+// The row stays locked until the transaction ends, so the file is removed in the same transaction before an upload can reuse the blob.
+func (c *proofCommand) DeleteEvidenceBlob(ctx context.Context, digest string, tx *sql.Tx) (bool, error) {
+	deleteStmt := table.EvidenceBlob.
+		DELETE().
+		WHERE(
+			table.EvidenceBlob.Digest.EQ(postgres.String(digest)).
+				AND(table.EvidenceBlob.RefCount.EQ(postgres.Int32(0))),
+		)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	sqlResult, err := deleteStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return false, errors.Join(constants.ErrExecute, err)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return false, errors.Join(constants.ErrRowResult, err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CreateIntegrityChecks method records the results of an integrity check run.
+func (c *proofCommand) CreateIntegrityChecks(ctx context.Context, checks []*model.IntegrityCheck, tx *sql.Tx) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	insertStmt := table.IntegrityCheck.
+		INSERT(table.IntegrityCheck.MutableColumns).
+		MODELS(checks)
+
+	var executable qrm.Executable
+	if tx != nil {
+		executable = tx
+	} else {
+		executable = c.db
+	}
+
+	_, err := insertStmt.ExecContext(ctx, executable)
+	if err != nil {
+		return errors.Join(constants.ErrExecute, err)
+	}
+
+	return nil
+}
@@ -4,21 +4,51 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"time"
 
 	"security-proof/internal/db/security_proof/proof/model"
 )
 
 // MockProofCommand struct is used for testing the proofCommand structure.
 type MockProofCommand struct {
-	BeginFn              func(ctx context.Context) (*sql.Tx, error)
-	CommitFn             func(ctx context.Context, tx *sql.Tx) error
-	RollbackFn           func(ctx context.Context, tx *sql.Tx) error
-	CreateProofFn        func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error)
-	UpdateProofFn        func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error)
-	UploadProofFn        func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error)
-	DeleteProofFn        func(ctx context.Context, idx int32, tx *sql.Tx) error
-	ConfirmProofFn       func(ctx context.Context, proof *model.Proof, tx *sql.Tx) error
-	ConfirmUpdateProofFn func(ctx context.Context, proof *model.Proof, tx *sql.Tx) error
+	BeginFn                          func(ctx context.Context) (*sql.Tx, error)
+	CommitFn                         func(ctx context.Context, tx *sql.Tx) error
+	RollbackFn                       func(ctx context.Context, tx *sql.Tx) error
+	TryLockFn                        func(ctx context.Context, key int64) (func(), bool, error)
+	CreateProofFn                    func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error)
+	UpdateProofFn                    func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error)
+	UploadProofFn                    func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error)
+	DeleteProofFn                    func(ctx context.Context, idx int32, tx *sql.Tx) error
+	ConfirmProofFn                   func(ctx context.Context, proof *model.Proof, tx *sql.Tx) error
+	ConfirmUpdateProofFn             func(ctx context.Context, proof *model.Proof, tx *sql.Tx) error
+	TransitProofStateFn              func(ctx context.Context, history *model.ProofStateHistory, tx *sql.Tx) error
+	CreateProofRejectFn              func(ctx context.Context, reject *model.ProofReject, tx *sql.Tx) (int32, error)
+	ResolveProofRejectFn             func(ctx context.Context, proofIdx int32, resolvedAt time.Time, tx *sql.Tx) error
+	CreateProofRevisionFn            func(ctx context.Context, revision *model.ProofRevision, tx *sql.Tx) (int32, error)
+	ConfirmProofRevisionFn           func(ctx context.Context, revisionIdx int32, tokenID int32, digest string, tx *sql.Tx) error
+	CreateProofAttachmentsFn         func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error
+	CreateEvidenceBlobsFn            func(ctx context.Context, blobs []*model.EvidenceBlob, tx *sql.Tx) error
+	UpdateProofAttachmentThumbnailFn func(ctx context.Context, idx int32, thumbnailPath string, tx *sql.Tx) error
+	UpdateProofAttachmentTypeFn      func(ctx context.Context, idx int32, mimeType string, tx *sql.Tx) error
+	UpdateProofAttachmentHashFn      func(ctx context.Context, idx int32, hash string, tx *sql.Tx) error
+	LockEvidenceBlobsFn              func(ctx context.Context, digests []string, tx *sql.Tx) ([]string, error)
+	DeleteOrphanedEvidenceBlobFn     func(ctx context.Context, digest string, orphanedBefore time.Time, tx *sql.Tx) (bool, error)
+	CreateCycleFn                    func(ctx context.Context, cycle *model.AssessmentCycle, tx *sql.Tx) (int32, error)
+	EndCycleFn                       func(ctx context.Context, idx int32, endedAt time.Time, tx *sql.Tx) error
+	CarryForwardProofsFn             func(ctx context.Context, fromCycleIdx int32, toCycleIdx int32, userIdx int32, createdAt time.Time, tx *sql.Tx) (int64, error)
+	UpsertControlsFn                 func(ctx context.Context, controls []*model.Control, tx *sql.Tx) (int64, error)
+	ReplaceProofControlsFn           func(ctx context.Context, proofIdx int32, controlIdxs []int32, tx *sql.Tx) error
+	UpdateProofDueAtFn               func(ctx context.Context, idx int32, dueAt *time.Time, tx *sql.Tx) error
+	MarkProofsRemindedFn             func(ctx context.Context, idxs []int32, remindedAt time.Time, tx *sql.Tx) error
+	CreateProofCommentFn             func(ctx context.Context, comment *model.ProofComment, tx *sql.Tx) (int32, error)
+	UpdateProofCommentFn             func(ctx context.Context, idx int32, body string, editedAt time.Time, tx *sql.Tx) error
+	DeleteProofCommentFn             func(ctx context.Context, idx int32, deletedAt time.Time, tx *sql.Tx) error
+	SetRetentionPolicyFn             func(ctx context.Context, policy *model.RetentionPolicy, tx *sql.Tx) (int32, error)
+	DeleteRetentionPolicyFn          func(ctx context.Context, idx int32, tx *sql.Tx) error
+	UpdateProofLegalHoldFn           func(ctx context.Context, proof *model.Proof, tx *sql.Tx) error
+	PurgeProofEvidenceFn             func(ctx context.Context, proofIdx int32, purgedAt time.Time, tx *sql.Tx) (int64, error)
+	DeleteEvidenceBlobFn             func(ctx context.Context, digest string, tx *sql.Tx) (bool, error)
+	CreateIntegrityChecksFn          func(ctx context.Context, checks []*model.IntegrityCheck, tx *sql.Tx) error
 }
 
 // Begin method is the mock test function for Begin.
@@ -45,6 +75,14 @@ func (m *MockProofCommand) Rollback(ctx context.Context, tx *sql.Tx) error {
 	return m.RollbackFn(ctx, tx)
 }
 
+// TryLock method is the mock test function for TryLock.
+func (m *MockProofCommand) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	if m.TryLockFn == nil {
+		log.Fatal("mock TryLockFn is nil")
+	}
+	return m.TryLockFn(ctx, key)
+}
+
 // CreateProof method is the mock test function for CreateProof.
 func (m *MockProofCommand) CreateProof(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error) {
 	if m.CreateProofFn == nil {
@@ -92,3 +130,227 @@ func (m *MockProofCommand) ConfirmUpdateProof(ctx context.Context, proof *model.
 	}
 	return m.ConfirmUpdateProofFn(ctx, proof, tx)
 }
+
+// TransitProofState method is the mock test function for TransitProofState.
+func (m *MockProofCommand) TransitProofState(ctx context.Context, history *model.ProofStateHistory, tx *sql.Tx) error {
+	if m.TransitProofStateFn == nil {
+		log.Fatal("mock TransitProofStateFn is nil")
+	}
+	return m.TransitProofStateFn(ctx, history, tx)
+}
+
+// CreateProofReject method is the mock test function for CreateProofReject.
+func (m *MockProofCommand) CreateProofReject(ctx context.Context, reject *model.ProofReject, tx *sql.Tx) (int32, error) {
+	if m.CreateProofRejectFn == nil {
+		log.Fatal("mock CreateProofRejectFn is nil")
+	}
+	return m.CreateProofRejectFn(ctx, reject, tx)
+}
+
+// ResolveProofReject method is the mock test function for ResolveProofReject.
+func (m *MockProofCommand) ResolveProofReject(ctx context.Context, proofIdx int32, resolvedAt time.Time, tx *sql.Tx) error {
+	if m.ResolveProofRejectFn == nil {
+		log.Fatal("mock ResolveProofRejectFn is nil")
+	}
+	return m.ResolveProofRejectFn(ctx, proofIdx, resolvedAt, tx)
+}
+
+// CreateProofRevision method is the mock test function for CreateProofRevision.
+func (m *MockProofCommand) CreateProofRevision(ctx context.Context, revision *model.ProofRevision, tx *sql.Tx) (int32, error) {
+	if m.CreateProofRevisionFn == nil {
+		log.Fatal("mock CreateProofRevisionFn is nil")
+	}
+	return m.CreateProofRevisionFn(ctx, revision, tx)
+}
+
+// ConfirmProofRevision method is the mock test function for ConfirmProofRevision.
+func (m *MockProofCommand) ConfirmProofRevision(ctx context.Context, revisionIdx int32, tokenID int32, digest string, tx *sql.Tx) error {
+	if m.ConfirmProofRevisionFn == nil {
+		log.Fatal("mock ConfirmProofRevisionFn is nil")
+	}
+	return m.ConfirmProofRevisionFn(ctx, revisionIdx, tokenID, digest, tx)
+}
+
+// CreateProofAttachments method is the mock test function for CreateProofAttachments.
+func (m *MockProofCommand) CreateProofAttachments(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+	if m.CreateProofAttachmentsFn == nil {
+		log.Fatal("mock CreateProofAttachmentsFn is nil")
+	}
+	return m.CreateProofAttachmentsFn(ctx, attachments, tx)
+}
+
+// CreateEvidenceBlobs method is the mock test function for CreateEvidenceBlobs.
+func (m *MockProofCommand) CreateEvidenceBlobs(ctx context.Context, blobs []*model.EvidenceBlob, tx *sql.Tx) error {
+	if m.CreateEvidenceBlobsFn == nil {
+		log.Fatal("mock CreateEvidenceBlobsFn is nil")
+	}
+	return m.CreateEvidenceBlobsFn(ctx, blobs, tx)
+}
+
+// UpdateProofAttachmentThumbnail method is the mock test function for UpdateProofAttachmentThumbnail.
+func (m *MockProofCommand) UpdateProofAttachmentThumbnail(ctx context.Context, idx int32, thumbnailPath string, tx *sql.Tx) error {
+	if m.UpdateProofAttachmentThumbnailFn == nil {
+		log.Fatal("mock UpdateProofAttachmentThumbnailFn is nil")
+	}
+	return m.UpdateProofAttachmentThumbnailFn(ctx, idx, thumbnailPath, tx)
+}
+
+// UpdateProofAttachmentType method is the mock test function for UpdateProofAttachmentType.
+func (m *MockProofCommand) UpdateProofAttachmentType(ctx context.Context, idx int32, mimeType string, tx *sql.Tx) error {
+	if m.UpdateProofAttachmentTypeFn == nil {
+		log.Fatal("mock UpdateProofAttachmentTypeFn is nil")
+	}
+	return m.UpdateProofAttachmentTypeFn(ctx, idx, mimeType, tx)
+}
+
+// UpdateProofAttachmentHash method is the mock test function for UpdateProofAttachmentHash.
+func (m *MockProofCommand) UpdateProofAttachmentHash(ctx context.Context, idx int32, hash string, tx *sql.Tx) error {
+	if m.UpdateProofAttachmentHashFn == nil {
+		log.Fatal("mock UpdateProofAttachmentHashFn is nil")
+	}
+	return m.UpdateProofAttachmentHashFn(ctx, idx, hash, tx)
+}
+
+// LockEvidenceBlobs method is the mock test function for LockEvidenceBlobs.
+func (m *MockProofCommand) LockEvidenceBlobs(ctx context.Context, digests []string, tx *sql.Tx) ([]string, error) {
+	if m.LockEvidenceBlobsFn == nil {
+		log.Fatal("mock LockEvidenceBlobsFn is nil")
+	}
+	return m.LockEvidenceBlobsFn(ctx, digests, tx)
+}
+
+// DeleteOrphanedEvidenceBlob method is the mock test function for DeleteOrphanedEvidenceBlob.
+func (m *MockProofCommand) DeleteOrphanedEvidenceBlob(ctx context.Context, digest string, orphanedBefore time.Time, tx *sql.Tx) (bool, error) {
+	if m.DeleteOrphanedEvidenceBlobFn == nil {
+		log.Fatal("mock DeleteOrphanedEvidenceBlobFn is nil")
+	}
+	return m.DeleteOrphanedEvidenceBlobFn(ctx, digest, orphanedBefore, tx)
+}
+
+// CreateCycle method is the mock test function for CreateCycle.
+func (m *MockProofCommand) CreateCycle(ctx context.Context, cycle *model.AssessmentCycle, tx *sql.Tx) (int32, error) {
+	if m.CreateCycleFn == nil {
+		log.Fatal("mock CreateCycleFn is nil")
+	}
+	return m.CreateCycleFn(ctx, cycle, tx)
+}
+
+// EndCycle method is the mock test function for EndCycle.
+func (m *MockProofCommand) EndCycle(ctx context.Context, idx int32, endedAt time.Time, tx *sql.Tx) error {
+	if m.EndCycleFn == nil {
+		log.Fatal("mock EndCycleFn is nil")
+	}
+	return m.EndCycleFn(ctx, idx, endedAt, tx)
+}
+
+// CarryForwardProofs method is the mock test function for CarryForwardProofs.
+func (m *MockProofCommand) CarryForwardProofs(ctx context.Context, fromCycleIdx int32, toCycleIdx int32, userIdx int32, createdAt time.Time, tx *sql.Tx) (int64, error) {
+	if m.CarryForwardProofsFn == nil {
+		log.Fatal("mock CarryForwardProofsFn is nil")
+	}
+	return m.CarryForwardProofsFn(ctx, fromCycleIdx, toCycleIdx, userIdx, createdAt, tx)
+}
+
+// UpsertControls method is the mock test function for UpsertControls.
+func (m *MockProofCommand) UpsertControls(ctx context.Context, controls []*model.Control, tx *sql.Tx) (int64, error) {
+	if m.UpsertControlsFn == nil {
+		log.Fatal("mock UpsertControlsFn is nil")
+	}
+	return m.UpsertControlsFn(ctx, controls, tx)
+}
+
+// ReplaceProofControls method is the mock test function for ReplaceProofControls.
+func (m *MockProofCommand) ReplaceProofControls(ctx context.Context, proofIdx int32, controlIdxs []int32, tx *sql.Tx) error {
+	if m.ReplaceProofControlsFn == nil {
+		log.Fatal("mock ReplaceProofControlsFn is nil")
+	}
+	return m.ReplaceProofControlsFn(ctx, proofIdx, controlIdxs, tx)
+}
+
+// UpdateProofDueAt method is the mock test function for UpdateProofDueAt.
+func (m *MockProofCommand) UpdateProofDueAt(ctx context.Context, idx int32, dueAt *time.Time, tx *sql.Tx) error {
+	if m.UpdateProofDueAtFn == nil {
+		log.Fatal("mock UpdateProofDueAtFn is nil")
+	}
+	return m.UpdateProofDueAtFn(ctx, idx, dueAt, tx)
+}
+
+// MarkProofsReminded method is the mock test function for MarkProofsReminded.
+func (m *MockProofCommand) MarkProofsReminded(ctx context.Context, idxs []int32, remindedAt time.Time, tx *sql.Tx) error {
+	if m.MarkProofsRemindedFn == nil {
+		log.Fatal("mock MarkProofsRemindedFn is nil")
+	}
+	return m.MarkProofsRemindedFn(ctx, idxs, remindedAt, tx)
+}
+
+// CreateProofComment method is the mock test function for CreateProofComment.
+func (m *MockProofCommand) CreateProofComment(ctx context.Context, comment *model.ProofComment, tx *sql.Tx) (int32, error) {
+	if m.CreateProofCommentFn == nil {
+		log.Fatal("mock CreateProofCommentFn is nil")
+	}
+	return m.CreateProofCommentFn(ctx, comment, tx)
+}
+
+// UpdateProofComment method is the mock test function for UpdateProofComment.
+func (m *MockProofCommand) UpdateProofComment(ctx context.Context, idx int32, body string, editedAt time.Time, tx *sql.Tx) error {
+	if m.UpdateProofCommentFn == nil {
+		log.Fatal("mock UpdateProofCommentFn is nil")
+	}
+	return m.UpdateProofCommentFn(ctx, idx, body, editedAt, tx)
+}
+
+// DeleteProofComment method is the mock test function for DeleteProofComment.
+func (m *MockProofCommand) DeleteProofComment(ctx context.Context, idx int32, deletedAt time.Time, tx *sql.Tx) error {
+	if m.DeleteProofCommentFn == nil {
+		log.Fatal("mock DeleteProofCommentFn is nil")
+	}
+	return m.DeleteProofCommentFn(ctx, idx, deletedAt, tx)
+}
+
+// SetRetentionPolicy method is the mock test function for SetRetentionPolicy.
+func (m *MockProofCommand) SetRetentionPolicy(ctx context.Context, policy *model.RetentionPolicy, tx *sql.Tx) (int32, error) {
+	if m.SetRetentionPolicyFn == nil {
+		log.Fatal("mock SetRetentionPolicyFn is nil")
+	}
+	return m.SetRetentionPolicyFn(ctx, policy, tx)
+}
+
+// DeleteRetentionPolicy method is the mock test function for DeleteRetentionPolicy.
+func (m *MockProofCommand) DeleteRetentionPolicy(ctx context.Context, idx int32, tx *sql.Tx) error {
+	if m.DeleteRetentionPolicyFn == nil {
+		log.Fatal("mock DeleteRetentionPolicyFn is nil")
+	}
+	return m.DeleteRetentionPolicyFn(ctx, idx, tx)
+}
+
+// UpdateProofLegalHold method is the mock test function for UpdateProofLegalHold.
+func (m *MockProofCommand) UpdateProofLegalHold(ctx context.Context, proof *model.Proof, tx *sql.Tx) error {
+	if m.UpdateProofLegalHoldFn == nil {
+		log.Fatal("mock UpdateProofLegalHoldFn is nil")
+	}
+	return m.UpdateProofLegalHoldFn(ctx, proof, tx)
+}
+
+// PurgeProofEvidence method is the mock test function for PurgeProofEvidence.
+func (m *MockProofCommand) PurgeProofEvidence(ctx context.Context, proofIdx int32, purgedAt time.Time, tx *sql.Tx) (int64, error) {
+	if m.PurgeProofEvidenceFn == nil {
+		log.Fatal("mock PurgeProofEvidenceFn is nil")
+	}
+	return m.PurgeProofEvidenceFn(ctx, proofIdx, purgedAt, tx)
+}
+
+// DeleteEvidenceBlob method is the mock test function for DeleteEvidenceBlob.
+func (m *MockProofCommand) DeleteEvidenceBlob(ctx context.Context, digest string, tx *sql.Tx) (bool, error) {
+	if m.DeleteEvidenceBlobFn == nil {
+		log.Fatal("mock DeleteEvidenceBlobFn is nil")
+	}
+	return m.DeleteEvidenceBlobFn(ctx, digest, tx)
+}
+
+// CreateIntegrityChecks method is the mock test function for CreateIntegrityChecks.
+func (m *MockProofCommand) CreateIntegrityChecks(ctx context.Context, checks []*model.IntegrityCheck, tx *sql.Tx) error {
+	if m.CreateIntegrityChecksFn == nil {
+		log.Fatal("mock CreateIntegrityChecksFn is nil")
+	}
+	return m.CreateIntegrityChecksFn(ctx, checks, tx)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
@@ -17,8 +18,18 @@ import (
 type ProofQuerier interface {
 	ProofReader
 	ProofsLister
-	ProofImageReader
-	ProofLogReader
+	ProofAttachmentReader
+	ProofHistoryLister
+	ProofRejectLister
+	ProofRevisionReader
+	ProofCycleReader
+	ControlLister
+	ProofDueLister
+	ProofConfirmedLister
+	ProofCommentReader
+	StoragePathLister
+	ProofRetentionReader
+	ProofIntegrityReader
 }
 
 // ProofReader interface is defining data related to querying read data.
@@ -28,19 +39,96 @@ type ProofReader interface {
 
 // ProofsLister interface is defining data related to querying listed data.
 type ProofsLister interface {
-	AllProofs(ctx context.Context) ([]*model.Proof, error)
-	SearchProofs(ctx context.Context, category string) (proofs []*model.Proof, err error)
+	AllProofs(ctx context.Context, cycleIdx int32) ([]*model.Proof, error)
+	SearchProofs(ctx context.Context, cycleIdx int32, category string) (proofs []*model.Proof, err error)
 }
 
-// ProofImageReader interface is defining data related to querying read image data.
-type ProofImageReader interface {
-	ReadFirstProofImage(ctx context.Context, idx int32) (proof *model.Proof, err error)
-	ReadSecondProofImage(ctx context.Context, idx int32) (proof *model.Proof, err error)
+// ProofAttachmentReader interface is defining data related to querying attachment data of a revision.
+type ProofAttachmentReader interface {
+	ListProofAttachments(ctx context.Context, revisionIdx int32) (attachments []*model.ProofAttachment, err error)
+	ReadProofAttachment(ctx context.Context, revisionIdx int32, position int32) (attachment *model.ProofAttachment, err error)
+	ReadEvidenceBlob(ctx context.Context, digest string) (blob *model.EvidenceBlob, err error)
+	ListThumbnailCandidates(ctx context.Context, afterIdx int32, limit int64) (attachments []*model.ProofAttachment, err error)
+	ListUnhashedAttachments(ctx context.Context, afterIdx int32, limit int64) (attachments []*model.ProofAttachment, err error)
 }
 
-// ProofLogReader interface is defining data related to querying read log data.
-type ProofLogReader interface {
-	ReadProofLog(ctx context.Context, idx int32) (log *model.Proof, err error)
+// ProofHistoryLister interface is defining data related to querying state history data.
+type ProofHistoryLister interface {
+	ListProofStateHistory(ctx context.Context, proofIdx int32) (histories []*model.ProofStateHistory, err error)
+}
+
+// ProofRejectLister interface is defining data related to querying reject data.
+type ProofRejectLister interface {
+	ListProofRejects(ctx context.Context, proofIdx int32) (rejects []*model.ProofReject, err error)
+}
+
+// ProofRevisionReader interface is defining data related to querying revision data.
+type ProofRevisionReader interface {
+	ListProofRevisions(ctx context.Context, proofIdx int32) (revisions []*model.ProofRevision, err error)
+	ReadProofRevision(ctx context.Context, proofIdx int32, revision int32) (*model.ProofRevision, error)
+	ReadLatestProofRevision(ctx context.Context, proofIdx int32) (*model.ProofRevision, error)
+}
+
+// ProofCycleReader interface is defining data related to querying assessment cycle data.
+type ProofCycleReader interface {
+	ReadActiveCycle(ctx context.Context) (cycle *model.AssessmentCycle, err error)
+	ListCycles(ctx context.Context) (cycles []*model.AssessmentCycle, err error)
+}
+
+// ControlLister interface is defining data related to querying control catalog data.
+type ControlLister interface {
+	ListControls(ctx context.Context, framework string) (controls []*model.Control, err error)
+	ListUncoveredControls(ctx context.Context, cycleIdx int32, framework string) (controls []*model.Control, err error)
+	ListProofControls(ctx context.Context, proofIdx int32) (controls []*model.Control, err error)
+}
+
+// ProofDueLister interface is defining data related to querying items by due date.
+type ProofDueLister interface {
+	ListDueProofs(ctx context.Context, cycleIdx int32, before time.Time) (proofs []*model.Proof, err error)
+}
+
+// ProofConfirmedLister interface is defining data related to querying confirmed items.
+type ProofConfirmedLister interface {
+	ListConfirmedProofs(ctx context.Context, cycleIdx int32) (proofs []*ConfirmedProof, err error)
+}
+
+// ConfirmedProof struct is composed of a Proof and the time it was last confirmed.
+type ConfirmedProof struct {
+	model.Proof
+	ConfirmedAt time.Time
+}
+
+// StoragePathLister interface is defining data related to querying every storage key still referenced and the blobs no longer referenced.
+type StoragePathLister interface {
+	ListStoragePaths(ctx context.Context, orphanedBefore time.Time) (paths []string, err error)
+	ListOrphanedEvidenceBlobs(ctx context.Context, orphanedBefore time.Time) (blobs []*model.EvidenceBlob, err error)
+}
+
+// ProofCommentReader interface is defining data related to querying comment data.
+type ProofCommentReader interface {
+	ReadProofComment(ctx context.Context, idx int32) (comment *model.ProofComment, err error)
+	ListProofComments(ctx context.Context, proofIdx int32) (comments []*model.ProofComment, err error)
+	CountProofComments(ctx context.Context, cycleIdx int32) (counts map[int32]int32, err error)
+}
+
+// ProofRetentionReader interface is defining data related to querying retention policy and purged evidence data.
+type ProofRetentionReader interface {
+	ListRetentionPolicies(ctx context.Context) (policies []*model.RetentionPolicy, err error)
+	ListRetainedProofs(ctx context.Context) (proofs []*RetainedProof, err error)
+	ReadRetainedProof(ctx context.Context, idx int32) (proof *RetainedProof, err error)
+	ListProofEvidence(ctx context.Context, proofIdx int32) (attachments []*model.ProofAttachment, err error)
+	ListPurgedEvidence(ctx context.Context, purgedAfter time.Time) (evidence []*model.PurgedEvidence, err error)
+}
+
+// RetainedProof struct is composed of a Proof and the time its assessment cycle ended, which is nil while the cycle is active.
+type RetainedProof struct {
+	model.Proof
+	CycleEndedAt *time.Time
+}
+
+// ProofIntegrityReader interface is defining data related to querying the anchored revisions checked for integrity.
+type ProofIntegrityReader interface {
+	ListAnchoredRevisions(ctx context.Context) (revisions []*model.ProofRevision, err error)
 }
 
 type proofQuery struct {
@@ -59,6 +147,9 @@ func (q *proofQuery) ReadProof(ctx context.Context, idx int32) (*model.Proof, er
 			table.Proof.Num,
 			table.Proof.Category,
 			table.Proof.Description,
+			table.Proof.FirstImagePath,
+			table.Proof.SecondImagePath,
+			table.Proof.LogPath,
 			table.Proof.CreatedUserIdx,
 			table.Proof.CreatedAt,
 			table.Proof.UpdatedUserIdx,
@@ -67,6 +158,15 @@ func (q *proofQuery) ReadProof(ctx context.Context, idx int32) (*model.Proof, er
 			table.Proof.UploadedAt,
 			table.Proof.Confirm,
 			table.Proof.TokenID,
+			table.Proof.State,
+			table.Proof.CycleIdx,
+			table.Proof.DueAt,
+			table.Proof.RemindedAt,
+			table.Proof.LegalHold,
+			table.Proof.LegalHoldReason,
+			table.Proof.LegalHoldUserIdx,
+			table.Proof.LegalHoldAt,
+			table.Proof.PurgedAt,
 		).
 		WHERE(table.Proof.Idx.EQ(postgres.Int32(idx))).
 		LIMIT(1)
@@ -83,7 +183,7 @@ func (q *proofQuery) ReadProof(ctx context.Context, idx int32) (*model.Proof, er
 	return dest, nil
 }
 
-func (q *proofQuery) AllProofs(ctx context.Context) ([]*model.Proof, error) {
+func (q *proofQuery) AllProofs(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
 	listStmt := table.Proof.
 		SELECT(
 			table.Proof.Idx,
@@ -92,7 +192,9 @@ func (q *proofQuery) AllProofs(ctx context.Context) ([]*model.Proof, error) {
 			table.Proof.Description,
 			table.Proof.UploadedAt,
 			table.Proof.Confirm,
-		)
+			table.Proof.State,
+		).
+		WHERE(table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)))
 
 	dest := make([]*model.Proof, 0)
 	err := listStmt.QueryContext(ctx, q.db, &dest)
@@ -105,7 +207,7 @@ func (q *proofQuery) AllProofs(ctx context.Context) ([]*model.Proof, error) {
 	return dest, nil
 }
 
-func (q *proofQuery) SearchProofs(ctx context.Context, category string) ([]*model.Proof, error) {
+func (q *proofQuery) SearchProofs(ctx context.Context, cycleIdx int32, category string) ([]*model.Proof, error) {
 	listStmt := table.Proof.
 		SELECT(
 			table.Proof.Idx,
@@ -114,7 +216,12 @@ func (q *proofQuery) SearchProofs(ctx context.Context, category string) ([]*mode
 			table.Proof.Description,
 			table.Proof.UploadedAt,
 			table.Proof.Confirm,
-		).WHERE(table.Proof.Category.LIKE(postgres.String(category)))
+			table.Proof.State,
+		).
+		WHERE(
+			table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)).
+				AND(table.Proof.Category.LIKE(postgres.String(category))),
+		)
 
 	dest := make([]*model.Proof, 0)
 	err := listStmt.QueryContext(ctx, q.db, &dest)
@@ -127,17 +234,205 @@ func (q *proofQuery) SearchProofs(ctx context.Context, category string) ([]*mode
 	return dest, nil
 }
 
-func (q *proofQuery) ReadFirstProofImage(ctx context.Context, idx int32) (*model.Proof, error) {
-	readStmt := table.Proof.
+func (q *proofQuery) ListProofAttachments(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+	listStmt := table.ProofAttachment.
+		SELECT(table.ProofAttachment.AllColumns).
+		WHERE(table.ProofAttachment.RevisionIdx.EQ(postgres.Int32(revisionIdx))).
+		ORDER_BY(table.ProofAttachment.Position.ASC())
+
+	dest := make([]*model.ProofAttachment, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ReadProofAttachment(ctx context.Context, revisionIdx int32, position int32) (*model.ProofAttachment, error) {
+	readStmt := table.ProofAttachment.
+		SELECT(table.ProofAttachment.AllColumns).
+		WHERE(
+			table.ProofAttachment.RevisionIdx.EQ(postgres.Int32(revisionIdx)).
+				AND(table.ProofAttachment.Position.EQ(postgres.Int32(position))),
+		).
+		LIMIT(1)
+
+	dest := &model.ProofAttachment{}
+
+	err := readStmt.QueryContext(ctx, q.db, dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ReadEvidenceBlob(ctx context.Context, digest string) (*model.EvidenceBlob, error) {
+	readStmt := table.EvidenceBlob.
+		SELECT(table.EvidenceBlob.AllColumns).
+		WHERE(table.EvidenceBlob.Digest.EQ(postgres.String(digest))).
+		LIMIT(1)
+
+	dest := &model.EvidenceBlob{}
+
+	err := readStmt.QueryContext(ctx, q.db, dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// ListThumbnailCandidates method lists the attachments without a thumbnail that may be images, after an index in index order.
+// Attachments migrated before the MIME type was sniffed are included, since their type is not known.
+func (q *proofQuery) ListThumbnailCandidates(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+	listStmt := table.ProofAttachment.
+		SELECT(table.ProofAttachment.AllColumns).
+		WHERE(
+			table.ProofAttachment.Idx.GT(postgres.Int32(afterIdx)).
+				AND(table.ProofAttachment.ThumbnailPath.IS_NULL()).
+				AND(table.ProofAttachment.MimeType.IN(
+					postgres.String("image/png"),
+					postgres.String("image/jpeg"),
+					postgres.String("application/octet-stream"),
+				)),
+		).
+		ORDER_BY(table.ProofAttachment.Idx.ASC()).
+		LIMIT(limit)
+
+	dest := make([]*model.ProofAttachment, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// ListUnhashedAttachments method lists the attachments migrated without a hash, after an index in index order.
+func (q *proofQuery) ListUnhashedAttachments(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+	listStmt := table.ProofAttachment.
+		SELECT(table.ProofAttachment.AllColumns).
+		WHERE(
+			table.ProofAttachment.Idx.GT(postgres.Int32(afterIdx)).
+				AND(table.ProofAttachment.Hash.EQ(postgres.String(""))),
+		).
+		ORDER_BY(table.ProofAttachment.Idx.ASC()).
+		LIMIT(limit)
+
+	dest := make([]*model.ProofAttachment, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ListProofStateHistory(ctx context.Context, proofIdx int32) ([]*model.ProofStateHistory, error) {
+	listStmt := table.ProofStateHistory.
 		SELECT(
-			table.Proof.Idx,
-			table.Proof.FirstImagePath,
+			table.ProofStateHistory.Idx,
+			table.ProofStateHistory.ProofIdx,
+			table.ProofStateHistory.FromState,
+			table.ProofStateHistory.ToState,
+			table.ProofStateHistory.UserIdx,
+			table.ProofStateHistory.CreatedAt,
+		).
+		WHERE(table.ProofStateHistory.ProofIdx.EQ(postgres.Int32(proofIdx))).
+		ORDER_BY(table.ProofStateHistory.Idx.ASC())
+
+	dest := make([]*model.ProofStateHistory, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ListProofRejects(ctx context.Context, proofIdx int32) ([]*model.ProofReject, error) {
+	listStmt := table.ProofReject.
+		SELECT(
+			table.ProofReject.Idx,
+			table.ProofReject.ProofIdx,
+			table.ProofReject.Reason,
+			table.ProofReject.RejectedUserIdx,
+			table.ProofReject.RejectedAt,
+			table.ProofReject.ResolvedAt,
+		).
+		WHERE(table.ProofReject.ProofIdx.EQ(postgres.Int32(proofIdx))).
+		ORDER_BY(table.ProofReject.RejectedAt.DESC())
+
+	dest := make([]*model.ProofReject, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ListProofRevisions(ctx context.Context, proofIdx int32) ([]*model.ProofRevision, error) {
+	listStmt := table.ProofRevision.
+		SELECT(table.ProofRevision.AllColumns).
+		WHERE(table.ProofRevision.ProofIdx.EQ(postgres.Int32(proofIdx))).
+		ORDER_BY(table.ProofRevision.Revision.DESC())
+
+	dest := make([]*model.ProofRevision, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ReadProofRevision(ctx context.Context, proofIdx int32, revision int32) (*model.ProofRevision, error) {
+	readStmt := table.ProofRevision.
+		SELECT(table.ProofRevision.AllColumns).
+		WHERE(
+			table.ProofRevision.ProofIdx.EQ(postgres.Int32(proofIdx)).
+				AND(table.ProofRevision.Revision.EQ(postgres.Int32(revision))),
 		).
-		WHERE(table.Proof.Idx.EQ(postgres.Int32(idx))).
 		LIMIT(1)
 
-	dest := &model.Proof{}
+	dest := &model.ProofRevision{}
+	err := readStmt.QueryContext(ctx, q.db, dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
 
+	return dest, nil
+}
+
+func (q *proofQuery) ReadLatestProofRevision(ctx context.Context, proofIdx int32) (*model.ProofRevision, error) {
+	readStmt := table.ProofRevision.
+		SELECT(table.ProofRevision.AllColumns).
+		WHERE(table.ProofRevision.ProofIdx.EQ(postgres.Int32(proofIdx))).
+		ORDER_BY(table.ProofRevision.Revision.DESC()).
+		LIMIT(1)
+
+	dest := &model.ProofRevision{}
 	err := readStmt.QueryContext(ctx, q.db, dest)
 	if errors.Is(err, qrm.ErrNoRows) {
 		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
@@ -148,17 +443,188 @@ func (q *proofQuery) ReadFirstProofImage(ctx context.Context, idx int32) (*model
 	return dest, nil
 }
 
-func (q *proofQuery) ReadSecondProofImage(ctx context.Context, idx int32) (*model.Proof, error) {
-	readStmt := table.Proof.
+func (q *proofQuery) ReadActiveCycle(ctx context.Context) (*model.AssessmentCycle, error) {
+	readStmt := table.AssessmentCycle.
+		SELECT(table.AssessmentCycle.AllColumns).
+		WHERE(table.AssessmentCycle.EndedAt.IS_NULL()).
+		LIMIT(1)
+
+	dest := &model.AssessmentCycle{}
+	err := readStmt.QueryContext(ctx, q.db, dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ListCycles(ctx context.Context) ([]*model.AssessmentCycle, error) {
+	listStmt := table.AssessmentCycle.
+		SELECT(table.AssessmentCycle.AllColumns).
+		ORDER_BY(table.AssessmentCycle.Idx.DESC())
+
+	dest := make([]*model.AssessmentCycle, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ListControls(ctx context.Context, framework string) ([]*model.Control, error) {
+	listStmt := table.Control.
+		SELECT(table.Control.AllColumns).
+		WHERE(frameworkCondition(framework)).
+		ORDER_BY(table.Control.Framework.ASC(), table.Control.ControlID.ASC())
+
+	dest := make([]*model.Control, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// ListUncoveredControls method lists controls that no confirmed proof of the cycle is mapped to.
+func (q *proofQuery) ListUncoveredControls(ctx context.Context, cycleIdx int32, framework string) ([]*model.Control, error) {
+	confirmedStmt := table.ProofControl.
+		INNER_JOIN(table.Proof, table.Proof.Idx.EQ(table.ProofControl.ProofIdx)).
+		SELECT(table.ProofControl.Idx).
+		WHERE(
+			table.ProofControl.ControlIdx.EQ(table.Control.Idx).
+				AND(table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx))).
+				AND(table.Proof.State.EQ(postgres.Int32(constants.StateConfirmed))),
+		)
+
+	listStmt := table.Control.
+		SELECT(table.Control.AllColumns).
+		WHERE(
+			frameworkCondition(framework).
+				AND(postgres.NOT(postgres.EXISTS(confirmedStmt))),
+		).
+		ORDER_BY(table.Control.Framework.ASC(), table.Control.ControlID.ASC())
+
+	dest := make([]*model.Control, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ListProofControls(ctx context.Context, proofIdx int32) ([]*model.Control, error) {
+	listStmt := table.Control.
+		INNER_JOIN(table.ProofControl, table.ProofControl.ControlIdx.EQ(table.Control.Idx)).
+		SELECT(table.Control.AllColumns).
+		WHERE(table.ProofControl.ProofIdx.EQ(postgres.Int32(proofIdx))).
+		ORDER_BY(table.Control.Framework.ASC(), table.Control.ControlID.ASC())
+
+	dest := make([]*model.Control, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// frameworkCondition function is returning a condition matching a framework, or every framework when it is empty.
+func frameworkCondition(framework string) postgres.BoolExpression {
+	if framework == "" {
+		return postgres.Bool(true)
+	}
+	return table.Control.Framework.EQ(postgres.String(framework))
+}
+
+// ListDueProofs method lists the proofs of a cycle due before the given time that are not confirmed yet.
+// The earliest due date comes first.
+func (q *proofQuery) ListDueProofs(ctx context.Context, cycleIdx int32, before time.Time) ([]*model.Proof, error) {
+	listStmt := table.Proof.
 		SELECT(
 			table.Proof.Idx,
-			table.Proof.SecondImagePath,
+			table.Proof.Num,
+			table.Proof.Category,
+			table.Proof.Description,
+			table.Proof.UploadedUserIdx,
+			table.Proof.State,
+			table.Proof.CycleIdx,
+			table.Proof.DueAt,
+			table.Proof.RemindedAt,
 		).
-		WHERE(table.Proof.Idx.EQ(postgres.Int32(idx))).
-		LIMIT(1)
+		WHERE(
+			table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)).
+				AND(table.Proof.DueAt.LT(postgres.TimestampzT(before))).
+				AND(table.Proof.State.NOT_IN(
+					postgres.Int32(constants.StateConfirmed),
+					postgres.Int32(constants.StateExpired),
+				)),
+		).
+		ORDER_BY(table.Proof.DueAt.ASC())
 
-	dest := &model.Proof{}
+	dest := make([]*model.Proof, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// ListConfirmedProofs method lists the confirmed proofs of a cycle with the time each one was last confirmed.
+func (q *proofQuery) ListConfirmedProofs(ctx context.Context, cycleIdx int32) ([]*ConfirmedProof, error) {
+	confirmedAt := table.ProofStateHistory.
+		SELECT(postgres.MAX(table.ProofStateHistory.CreatedAt)).
+		WHERE(
+			table.ProofStateHistory.ProofIdx.EQ(table.Proof.Idx).
+				AND(table.ProofStateHistory.ToState.EQ(postgres.Int32(constants.StateConfirmed))),
+		)
+
+	listStmt := table.Proof.
+		SELECT(
+			table.Proof.AllColumns,
+			postgres.TimestampzExp(confirmedAt).AS("confirmed_proof.confirmed_at"),
+		).
+		WHERE(
+			table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)).
+				AND(table.Proof.State.EQ(postgres.Int32(constants.StateConfirmed))),
+		).
+		ORDER_BY(table.Proof.Idx.ASC())
+
+	dest := make([]*ConfirmedProof, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ReadProofComment(ctx context.Context, idx int32) (*model.ProofComment, error) {
+	readStmt := table.ProofComment.
+		SELECT(table.ProofComment.AllColumns).
+		WHERE(
+			table.ProofComment.Idx.EQ(postgres.Int32(idx)).
+				AND(table.ProofComment.DeletedAt.IS_NULL()),
+		)
 
+	dest := &model.ProofComment{}
 	err := readStmt.QueryContext(ctx, q.db, dest)
 	if errors.Is(err, qrm.ErrNoRows) {
 		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
@@ -169,17 +635,200 @@ func (q *proofQuery) ReadSecondProofImage(ctx context.Context, idx int32) (*mode
 	return dest, nil
 }
 
-func (q *proofQuery) ReadProofLog(ctx context.Context, idx int32) (*model.Proof, error) {
-	readStmt := table.Proof.
+func (q *proofQuery) ListProofComments(ctx context.Context, proofIdx int32) ([]*model.ProofComment, error) {
+	listStmt := table.ProofComment.
+		SELECT(table.ProofComment.AllColumns).
+		WHERE(
+			table.ProofComment.ProofIdx.EQ(postgres.Int32(proofIdx)).
+				AND(table.ProofComment.DeletedAt.IS_NULL()),
+		).
+		ORDER_BY(table.ProofComment.CreatedAt.ASC(), table.ProofComment.Idx.ASC())
+
+	dest := make([]*model.ProofComment, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// CountProofComments method counts the comments that are not deleted for each proof of a cycle.
+// Proofs without any comment are left out of the result.
+func (q *proofQuery) CountProofComments(ctx context.Context, cycleIdx int32) (map[int32]int32, error) {
+	countStmt := table.ProofComment.
+		INNER_JOIN(table.Proof, table.Proof.Idx.EQ(table.ProofComment.ProofIdx)).
+		SELECT(
+			table.ProofComment.ProofIdx.AS("comment_count.proof_idx"),
+			postgres.COUNT(table.ProofComment.Idx).AS("comment_count.count"),
+		).
+		WHERE(
+			table.Proof.CycleIdx.EQ(postgres.Int32(cycleIdx)).
+				AND(table.ProofComment.DeletedAt.IS_NULL()),
+		).
+		GROUP_BY(table.ProofComment.ProofIdx)
+
+	type commentCount struct {
+		ProofIdx int32
+		Count    int64
+	}
+
+	dest := make([]*commentCount, 0)
+	err := countStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	counts := make(map[int32]int32, len(dest))
+	for _, count := range dest {
+		counts[count.ProofIdx] = int32(count.Count)
+	}
+
+	return counts, nil
+}
+
+// ListOrphanedEvidenceBlobs method lists the blobs no attachment has referenced since the given time.
+func (q *proofQuery) ListOrphanedEvidenceBlobs(ctx context.Context, orphanedBefore time.Time) ([]*model.EvidenceBlob, error) {
+	listStmt := table.EvidenceBlob.
+		SELECT(table.EvidenceBlob.AllColumns).
+		WHERE(
+			table.EvidenceBlob.RefCount.EQ(postgres.Int32(0)).
+				AND(postgres.TimestampzExp(postgres.COALESCE(table.EvidenceBlob.OrphanedAt, table.EvidenceBlob.CreatedAt)).LT(postgres.TimestampzT(orphanedBefore))),
+		).
+		ORDER_BY(table.EvidenceBlob.Digest.ASC())
+
+	dest := make([]*model.EvidenceBlob, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// ListStoragePaths method lists the storage keys referenced by proofs, attachments, thumbnails and blobs.
+// Blobs orphaned before the given time are left out since the collector removes them, so their files are not protected.
+func (q *proofQuery) ListStoragePaths(ctx context.Context, orphanedBefore time.Time) ([]string, error) {
+	proofStmt := table.Proof.
 		SELECT(
 			table.Proof.Idx,
+			table.Proof.FirstImagePath,
+			table.Proof.SecondImagePath,
 			table.Proof.LogPath,
+		)
+
+	proofs := make([]*model.Proof, 0)
+	err := proofStmt.QueryContext(ctx, q.db, &proofs)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	attachmentStmt := table.ProofAttachment.
+		SELECT(
+			table.ProofAttachment.Idx,
+			table.ProofAttachment.Path,
+			table.ProofAttachment.ThumbnailPath,
+		)
+
+	attachments := make([]*model.ProofAttachment, 0)
+	err = attachmentStmt.QueryContext(ctx, q.db, &attachments)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	blobStmt := table.EvidenceBlob.
+		SELECT(
+			table.EvidenceBlob.Digest,
+			table.EvidenceBlob.Path,
 		).
-		WHERE(table.Proof.Idx.EQ(postgres.Int32(idx))).
-		LIMIT(1)
+		WHERE(
+			table.EvidenceBlob.RefCount.GT(postgres.Int32(0)).
+				OR(postgres.TimestampzExp(postgres.COALESCE(table.EvidenceBlob.OrphanedAt, table.EvidenceBlob.CreatedAt)).GT_EQ(postgres.TimestampzT(orphanedBefore))),
+		)
 
-	dest := &model.Proof{}
+	blobs := make([]*model.EvidenceBlob, 0)
+	err = blobStmt.QueryContext(ctx, q.db, &blobs)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	paths := make([]string, 0, len(proofs)*3+len(attachments)*2+len(blobs))
+	for _, proof := range proofs {
+		for _, path := range []*string{proof.FirstImagePath, proof.SecondImagePath, proof.LogPath} {
+			if path != nil && *path != "" {
+				paths = append(paths, *path)
+			}
+		}
+	}
+	for _, attachment := range attachments {
+		paths = append(paths, attachment.Path)
+		if attachment.ThumbnailPath != nil {
+			paths = append(paths, *attachment.ThumbnailPath)
+		}
+	}
+	for _, blob := range blobs {
+		paths = append(paths, blob.Path)
+	}
+
+	return paths, nil
+}
+
+func (q *proofQuery) ListRetentionPolicies(ctx context.Context) ([]*model.RetentionPolicy, error) {
+	listStmt := table.RetentionPolicy.
+		SELECT(table.RetentionPolicy.AllColumns).
+		ORDER_BY(table.RetentionPolicy.Idx.ASC())
 
+	dest := make([]*model.RetentionPolicy, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// ListRetainedProofs method lists the proofs of ended cycles that still have attachments, which are the candidates of a purge.
+func (q *proofQuery) ListRetainedProofs(ctx context.Context) ([]*RetainedProof, error) {
+	hasAttachment := postgres.EXISTS(
+		table.ProofAttachment.
+			SELECT(table.ProofAttachment.Idx).
+			WHERE(table.ProofAttachment.ProofIdx.EQ(table.Proof.Idx)),
+	)
+
+	listStmt := table.Proof.
+		INNER_JOIN(table.AssessmentCycle, table.AssessmentCycle.Idx.EQ(table.Proof.CycleIdx)).
+		SELECT(
+			table.Proof.AllColumns,
+			table.AssessmentCycle.EndedAt.AS("retained_proof.cycle_ended_at"),
+		).
+		WHERE(
+			table.Proof.PurgedAt.IS_NULL().
+				AND(table.AssessmentCycle.EndedAt.IS_NOT_NULL()).
+				AND(hasAttachment),
+		).
+		ORDER_BY(table.Proof.Idx.ASC())
+
+	dest := make([]*RetainedProof, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ReadRetainedProof(ctx context.Context, idx int32) (*RetainedProof, error) {
+	readStmt := table.Proof.
+		INNER_JOIN(table.AssessmentCycle, table.AssessmentCycle.Idx.EQ(table.Proof.CycleIdx)).
+		SELECT(
+			table.Proof.AllColumns,
+			table.AssessmentCycle.EndedAt.AS("retained_proof.cycle_ended_at"),
+		).
+		WHERE(table.Proof.Idx.EQ(postgres.Int32(idx)))
+
+	dest := &RetainedProof{}
 	err := readStmt.QueryContext(ctx, q.db, dest)
 	if errors.Is(err, qrm.ErrNoRows) {
 		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
@@ -189,3 +838,57 @@ func (q *proofQuery) ReadProofLog(ctx context.Context, idx int32) (*model.Proof,
 
 	return dest, nil
 }
+
+// ListProofEvidence method lists the attachments of every revision of a proof.
+func (q *proofQuery) ListProofEvidence(ctx context.Context, proofIdx int32) ([]*model.ProofAttachment, error) {
+	listStmt := table.ProofAttachment.
+		SELECT(table.ProofAttachment.AllColumns).
+		WHERE(table.ProofAttachment.ProofIdx.EQ(postgres.Int32(proofIdx))).
+		ORDER_BY(table.ProofAttachment.Idx.ASC())
+
+	dest := make([]*model.ProofAttachment, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+func (q *proofQuery) ListPurgedEvidence(ctx context.Context, purgedAfter time.Time) ([]*model.PurgedEvidence, error) {
+	listStmt := table.PurgedEvidence.
+		SELECT(table.PurgedEvidence.AllColumns).
+		WHERE(table.PurgedEvidence.PurgedAt.GT_EQ(postgres.TimestampzT(purgedAfter))).
+		ORDER_BY(table.PurgedEvidence.PurgedAt.ASC(), table.PurgedEvidence.Idx.ASC())
+
+	dest := make([]*model.PurgedEvidence, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
+
+// ListAnchoredRevisions method lists the latest revision anchored on chain of every confirmed or expired proof whose evidence is not purged.
+// Expired evidence stays anchored until it is purged, so it is still checked.
+func (q *proofQuery) ListAnchoredRevisions(ctx context.Context) ([]*model.ProofRevision, error) {
+	listStmt := table.ProofRevision.
+		INNER_JOIN(table.Proof, table.Proof.Idx.EQ(table.ProofRevision.ProofIdx)).
+		SELECT(table.ProofRevision.AllColumns).
+		DISTINCT(table.ProofRevision.ProofIdx).
+		WHERE(
+			table.Proof.State.IN(postgres.Int32(constants.StateConfirmed), postgres.Int32(constants.StateExpired)).
+				AND(table.Proof.PurgedAt.IS_NULL()).
+				AND(table.ProofRevision.TokenID.IS_NOT_NULL()),
+		).
+		ORDER_BY(table.ProofRevision.ProofIdx.ASC(), table.ProofRevision.Revision.DESC())
+
+	dest := make([]*model.ProofRevision, 0)
+	err := listStmt.QueryContext(ctx, q.db, &dest)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, errors.Join(constants.ErrQuery, err)
+	}
+
+	return dest, nil
+}
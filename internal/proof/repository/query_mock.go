@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"golang.org/x/net/context"
 
 	"security-proof/internal/db/security_proof/proof/model"
@@ -8,12 +10,37 @@ import (
 
 // MockProofQuery struct is used for testing the proofQuery structure.
 type MockProofQuery struct {
-	ReadProofFn            func(ctx context.Context, idx int32) (*model.Proof, error)
-	AllProofsFn            func(ctx context.Context) ([]*model.Proof, error)
-	SearchProofsFn         func(ctx context.Context, category string) ([]*model.Proof, error)
-	ReadFirstProofImageFn  func(ctx context.Context, idx int32) (proof *model.Proof, err error)
-	ReadSecondProofImageFn func(ctx context.Context, idx int32) (proof *model.Proof, err error)
-	ReadProofLogFn         func(ctx context.Context, idx int32) (*model.Proof, error)
+	ReadProofFn                 func(ctx context.Context, idx int32) (*model.Proof, error)
+	AllProofsFn                 func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error)
+	SearchProofsFn              func(ctx context.Context, cycleIdx int32, category string) ([]*model.Proof, error)
+	ListProofAttachmentsFn      func(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error)
+	ReadProofAttachmentFn       func(ctx context.Context, revisionIdx int32, position int32) (*model.ProofAttachment, error)
+	ReadEvidenceBlobFn          func(ctx context.Context, digest string) (*model.EvidenceBlob, error)
+	ListThumbnailCandidatesFn   func(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error)
+	ListUnhashedAttachmentsFn   func(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error)
+	ListStoragePathsFn          func(ctx context.Context, orphanedBefore time.Time) ([]string, error)
+	ListOrphanedEvidenceBlobsFn func(ctx context.Context, orphanedBefore time.Time) ([]*model.EvidenceBlob, error)
+	ListProofStateHistoryFn     func(ctx context.Context, proofIdx int32) ([]*model.ProofStateHistory, error)
+	ListProofRejectsFn          func(ctx context.Context, proofIdx int32) ([]*model.ProofReject, error)
+	ListProofRevisionsFn        func(ctx context.Context, proofIdx int32) ([]*model.ProofRevision, error)
+	ReadProofRevisionFn         func(ctx context.Context, proofIdx int32, revision int32) (*model.ProofRevision, error)
+	ReadLatestProofRevisionFn   func(ctx context.Context, proofIdx int32) (*model.ProofRevision, error)
+	ReadActiveCycleFn           func(ctx context.Context) (*model.AssessmentCycle, error)
+	ListCyclesFn                func(ctx context.Context) ([]*model.AssessmentCycle, error)
+	ListControlsFn              func(ctx context.Context, framework string) ([]*model.Control, error)
+	ListUncoveredControlsFn     func(ctx context.Context, cycleIdx int32, framework string) ([]*model.Control, error)
+	ListProofControlsFn         func(ctx context.Context, proofIdx int32) ([]*model.Control, error)
+	ListDueProofsFn             func(ctx context.Context, cycleIdx int32, before time.Time) ([]*model.Proof, error)
+	ListConfirmedProofsFn       func(ctx context.Context, cycleIdx int32) ([]*ConfirmedProof, error)
+	ReadProofCommentFn          func(ctx context.Context, idx int32) (*model.ProofComment, error)
+	ListProofCommentsFn         func(ctx context.Context, proofIdx int32) ([]*model.ProofComment, error)
+	CountProofCommentsFn        func(ctx context.Context, cycleIdx int32) (map[int32]int32, error)
+	ListRetentionPoliciesFn     func(ctx context.Context) ([]*model.RetentionPolicy, error)
+	ListRetainedProofsFn        func(ctx context.Context) ([]*RetainedProof, error)
+	ReadRetainedProofFn         func(ctx context.Context, idx int32) (*RetainedProof, error)
+	ListProofEvidenceFn         func(ctx context.Context, proofIdx int32) ([]*model.ProofAttachment, error)
+	ListPurgedEvidenceFn        func(ctx context.Context, purgedAfter time.Time) ([]*model.PurgedEvidence, error)
+	ListAnchoredRevisionsFn     func(ctx context.Context) ([]*model.ProofRevision, error)
 }
 
 // ReadProof method is the mock test function for ReadProof.
@@ -22,26 +49,151 @@ func (m *MockProofQuery) ReadProof(ctx context.Context, idx int32) (*model.Proof
 }
 
 // AllProofs method is the mock test function for AllProofs.
-func (m *MockProofQuery) AllProofs(ctx context.Context) ([]*model.Proof, error) {
-	return m.AllProofsFn(ctx)
+func (m *MockProofQuery) AllProofs(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+	return m.AllProofsFn(ctx, cycleIdx)
 }
 
 // SearchProofs method is the mock test function for SearchProofs.
-func (m *MockProofQuery) SearchProofs(ctx context.Context, category string) ([]*model.Proof, error) {
-	return m.SearchProofsFn(ctx, category)
+func (m *MockProofQuery) SearchProofs(ctx context.Context, cycleIdx int32, category string) ([]*model.Proof, error) {
+	return m.SearchProofsFn(ctx, cycleIdx, category)
+}
+
+// ReadEvidenceBlob method is the mock test function for ReadEvidenceBlob.
+func (m *MockProofQuery) ReadEvidenceBlob(ctx context.Context, digest string) (*model.EvidenceBlob, error) {
+	return m.ReadEvidenceBlobFn(ctx, digest)
+}
+
+// ListThumbnailCandidates method is the mock test function for ListThumbnailCandidates.
+func (m *MockProofQuery) ListThumbnailCandidates(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+	return m.ListThumbnailCandidatesFn(ctx, afterIdx, limit)
+}
+
+// ListUnhashedAttachments method is the mock test function for ListUnhashedAttachments.
+func (m *MockProofQuery) ListUnhashedAttachments(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+	return m.ListUnhashedAttachmentsFn(ctx, afterIdx, limit)
+}
+
+// ListProofAttachments method is the mock test function for ListProofAttachments.
+func (m *MockProofQuery) ListProofAttachments(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+	return m.ListProofAttachmentsFn(ctx, revisionIdx)
+}
+
+// ReadProofAttachment method is the mock test function for ReadProofAttachment.
+func (m *MockProofQuery) ReadProofAttachment(ctx context.Context, revisionIdx int32, position int32) (*model.ProofAttachment, error) {
+	return m.ReadProofAttachmentFn(ctx, revisionIdx, position)
+}
+
+// ListProofStateHistory method is the mock test function for ListProofStateHistory.
+func (m *MockProofQuery) ListProofStateHistory(ctx context.Context, proofIdx int32) ([]*model.ProofStateHistory, error) {
+	return m.ListProofStateHistoryFn(ctx, proofIdx)
+}
+
+// ListProofRejects method is the mock test function for ListProofRejects.
+func (m *MockProofQuery) ListProofRejects(ctx context.Context, proofIdx int32) ([]*model.ProofReject, error) {
+	return m.ListProofRejectsFn(ctx, proofIdx)
+}
+
+// ListProofRevisions method is the mock test function for ListProofRevisions.
+func (m *MockProofQuery) ListProofRevisions(ctx context.Context, proofIdx int32) ([]*model.ProofRevision, error) {
+	return m.ListProofRevisionsFn(ctx, proofIdx)
+}
+
+// ReadProofRevision method is the mock test function for ReadProofRevision.
+func (m *MockProofQuery) ReadProofRevision(ctx context.Context, proofIdx int32, revision int32) (*model.ProofRevision, error) {
+	return m.ReadProofRevisionFn(ctx, proofIdx, revision)
+}
+
+// ReadLatestProofRevision method is the mock test function for ReadLatestProofRevision.
+func (m *MockProofQuery) ReadLatestProofRevision(ctx context.Context, proofIdx int32) (*model.ProofRevision, error) {
+	return m.ReadLatestProofRevisionFn(ctx, proofIdx)
+}
+
+// ReadActiveCycle method is the mock test function for ReadActiveCycle.
+func (m *MockProofQuery) ReadActiveCycle(ctx context.Context) (*model.AssessmentCycle, error) {
+	return m.ReadActiveCycleFn(ctx)
+}
+
+// ListCycles method is the mock test function for ListCycles.
+func (m *MockProofQuery) ListCycles(ctx context.Context) ([]*model.AssessmentCycle, error) {
+	return m.ListCyclesFn(ctx)
+}
+
+// ListControls method is the mock test function for ListControls.
+func (m *MockProofQuery) ListControls(ctx context.Context, framework string) ([]*model.Control, error) {
+	return m.ListControlsFn(ctx, framework)
+}
+
+// ListUncoveredControls method is the mock test function for ListUncoveredControls.
+func (m *MockProofQuery) ListUncoveredControls(ctx context.Context, cycleIdx int32, framework string) ([]*model.Control, error) {
+	return m.ListUncoveredControlsFn(ctx, cycleIdx, framework)
+}
+
+// ListProofControls method is the mock test function for ListProofControls.
+func (m *MockProofQuery) ListProofControls(ctx context.Context, proofIdx int32) ([]*model.Control, error) {
+	return m.ListProofControlsFn(ctx, proofIdx)
+}
+
+// ListDueProofs method is the mock test function for ListDueProofs.
+func (m *MockProofQuery) ListDueProofs(ctx context.Context, cycleIdx int32, before time.Time) ([]*model.Proof, error) {
+	return m.ListDueProofsFn(ctx, cycleIdx, before)
+}
+
+// ListConfirmedProofs method is the mock test function for ListConfirmedProofs.
+func (m *MockProofQuery) ListConfirmedProofs(ctx context.Context, cycleIdx int32) ([]*ConfirmedProof, error) {
+	return m.ListConfirmedProofsFn(ctx, cycleIdx)
+}
+
+// ReadProofComment method is the mock test function for ReadProofComment.
+func (m *MockProofQuery) ReadProofComment(ctx context.Context, idx int32) (*model.ProofComment, error) {
+	return m.ReadProofCommentFn(ctx, idx)
+}
+
+// ListProofComments method is the mock test function for ListProofComments.
+func (m *MockProofQuery) ListProofComments(ctx context.Context, proofIdx int32) ([]*model.ProofComment, error) {
+	return m.ListProofCommentsFn(ctx, proofIdx)
+}
+
+// CountProofComments method is the mock test function for CountProofComments.
+func (m *MockProofQuery) CountProofComments(ctx context.Context, cycleIdx int32) (map[int32]int32, error) {
+	return m.CountProofCommentsFn(ctx, cycleIdx)
+}
+
+// ListStoragePaths method is the mock test function for ListStoragePaths.
+func (m *MockProofQuery) ListStoragePaths(ctx context.Context, orphanedBefore time.Time) ([]string, error) {
+	return m.ListStoragePathsFn(ctx, orphanedBefore)
+}
+
+// ListOrphanedEvidenceBlobs method is the mock test function for ListOrphanedEvidenceBlobs.
+func (m *MockProofQuery) ListOrphanedEvidenceBlobs(ctx context.Context, orphanedBefore time.Time) ([]*model.EvidenceBlob, error) {
+	return m.ListOrphanedEvidenceBlobsFn(ctx, orphanedBefore)
+}
+
+// ListRetentionPolicies method is the mock test function for ListRetentionPolicies.
+func (m *MockProofQuery) ListRetentionPolicies(ctx context.Context) ([]*model.RetentionPolicy, error) {
+	return m.ListRetentionPoliciesFn(ctx)
+}
+
+// ListRetainedProofs method is the mock test function for ListRetainedProofs.
+func (m *MockProofQuery) ListRetainedProofs(ctx context.Context) ([]*RetainedProof, error) {
+	return m.ListRetainedProofsFn(ctx)
+}
+
+// ReadRetainedProof method is the mock test function for ReadRetainedProof.
+func (m *MockProofQuery) ReadRetainedProof(ctx context.Context, idx int32) (*RetainedProof, error) {
+	return m.ReadRetainedProofFn(ctx, idx)
 }
 
-// ReadProofLog method is the mock test function for ReadProofLog.
-func (m *MockProofQuery) ReadProofLog(ctx context.Context, idx int32) (*model.Proof, error) {
-	return m.ReadProofLogFn(ctx, idx)
+// ListProofEvidence method is the mock test function for ListProofEvidence.
+func (m *MockProofQuery) ListProofEvidence(ctx context.Context, proofIdx int32) ([]*model.ProofAttachment, error) {
+	return m.ListProofEvidenceFn(ctx, proofIdx)
 }
 
-// ReadFirstProofImage method is the mock test function for ReadFirstProofImage.
-func (m *MockProofQuery) ReadFirstProofImage(ctx context.Context, idx int32) (proof *model.Proof, err error) {
-	return m.ReadFirstProofImageFn(ctx, idx)
+// ListPurgedEvidence method is the mock test function for ListPurgedEvidence.
+func (m *MockProofQuery) ListPurgedEvidence(ctx context.Context, purgedAfter time.Time) ([]*model.PurgedEvidence, error) {
+	return m.ListPurgedEvidenceFn(ctx, purgedAfter)
 }
 
-// ReadSecondProofImage method is the mock test function for ReadSecondProofImage.
-func (m *MockProofQuery) ReadSecondProofImage(ctx context.Context, idx int32) (proof *model.Proof, err error) {
-	return m.ReadSecondProofImageFn(ctx, idx)
+// ListAnchoredRevisions method is the mock test function for ListAnchoredRevisions.
+func (m *MockProofQuery) ListAnchoredRevisions(ctx context.Context) ([]*model.ProofRevision, error) {
+	return m.ListAnchoredRevisionsFn(ctx)
 }
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/constants"
+	filemanage "security-proof/pkg/manage/file"
+	scanmanage "security-proof/pkg/manage/scan"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// Attachment struct is composed of a label and a data of an uploaded evidence file.
+type Attachment struct {
+	Label string
+	Data  []byte
+}
+
+// AttachmentStream struct is composed of a label and a reader of an evidence file being uploaded, which is read only once,
+// and whether it is the text log of the upload.
+type AttachmentStream struct {
+	Label  string
+	Reader io.Reader
+	Log    bool
+}
+
+// AttachmentStreams is a function returning the next AttachmentStream of an upload in order, and io.EOF after the last one.
+// The previous stream is read to the end before the next one is requested, as the parts of a multipart body are.
+type AttachmentStreams func() (*AttachmentStream, error)
+
+// attachmentStreams function is returning AttachmentStreams, accepting attachments already held in memory.
+func attachmentStreams(attachments []*Attachment) AttachmentStreams {
+	next := 0
+	return func() (*AttachmentStream, error) {
+		if next == len(attachments) {
+			return nil, io.EOF
+		}
+		attachment := attachments[next]
+		next++
+		return &AttachmentStream{Label: attachment.Label, Reader: bytes.NewReader(attachment.Data)}, nil
+	}
+}
+
+// spooledFile struct is composed of a temporary file holding an uploaded attachment, and its SHA-256 hex digest, size and MIME type.
+type spooledFile struct {
+	file     *os.File
+	digest   string
+	size     int64
+	mimeType string
+}
+
+// spoolAttachment function is returning a spooledFile and an error, accepting a reader and the largest size accepted.
+// The data is hashed while it is written to a temporary file, so an upload is never held in memory as a whole.
+// Nothing is left behind when the reader fails, as a disconnected client does, or when the size is exceeded.
+func spoolAttachment(r io.Reader, maxSize int64) (*spooledFile, error) {
+	file, err := os.CreateTemp("", "proof-upload-*")
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileSave, err)
+	}
+	spooled := &spooledFile{file: file}
+
+	digest := sha256.New()
+	sniff := &sniffWriter{}
+	written, err := io.Copy(io.MultiWriter(file, digest, sniff), io.LimitReader(r, maxSize+1))
+	if err != nil {
+		spooled.Close()
+		return nil, errors.Join(constants.ErrFileSave, err)
+	}
+	if written > maxSize {
+		spooled.Close()
+		return nil, errors.Join(constants.ErrFileTooLarge, fmt.Errorf("larger than %d bytes", maxSize))
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		spooled.Close()
+		return nil, errors.Join(constants.ErrFileSave, err)
+	}
+
+	spooled.digest = hex.EncodeToString(digest.Sum(nil))
+	spooled.size = written
+	spooled.mimeType = filemanage.DetectType(sniff.data)
+	return spooled, nil
+}
+
+// stripAttachment function is returning a spooledFile holding a copy of an image without location data, its Metadata and an error,
+// accepting a spooledFile of an image and the largest size accepted.
+// The copy is spooled again so that the digest and the size are those of the stored copy.
+func stripAttachment(spooled *spooledFile, maxSize int64) (*spooledFile, *filemanage.Metadata, error) {
+	_, err := spooled.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, nil, errors.Join(constants.ErrFileSave, err)
+	}
+
+	reader, writer := io.Pipe()
+	var metadata *filemanage.Metadata
+	stripped := make(chan error, 1)
+	go func() {
+		var stripErr error
+		metadata, stripErr = filemanage.StripLocation(writer, spooled.file, spooled.mimeType)
+		_ = writer.CloseWithError(stripErr)
+		stripped <- stripErr
+	}()
+
+	sanitized, err := spoolAttachment(reader, maxSize)
+	// 사본을 다 읽지 못했다면 쓰는 쪽도 멈추도록 닫습니다.
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	stripErr := <-stripped
+	if stripErr != nil {
+		if sanitized != nil {
+			sanitized.Close()
+		}
+		return nil, nil, stripErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sanitized, metadata, nil
+}
+
+// scanAttachment function is returning an error, accepting a context, a Scanner and a spooledFile.
+// The file is rewound afterwards, and an infected file is rejected with the signature found in it.
+func scanAttachment(ctx context.Context, scanner scanmanage.Scanner, spooled *spooledFile) error {
+	_, err := spooled.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+
+	result, err := scanner.Scan(ctx, spooled.file)
+	if err != nil {
+		return err
+	}
+
+	_, err = spooled.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+
+	if result.Infected {
+		return errors.Join(constants.ErrFileInfected, fmt.Errorf("signature %q", result.Signature))
+	}
+	return nil
+}
+
+// Close method is closing and removing the temporary file.
+func (s *spooledFile) Close() {
+	err := s.file.Close()
+	if err != nil {
+		log.Printf("Failed to close spooled file: %v", err)
+	}
+	err = os.Remove(s.file.Name())
+	if err != nil {
+		log.Printf("Failed to remove spooled file: %v", err)
+	}
+}
+
+// uploadedBlob struct is composed of an EvidenceBlob stored by an upload, the key of its thumbnail, which is nil for non images,
+// the Metadata embedded in it, its number of lines, which is nil for non text files, and whether the blob was registered before the upload.
+type uploadedBlob struct {
+	blob          *model.EvidenceBlob
+	thumbnailPath *string
+	metadata      *filemanage.Metadata
+	lines         *int32
+	reused        bool
+}
+
+// storeThumbnail function is returning the key of a stored thumbnail, accepting a context, a Storage and a spooledFile.
+// A thumbnail is a convenience for reviewers, so a failure is logged and nil is returned without failing the upload.
+func storeThumbnail(ctx context.Context, storage storagemanage.Storage, spooled *spooledFile) *string {
+	if !filemanage.IsImage(spooled.mimeType) {
+		return nil
+	}
+
+	// 같은 내용의 이미지는 썸네일도 같으므로 이미 있으면 다시 만들지 않습니다.
+	key := storagemanage.ThumbnailKey(spooled.digest)
+	_, err := storage.Stat(ctx, key)
+	if err == nil {
+		return &key
+	}
+
+	_, err = spooled.file.Seek(0, io.SeekStart)
+	if err != nil {
+		log.Printf("Failed to rewind spooled file for thumbnail: %v", err)
+		return nil
+	}
+	thumbnail, err := filemanage.Thumbnail(spooled.file)
+	if err != nil {
+		log.Printf("Failed to create thumbnail of %s: %v", spooled.digest, err)
+		return nil
+	}
+
+	err = storage.Put(ctx, key, bytes.NewReader(thumbnail), int64(len(thumbnail)))
+	if err != nil {
+		log.Printf("Failed to store thumbnail of %s: %v", spooled.digest, err)
+		return nil
+	}
+	return &key
+}
+
+// sniffWriter struct is keeping the leading bytes written to it for MIME type detection.
+type sniffWriter struct {
+	data []byte
+}
+
+// Write method is returning the length of a data and nil, accepting a data.
+func (s *sniffWriter) Write(p []byte) (int, error) {
+	if rest := filemanage.SniffSize - len(s.data); rest > 0 {
+		s.data = append(s.data, p[:min(rest, len(p))]...)
+	}
+	return len(p), nil
+}
+
+// attachmentsDigest function is returning a single SHA-256 hex string, accepting ordered attachments and their hashes.
+// The digest covers the position, the label and the hash of every attachment so that reordering or relabeling changes it,
+// and a log is marked so that it cannot be passed off as another attachment.
+func attachmentsDigest(attachments []*model.ProofAttachment, hashes []string) string {
+	digest := sha256.New()
+	for i, attachment := range attachments {
+		if attachment.IsLog {
+			_, _ = fmt.Fprintf(digest, "%d\t%s\t%s\tlog\n", attachment.Position, attachment.Label, hashes[i])
+			continue
+		}
+		_, _ = fmt.Fprintf(digest, "%d\t%s\t%s\n", attachment.Position, attachment.Label, hashes[i])
+	}
+	return hex.EncodeToString(digest.Sum(nil))
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// errSkipCollect is rolling back the blob deleted for a file stored again after it was listed.
+var errSkipCollect = errors.New("skip collect")
+
+// CollectReport struct is composed of the number of files scanned, the orphaned files found and their size,
+// and the files removed and the bytes reclaimed, which are 0 in a dry run.
+type CollectReport struct {
+	Scanned        int
+	Orphaned       int
+	OrphanedBytes  int64
+	Removed        int
+	ReclaimedBytes int64
+	Blobs          int64
+}
+
+// StorageCollector struct is composed of a ProofCommander, a ProofQuerier, a Storage, the local root path of the storage and a GC config.
+type StorageCollector struct {
+	proofCommand repository.ProofCommander
+	proofQuery   repository.ProofQuerier
+	storage      storagemanage.Storage
+	root         string
+	config       *storagemanage.GCConfig
+}
+
+// NewStorageCollector function is returning a StorageCollector, accepting a ProofCommander, a ProofQuerier, a Storage,
+// the local root path the files saved before the storage existed are recorded under and a GC config.
+func NewStorageCollector(
+	proofCommander repository.ProofCommander,
+	proofQuerier repository.ProofQuerier,
+	storage storagemanage.Storage,
+	root string,
+	config *storagemanage.GCConfig,
+) *StorageCollector {
+	return &StorageCollector{
+		proofCommand: proofCommander,
+		proofQuery:   proofQuerier,
+		storage:      storage,
+		root:         root,
+		config:       config,
+	}
+}
+
+// Run method is collecting every config interval until the context is done, accepting a context.
+// Nothing is collected when the interval is 0.
+// A run is skipped while another instance holds the lock of the job.
+func (s *StorageCollector) Run(ctx context.Context) {
+	if s.config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		runLocked(ctx, s.proofCommand, collectorLockKey, func() {
+			report, err := s.Collect(ctx, time.Now(), false)
+			if err != nil {
+				log.Printf("Failed to collect orphaned files: %v", err)
+			}
+			if report != nil {
+				log.Printf("Collected %d of %d orphaned files, reclaiming %d bytes", report.Removed, report.Orphaned, report.ReclaimedBytes)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CollectLocked method is returning a CollectReport and an error, accepting a context, the current time and whether it is a dry run.
+// It collects while holding the same lock as Run, and ErrLockHeld is returned while another instance collects.
+func (s *StorageCollector) CollectLocked(ctx context.Context, now time.Time, dryRun bool) (*CollectReport, error) {
+	var report *CollectReport
+	err := withLock(ctx, s.proofCommand, collectorLockKey, func() error {
+		var err error
+		report, err = s.Collect(ctx, now, dryRun)
+		return err
+	})
+	return report, err
+}
+
+// Collect method is returning a CollectReport and an error, accepting a context, the current time and whether it is a dry run.
+// A file is orphaned when no proof, attachment, thumbnail or blob references it and it is older than the grace period.
+// Orphaned files are moved under the quarantine prefix or deleted by the config mode, and a dry run only reports them.
+func (s *StorageCollector) Collect(ctx context.Context, now time.Time, dryRun bool) (*CollectReport, error) {
+	if s.config.Mode != storagemanage.GCModeQuarantine && s.config.Mode != storagemanage.GCModeDelete {
+		return nil, errors.Join(constants.ErrStorageCollect, constants.ErrStorageConfig, fmt.Errorf("mode %q", s.config.Mode))
+	}
+
+	report := &CollectReport{}
+	orphanedBefore := now.Add(-s.config.Grace)
+
+	paths, err := s.proofQuery.ListStoragePaths(ctx, orphanedBefore)
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageCollect, err)
+	}
+	referenced := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		// 저장소 이전에 저장된 파일은 루트가 붙은 경로로 기록되어 있으므로 저장소 키로 바꿔 보호합니다.
+		referenced[path] = true
+		referenced[storagemanage.RecordedKey(s.root, path)] = true
+	}
+
+	blobs, err := s.proofQuery.ListOrphanedEvidenceBlobs(ctx, orphanedBefore)
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageCollect, err)
+	}
+	orphanedBlobs := make(map[string]*model.EvidenceBlob, len(blobs))
+	for _, blob := range blobs {
+		orphanedBlobs[storagemanage.RecordedKey(s.root, blob.Path)] = blob
+	}
+
+	objects, err := s.storage.List(ctx, "")
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageCollect, err)
+	}
+
+	var removeErr error
+	for _, object := range objects {
+		if strings.HasPrefix(object.Key, storagemanage.QuarantinePrefix) {
+			continue
+		}
+		report.Scanned++
+
+		// 업로드 중이라 아직 기록되지 않은 파일은 유예 기간 동안 남겨둡니다.
+		if referenced[object.Key] || !object.ModTime.Before(orphanedBefore) {
+			continue
+		}
+
+		report.Orphaned++
+		report.OrphanedBytes += object.Size
+
+		if dryRun {
+			continue
+		}
+
+		blob := orphanedBlobs[object.Key]
+		delete(orphanedBlobs, object.Key)
+
+		// 파일 하나를 지우지 못해도 나머지 파일은 계속 정리합니다.
+		removed, err := s.collect(ctx, object, blob, orphanedBefore)
+		if err != nil {
+			removeErr = errors.Join(removeErr, fmt.Errorf("%s: %w", object.Key, err))
+			continue
+		}
+		// 다시 참조되거나 다시 저장된 파일은 더 이상 정리 대상이 아닙니다.
+		if !removed {
+			report.Orphaned--
+			report.OrphanedBytes -= object.Size
+			continue
+		}
+		report.Removed++
+		report.ReclaimedBytes += object.Size
+		if blob != nil {
+			report.Blobs++
+		}
+	}
+
+	// 파일이 이미 없는 블롭은 행만 지웁니다.
+	for _, blob := range orphanedBlobs {
+		if dryRun {
+			break
+		}
+		deleted, err := s.proofCommand.DeleteOrphanedEvidenceBlob(ctx, blob.Digest, orphanedBefore, nil)
+		if err != nil {
+			removeErr = errors.Join(removeErr, fmt.Errorf("%s: %w", blob.Digest, err))
+			continue
+		}
+		if deleted {
+			report.Blobs++
+		}
+	}
+
+	if removeErr != nil {
+		return report, errors.Join(constants.ErrStorageCollect, removeErr)
+	}
+
+	return report, nil
+}
+
+// collect method is returning whether an orphaned file was removed and an error, accepting a context, an orphaned Object,
+// the blob stored in it, which is nil for a file without a blob, and the time blobs must have been orphaned before.
+// The blob is deleted in the same transaction as its file, so an upload locking it either keeps the file or sees it removed.
+func (s *StorageCollector) collect(ctx context.Context, object *storagemanage.Object, blob *model.EvidenceBlob, orphanedBefore time.Time) (bool, error) {
+	removed := false
+	err := runInTx(ctx, s.proofCommand, func(tx *sql.Tx) error {
+		if blob != nil {
+			// 목록을 읽은 뒤 다시 참조된 블롭은 남겨둡니다.
+			deleted, err := s.proofCommand.DeleteOrphanedEvidenceBlob(ctx, blob.Digest, orphanedBefore, tx)
+			if err != nil || !deleted {
+				return err
+			}
+		}
+
+		// 목록을 읽은 뒤 같은 키로 다시 저장된 파일은 남겨둡니다.
+		current, err := s.storage.Stat(ctx, object.Key)
+		if errors.Is(err, constants.ErrItemNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !current.ModTime.Before(orphanedBefore) {
+			return errSkipCollect
+		}
+
+		err = s.remove(ctx, object)
+		if err != nil {
+			return err
+		}
+		removed = true
+		return nil
+	})
+	if errors.Is(err, errSkipCollect) {
+		return false, nil
+	}
+	return removed, err
+}
+
+// remove method is returning an error, accepting a context and an orphaned Object.
+// A quarantined file is copied under the quarantine prefix before the original is deleted.
+func (s *StorageCollector) remove(ctx context.Context, object *storagemanage.Object) error {
+	if s.config.Mode == storagemanage.GCModeDelete {
+		return s.storage.Delete(ctx, object.Key)
+	}
+
+	reader, err := s.storage.Get(ctx, object.Key)
+	if err != nil {
+		return err
+	}
+	err = s.storage.Put(ctx, storagemanage.QuarantinePrefix+object.Key, reader, object.Size)
+	closeErr := reader.Close()
+	if closeErr != nil {
+		log.Printf("Failed to close orphaned file: %v", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Delete(ctx, object.Key)
+}
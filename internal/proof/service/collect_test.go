@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// newTestCollector function is returning a StorageCollector over a storage holding referenced, orphaned and quarantined files.
+func newTestCollector(t *testing.T, mode string) (*StorageCollector, storagemanage.Storage) {
+	storage := storagemanage.NewMemory()
+	files := map[string]string{
+		"sha256/9f/9f86":    "evidence",
+		"thumbnail/9f/9f86": "thumbnail",
+		"1_1700000000_1":    "legacy",
+		"orphan":            "orphaned",
+		"sha256/ab/abcd":    "blob",
+		"quarantine/old":    "quarantined",
+	}
+	for key, data := range files {
+		err := storage.Put(context.Background(), key, strings.NewReader(data), int64(len(data)))
+		assert.NoError(t, err)
+	}
+
+	query := *mockQuery
+	query.ListStoragePathsFn = func(ctx context.Context, orphanedBefore time.Time) ([]string, error) {
+		// 이전 파일은 루트가 붙은 절대 경로로 기록되어 있습니다.
+		return []string{"sha256/9f/9f86", "thumbnail/9f/9f86", "/srv/savedproofs/1_1700000000_1"}, nil
+	}
+
+	// 참조가 사라진 블롭 중 하나는 파일이 이미 없습니다.
+	query.ListOrphanedEvidenceBlobsFn = func(ctx context.Context, orphanedBefore time.Time) ([]*model.EvidenceBlob, error) {
+		return []*model.EvidenceBlob{{Digest: "abcd", Path: "sha256/ab/abcd"}, {Digest: "cdef", Path: "sha256/cd/cdef"}}, nil
+	}
+
+	commander := *mockCommand
+	commander.DeleteOrphanedEvidenceBlobFn = func(ctx context.Context, digest string, orphanedBefore time.Time, tx *sql.Tx) (bool, error) {
+		return true, nil
+	}
+
+	config := &storagemanage.GCConfig{Grace: time.Hour, Mode: mode}
+	return NewStorageCollector(&commander, &query, storage, "/srv/savedproofs", config), storage
+}
+
+func TestStorageCollector_Collect(t *testing.T) {
+	later := time.Now().Add(2 * time.Hour)
+
+	t.Run("드라이런 케이스", func(t *testing.T) {
+		collector, storage := newTestCollector(t, storagemanage.GCModeDelete)
+		collector.proofCommand.(*repository.MockProofCommand).DeleteOrphanedEvidenceBlobFn = func(ctx context.Context, digest string, orphanedBefore time.Time, tx *sql.Tx) (bool, error) {
+			t.Fatal("드라이런에서는 블롭을 지우지 않아야 합니다.")
+			return false, nil
+		}
+
+		report, err := collector.Collect(context.Background(), later, true)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 5, report.Scanned, "격리된 파일은 검사하지 않습니다.")
+		assert.Equal(t, 2, report.Orphaned)
+		assert.Equal(t, int64(len("orphaned")+len("blob")), report.OrphanedBytes)
+		assert.Zero(t, report.Removed)
+
+		_, err = storage.Stat(context.Background(), "orphan")
+		assert.NoError(t, err, "드라이런에서는 파일이 남아 있습니다.")
+	})
+
+	t.Run("격리 케이스", func(t *testing.T) {
+		collector, storage := newTestCollector(t, storagemanage.GCModeQuarantine)
+
+		report, err := collector.Collect(context.Background(), later, false)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 2, report.Removed)
+		assert.Equal(t, int64(len("orphaned")+len("blob")), report.ReclaimedBytes, "회수한 크기가 보고되었습니다.")
+		assert.Equal(t, int64(2), report.Blobs, "파일이 없는 블롭도 지워졌습니다.")
+
+		_, err = storage.Stat(context.Background(), "orphan")
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+
+		reader, err := storage.Get(context.Background(), storagemanage.QuarantinePrefix+"orphan")
+		assert.NoError(t, err, "참조되지 않는 파일이 격리되었습니다.")
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "orphaned", string(data))
+
+		_, err = storage.Stat(context.Background(), "1_1700000000_1")
+		assert.NoError(t, err, "참조되는 이전 파일은 남아 있습니다.")
+	})
+
+	t.Run("삭제 케이스", func(t *testing.T) {
+		collector, storage := newTestCollector(t, storagemanage.GCModeDelete)
+
+		report, err := collector.Collect(context.Background(), later, false)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 2, report.Removed)
+
+		objects, err := storage.List(context.Background(), "")
+		assert.NoError(t, err)
+		assert.Len(t, objects, 4, "참조되지 않는 파일만 지워졌습니다.")
+	})
+
+	t.Run("다시 참조된 블롭 케이스", func(t *testing.T) {
+		collector, storage := newTestCollector(t, storagemanage.GCModeDelete)
+		collector.proofCommand.(*repository.MockProofCommand).DeleteOrphanedEvidenceBlobFn = func(ctx context.Context, digest string, orphanedBefore time.Time, tx *sql.Tx) (bool, error) {
+			// 목록을 읽은 뒤 업로드가 블롭을 다시 참조했습니다.
+			return false, nil
+		}
+
+		report, err := collector.Collect(context.Background(), later, false)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 1, report.Removed)
+		assert.Equal(t, 1, report.Orphaned, "다시 참조된 블롭은 정리 대상이 아닙니다.")
+		assert.Zero(t, report.Blobs)
+
+		_, err = storage.Stat(context.Background(), "sha256/ab/abcd")
+		assert.NoError(t, err, "다시 참조된 블롭의 파일은 남아 있습니다.")
+	})
+
+	t.Run("유예 기간 케이스", func(t *testing.T) {
+		collector, _ := newTestCollector(t, storagemanage.GCModeDelete)
+
+		report, err := collector.Collect(context.Background(), time.Now(), false)
+		assert.NoError(t, err)
+		assert.Zero(t, report.Orphaned, "유예 기간이 지나지 않은 파일은 업로드 중일 수 있어 남겨둡니다.")
+	})
+
+	t.Run("잘못된 모드 케이스", func(t *testing.T) {
+		collector, _ := newTestCollector(t, "archive")
+
+		_, err := collector.Collect(context.Background(), later, false)
+		assert.ErrorIs(t, err, constants.ErrStorageConfig)
+	})
+}
@@ -3,47 +3,75 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"io"
+	"log"
 	"strconv"
+	"strings"
 	"time"
 
+	"buf.build/gen/go/wanho/security-proof-api/connectrpc/go/api/v1/apiv1connect"
 	"buf.build/gen/go/wanho/security-proof-api/connectrpc/go/chain/v1/chainv1connect"
 	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
 	chainv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/chain/v1"
 	"connectrpc.com/connect"
 
+	"security-proof/internal/db/security_proof/proof/model"
 	"security-proof/internal/proof/convert"
 	"security-proof/internal/proof/repository"
 	"security-proof/pkg/auth"
 	"security-proof/pkg/constants"
+	chainmanage "security-proof/pkg/manage/chain"
 	filemanage "security-proof/pkg/manage/file"
+	scanmanage "security-proof/pkg/manage/scan"
+	storagemanage "security-proof/pkg/manage/storage"
 )
 
 var conv = convert.ServiceConverterImpl{}
 
-// ProofCommand struct is composed of a Token, a ProofCommander, a ProofQuerier and a chain.
+// ProofCommand struct is composed of a Token, a ProofCommander, a ProofQuerier, a chain, an UserServiceClient, a Storage
+// and a Validator of uploaded files.
 type ProofCommand struct {
 	token        *auth.Token
 	proofCommand repository.ProofCommander
 	proofQuery   repository.ProofQuerier
 	chain        chainv1connect.ProofServiceClient
+	user         apiv1connect.UserServiceClient
+	storage      storagemanage.Storage
+	validator    *filemanage.Validator
+	scanner      scanmanage.Scanner
 }
 
-// NewProofCommand function is returning a ProofCommand interface, accepting a Token, a ProofCommander, a ProofQuerier and ProofServiceClient.
+// NewProofCommand function is returning a ProofCommand interface, accepting a Token, a ProofCommander, a ProofQuerier, ProofServiceClient,
+// an UserServiceClient, a Storage, a Validator and a Scanner.
 func NewProofCommand(
 	token *auth.Token,
 	proofCommander repository.ProofCommander,
 	proofQuerier repository.ProofQuerier,
 	chain chainv1connect.ProofServiceClient,
+	user apiv1connect.UserServiceClient,
+	storage storagemanage.Storage,
+	validator *filemanage.Validator,
+	scanner scanmanage.Scanner,
 ) *ProofCommand {
 	return &ProofCommand{
 		token:        token,
 		proofCommand: proofCommander,
 		proofQuery:   proofQuerier,
 		chain:        chain,
+		user:         user,
+		storage:      storage,
+		validator:    validator,
+		scanner:      scanner,
 	}
 }
 
+// MaxAttachmentSize method is returning the largest attachment file size accepted.
+func (c *ProofCommand) MaxAttachmentSize() int64 {
+	return c.validator.MaxSize()
+}
+
 // CreateProof method is returning a created index and an error, accepting a context, a Proof and an access token.
 func (c *ProofCommand) CreateProof(ctx context.Context, proof *apiv1.Proof, accessToken string) (int32, error) {
 	userIdx, role, err := c.token.ValidateToken(accessToken)
@@ -59,11 +87,31 @@ func (c *ProofCommand) CreateProof(ctx context.Context, proof *apiv1.Proof, acce
 	proof.CreatedAt = convert.TimeToPTimestamppb(time.Now())
 	proof.UpdatedAt = convert.TimeToPTimestamppb(time.Now())
 
-	idx, err := c.proofCommand.CreateProof(ctx, conv.ProtoToModel(proof), nil)
+	cycle, err := c.proofQuery.ReadActiveCycle(ctx)
 	if err != nil {
 		return 0, errors.Join(constants.ErrProofCreate, err)
 	}
-	return idx, nil
+
+	proofModel := proofToModel(proof)
+	proofModel.State = constants.StateDraft
+	proofModel.CycleIdx = cycle.Idx
+
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		idx, createErr := c.proofCommand.CreateProof(ctx, proofModel, tx)
+		if createErr != nil {
+			return createErr
+		}
+		proofModel.Idx = idx
+
+		if proof.UploadedUserIdx == 0 {
+			return nil
+		}
+		return c.transitProof(ctx, proofModel, constants.StateAssigned, proof.CreatedUserIdx, tx)
+	})
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofCreate, err)
+	}
+	return proofModel.Idx, nil
 }
 
 // UpdateProof method is returning an updated index and an error, accepting a context, a Proof and an access token.
@@ -77,14 +125,45 @@ func (c *ProofCommand) UpdateProof(ctx context.Context, proof *apiv1.Proof, acce
 		return 0, errors.Join(constants.ErrProofUpdate, constants.ErrTokenRoleAuth)
 	}
 
+	readProof, err := c.proofQuery.ReadProof(ctx, proof.Idx)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofUpdate, err)
+	}
+
+	err = checkWritable(readProof)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofUpdate, err)
+	}
+
 	proof.UpdatedUserIdx = auth.StrToInt32(userIdx)
 	proof.UpdatedAt = convert.TimeToPTimestamppb(time.Now())
 
-	idx, err := c.proofCommand.UpdateProof(ctx, conv.ProtoToModel(proof), nil)
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		_, updateErr := c.proofCommand.UpdateProof(ctx, proofToModel(proof), tx)
+		if updateErr != nil {
+			return updateErr
+		}
+
+		// 담당자가 지정되지 않은 초안은 담당자가 지정되는 시점에 assigned 상태로 전이합니다.
+		if readProof.State != constants.StateDraft || proof.UploadedUserIdx == 0 {
+			return nil
+		}
+		return c.transitProof(ctx, readProof, constants.StateAssigned, proof.UpdatedUserIdx, tx)
+	})
 	if err != nil {
 		return 0, errors.Join(constants.ErrProofUpdate, err)
 	}
-	return idx, nil
+	return proof.Idx, nil
+}
+
+// proofToModel function is returning a Proof model, accepting a Proof.
+// A proof without an assignee is recorded with no assignee rather than 0, the same as an imported proof.
+func proofToModel(proof *apiv1.Proof) *model.Proof {
+	proofModel := conv.ProtoToModel(proof)
+	if proof.UploadedUserIdx == 0 {
+		proofModel.UploadedUserIdx = nil
+	}
+	return proofModel
 }
 
 // DeleteProof method is returning an error, accepting a context, a deleting idx and an access token.
@@ -107,11 +186,19 @@ func (c *ProofCommand) DeleteProof(ctx context.Context, idx int32, accessToken s
 		return errors.Join(constants.ErrProofCreate, constants.ErrTokenRoleAuth)
 	}
 
-	_, err = c.proofQuery.ReadProof(ctx, idx)
+	err = checkWritable(readProof)
 	if err != nil {
 		return errors.Join(constants.ErrProofDelete, err)
 	}
 
+	// 올린 증적이 있으면 보관 기간이 지나기 전에는 삭제할 수 없습니다.
+	if readProof.UploadedAt != nil {
+		err = c.checkRetention(ctx, idx, time.Now())
+		if err != nil {
+			return errors.Join(constants.ErrProofDelete, err)
+		}
+	}
+
 	err = c.proofCommand.DeleteProof(ctx, idx, nil)
 	if err != nil {
 		return errors.Join(constants.ErrProofDelete, err)
@@ -121,7 +208,27 @@ func (c *ProofCommand) DeleteProof(ctx context.Context, idx int32, accessToken s
 }
 
 // UploadProof method is returning an uploaded index and an error, accepting context, an uploading index, a first image byte, a second image byte and access token.
+// The two images are stored as the first and the second attachment, and an empty image is skipped.
 func (c *ProofCommand) UploadProof(ctx context.Context, idx int32, firstImage []byte, secondImage []byte, accessToken string) (int32, error) {
+	attachments := make([]*Attachment, 0, 2)
+	if len(firstImage) > 0 {
+		attachments = append(attachments, &Attachment{Label: "first", Data: firstImage})
+	}
+	if len(secondImage) > 0 {
+		attachments = append(attachments, &Attachment{Label: "second", Data: secondImage})
+	}
+
+	return c.UploadAttachments(ctx, idx, attachments, accessToken)
+}
+
+// UploadAttachments method is returning an uploaded index and an error, accepting a context, an uploading index, ordered attachments and an access token.
+func (c *ProofCommand) UploadAttachments(ctx context.Context, idx int32, attachments []*Attachment, accessToken string) (int32, error) {
+	return c.UploadAttachmentStreams(ctx, idx, attachmentStreams(attachments), accessToken)
+}
+
+// UploadAttachmentStreams method is returning an uploaded index and an error, accepting a context, an uploading index, ordered attachment streams and an access token.
+// Every upload is kept as a new revision holding its own attachments, and each attachment is hashed while it is read.
+func (c *ProofCommand) UploadAttachmentStreams(ctx context.Context, idx int32, next AttachmentStreams, accessToken string) (int32, error) {
 	userIdx, role, err := c.token.ValidateToken(accessToken)
 	if err != nil {
 		return 0, errors.Join(constants.ErrProofUpload, err)
@@ -140,33 +247,163 @@ func (c *ProofCommand) UploadProof(ctx context.Context, idx int32, firstImage []
 		return 0, errors.Join(constants.ErrProofUpload, constants.ErrTokenRoleAuth)
 	}
 
-	fileName := strconv.Itoa(int(idx)) + "_" + strconv.FormatInt(time.Now().Unix(), 10) + "_"
-
-	firstImagePath, err := filemanage.SaveFile(fileName+"1", firstImage)
+	// 큰 파일을 받기 전에 업로드할 수 있는 상태인지 먼저 확인합니다.
+	err = checkWritable(readProof)
 	if err != nil {
 		return 0, errors.Join(constants.ErrProofUpload, err)
 	}
-
-	secondImagePath, err := filemanage.SaveFile(fileName+"2", secondImage)
+	err = checkTransition(readProof, constants.StateUploaded)
 	if err != nil {
 		return 0, errors.Join(constants.ErrProofUpload, err)
 	}
 
-	uploadedUserIdx64, err := strconv.ParseInt(userIdx, 10, 32)
-	if err != nil {
-		return 0, errors.Join(constants.ErrProofUpload, err)
-	}
+	uploadedAt := time.Now()
+
+	proofAttachments := make([]*model.ProofAttachment, 0)
+	images := make([]*model.ProofAttachment, 0)
+	var logAttachment *model.ProofAttachment
+	blobs := make([]*model.EvidenceBlob, 0)
+	reusedDigests := make([]string, 0)
+	blobByDigest := make(map[string]*uploadedBlob)
+	for {
+		stream, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return 0, errors.Join(constants.ErrProofUpload, err)
+		}
+
+		if stream.Log && logAttachment != nil {
+			return 0, errors.Join(constants.ErrProofUpload, constants.ErrProofLogDuplicate)
+		}
+
+		position := int32(len(proofAttachments) + 1)
+
+		uploaded, stored, err := c.uploadBlob(ctx, stream.Reader, stream.Log, uploadedAt, blobByDigest)
+		if errors.Is(err, constants.ErrFileInfected) {
+			log.Printf("Rejected infected attachment %d of proof %d uploaded by user %s: %v", position, idx, userIdx, err)
+		}
+		if err != nil {
+			return 0, errors.Join(constants.ErrProofUpload, err)
+		}
+		blob := uploaded.blob
+		if stored && uploaded.reused {
+			reusedDigests = append(reusedDigests, blob.Digest)
+		} else if stored {
+			blobs = append(blobs, blob)
+		}
+
+		label := strings.TrimSpace(stream.Label)
+		if label == "" && stream.Log {
+			label = "log"
+		} else if label == "" {
+			label = strconv.Itoa(int(position))
+		}
+
+		attachment := &model.ProofAttachment{
+			ProofIdx:        idx,
+			Position:        position,
+			Label:           label,
+			MimeType:        blob.MimeType,
+			Path:            blob.Path,
+			Hash:            blob.Digest,
+			Size:            blob.Size,
+			CreatedAt:       uploadedAt,
+			ThumbnailPath:   uploaded.thumbnailPath,
+			CapturedAt:      uploaded.metadata.CapturedAt,
+			DeviceMake:      convert.StringToPString(uploaded.metadata.DeviceMake),
+			DeviceModel:     convert.StringToPString(uploaded.metadata.DeviceModel),
+			Software:        convert.StringToPString(uploaded.metadata.Software),
+			LocationRemoved: uploaded.metadata.LocationRemoved,
+			IsLog:           stream.Log,
+			LineCount:       uploaded.lines,
+		}
+		proofAttachments = append(proofAttachments, attachment)
+		if stream.Log {
+			logAttachment = attachment
+		} else {
+			images = append(images, attachment)
+		}
+	}
+
+	if len(proofAttachments) == 0 {
+		return 0, errors.Join(constants.ErrProofUpload, constants.ErrProofAttachmentEmpty)
+	}
+
+	// 증적 조회 API 호환을 위해 첫 번째, 두 번째 첨부 파일과 로그의 경로를 증적에도 남깁니다.
 	proof := &apiv1.Proof{
 		Idx:             idx,
-		FirstImagePath:  firstImagePath,
-		SecondImagePath: secondImagePath,
-		UploadedUserIdx: int32(uploadedUserIdx64),
-		UploadedAt:      convert.TimeToPTimestamppb(time.Now()),
+		UploadedUserIdx: auth.StrToInt32(userIdx),
+		UploadedAt:      convert.TimeToPTimestamppb(uploadedAt),
 		Confirm:         constants.NotConfirm,
 	}
-
-	_, err = c.proofCommand.UploadProof(ctx, conv.ProtoToModel(proof), nil)
-
+	if len(images) > 0 {
+		proof.FirstImagePath = images[0].Path
+	}
+	if len(images) > 1 {
+		proof.SecondImagePath = images[1].Path
+	}
+	if logAttachment != nil {
+		proof.LogPath = logAttachment.Path
+	}
+
+	// 확정할 때 저장소의 파일과 비교할 수 있도록 업로드한 내용의 다이제스트를 리비전에 남깁니다.
+	hashes := make([]string, len(proofAttachments))
+	for i, attachment := range proofAttachments {
+		hashes[i] = attachment.Hash
+	}
+	digest := attachmentsDigest(proofAttachments, hashes)
+
+	revision := &model.ProofRevision{
+		ProofIdx:        idx,
+		UploadedUserIdx: proof.UploadedUserIdx,
+		UploadedAt:      uploadedAt,
+		Digest:          &digest,
+	}
+
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		_, uploadErr := c.proofCommand.UploadProof(ctx, conv.ProtoToModel(proof), tx)
+		if uploadErr != nil {
+			return uploadErr
+		}
+
+		// 이전 증적이 덮어써지지 않도록 업로드마다 리비전을 남깁니다.
+		revisionIdx, uploadErr := c.proofCommand.CreateProofRevision(ctx, revision, tx)
+		if uploadErr != nil {
+			return uploadErr
+		}
+
+		// 블롭을 먼저 등록해야 첨부 파일이 추가될 때 참조 수가 올라갑니다.
+		uploadErr = c.proofCommand.CreateEvidenceBlobs(ctx, blobs, tx)
+		if uploadErr != nil {
+			return uploadErr
+		}
+
+		// 다시 쓰는 블롭은 정리 작업이 지우지 못하도록 잠그고, 그 사이 파일과 함께 지워졌다면 업로드를 다시 받습니다.
+		locked, uploadErr := c.proofCommand.LockEvidenceBlobs(ctx, reusedDigests, tx)
+		if uploadErr != nil {
+			return uploadErr
+		}
+		if len(locked) < len(reusedDigests) {
+			return constants.ErrFileRemoved
+		}
+
+		for _, attachment := range proofAttachments {
+			attachment.RevisionIdx = revisionIdx
+		}
+		uploadErr = c.proofCommand.CreateProofAttachments(ctx, proofAttachments, tx)
+		if uploadErr != nil {
+			return uploadErr
+		}
+
+		if readProof.State == constants.StateRejected {
+			uploadErr = c.proofCommand.ResolveProofReject(ctx, idx, uploadedAt, tx)
+			if uploadErr != nil {
+				return uploadErr
+			}
+		}
+		return c.transitProof(ctx, readProof, constants.StateUploaded, revision.UploadedUserIdx, tx)
+	})
 	if err != nil {
 		return 0, errors.Join(constants.ErrProofUpload, err)
 	}
@@ -175,18 +412,14 @@ func (c *ProofCommand) UploadProof(ctx context.Context, idx int32, firstImage []
 }
 
 // ConfirmProof method is returning an error accepting a context, a confirmed index and access token.
+// An uploaded proof is moved through the review state, so confirming does not need a review to be started first.
 func (c *ProofCommand) ConfirmProof(ctx context.Context, idx int32, accessToken string) error {
 	userIdx, role, err := c.token.ValidateToken(accessToken)
 	if err != nil {
 		return errors.Join(constants.ErrProofConfirm, err)
 	}
 
-	readFirstProofImage, err := c.proofQuery.ReadFirstProofImage(ctx, idx)
-	if err != nil {
-		return errors.Join(constants.ErrProofConfirm, err)
-	}
-
-	readSecondProofImage, err := c.proofQuery.ReadSecondProofImage(ctx, idx)
+	readProof, err := c.proofQuery.ReadProof(ctx, idx)
 	if err != nil {
 		return errors.Join(constants.ErrProofConfirm, err)
 	}
@@ -195,12 +428,12 @@ func (c *ProofCommand) ConfirmProof(ctx context.Context, idx int32, accessToken
 		return errors.Join(constants.ErrProofConfirm, constants.ErrTokenRoleAuth)
 	}
 
-	firstImageHash, err := filemanage.ImageToHash(readFirstProofImage.FirstImagePath)
+	err = checkConfirm(readProof)
 	if err != nil {
 		return errors.Join(constants.ErrProofConfirm, err)
 	}
 
-	secondImageHash, err := filemanage.ImageToHash(readSecondProofImage.SecondImagePath)
+	revision, hashes, digest, err := c.latestRevisionDigest(ctx, idx)
 	if err != nil {
 		return errors.Join(constants.ErrProofConfirm, err)
 	}
@@ -208,9 +441,9 @@ func (c *ProofCommand) ConfirmProof(ctx context.Context, idx int32, accessToken
 	res, err := c.chain.ConfirmProof(
 		ctx,
 		connect.NewRequest(&chainv1.ConfirmProofRequest{
-			Idx:             readFirstProofImage.Idx,
-			FirstImageHash:  firstImageHash,
-			SecondImageHash: secondImageHash,
+			Idx:             idx,
+			FirstImageHash:  hashes[0],
+			SecondImageHash: chainmanage.DigestHash(digest),
 		}),
 	)
 
@@ -231,7 +464,18 @@ func (c *ProofCommand) ConfirmProof(ctx context.Context, idx int32, accessToken
 		TokenId:        res.Msg.TokenId,
 	}
 
-	err = c.proofCommand.ConfirmProof(ctx, conv.ProtoToModel(proof), nil)
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		confirmErr := c.proofCommand.ConfirmProof(ctx, conv.ProtoToModel(proof), tx)
+		if confirmErr != nil {
+			return confirmErr
+		}
+
+		confirmErr = c.proofCommand.ConfirmProofRevision(ctx, revision.Idx, proof.TokenId, digest, tx)
+		if confirmErr != nil {
+			return confirmErr
+		}
+		return c.confirmProofState(ctx, readProof, proof.UpdatedUserIdx, tx)
+	})
 	if err != nil {
 		return errors.Join(constants.ErrProofConfirm, err)
 	}
@@ -240,6 +484,7 @@ func (c *ProofCommand) ConfirmProof(ctx context.Context, idx int32, accessToken
 }
 
 // ConfirmUpdateProof method is returning an error accepting a context, a confirming index and an access token.
+// An uploaded proof is moved through the review state, so confirming does not need a review to be started first.
 func (c *ProofCommand) ConfirmUpdateProof(ctx context.Context, idx int32, accessToken string) error {
 	userIdx, role, err := c.token.ValidateToken(accessToken)
 	if err != nil {
@@ -255,12 +500,16 @@ func (c *ProofCommand) ConfirmUpdateProof(ctx context.Context, idx int32, access
 		return errors.Join(constants.ErrProofUpdateConfirm, constants.ErrTokenRoleAuth)
 	}
 
-	firstImageHash, err := filemanage.ImageToHash(readProof.FirstImagePath)
+	if readProof.TokenID == nil {
+		return errors.Join(constants.ErrProofUpdateConfirm, constants.ErrItemNotFound)
+	}
+
+	err = checkConfirm(readProof)
 	if err != nil {
-		return errors.Join(constants.ErrProofConfirm, err)
+		return errors.Join(constants.ErrProofUpdateConfirm, err)
 	}
 
-	secondImageHash, err := filemanage.ImageToHash(readProof.SecondImagePath)
+	revision, hashes, digest, err := c.latestRevisionDigest(ctx, idx)
 	if err != nil {
 		return errors.Join(constants.ErrProofUpdateConfirm, err)
 	}
@@ -269,8 +518,8 @@ func (c *ProofCommand) ConfirmUpdateProof(ctx context.Context, idx int32, access
 		ctx,
 		connect.NewRequest(&chainv1.ConfirmUpdateProofRequest{
 			TokenId:         *readProof.TokenID,
-			FirstImageHash:  firstImageHash,
-			SecondImageHash: secondImageHash,
+			FirstImageHash:  hashes[0],
+			SecondImageHash: chainmanage.DigestHash(digest),
 		}),
 	)
 	if err != nil {
@@ -289,10 +538,309 @@ func (c *ProofCommand) ConfirmUpdateProof(ctx context.Context, idx int32, access
 		Confirm:        constants.Confirm,
 	}
 
-	err = c.proofCommand.ConfirmUpdateProof(ctx, conv.ProtoToModel(proof), nil)
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		confirmErr := c.proofCommand.ConfirmUpdateProof(ctx, conv.ProtoToModel(proof), tx)
+		if confirmErr != nil {
+			return confirmErr
+		}
+
+		confirmErr = c.proofCommand.ConfirmProofRevision(ctx, revision.Idx, *readProof.TokenID, digest, tx)
+		if confirmErr != nil {
+			return confirmErr
+		}
+		return c.confirmProofState(ctx, readProof, proof.UpdatedUserIdx, tx)
+	})
 	if err != nil {
 		return errors.Join(constants.ErrProofUpdateConfirm, err)
 	}
 
 	return nil
 }
+
+// StartCycle method is returning a started cycle index and an error, accepting a context, a cycle name and an access token.
+// The active cycle is ended and every proof definition in it is carried forward into the new cycle without evidence.
+func (c *ProofCommand) StartCycle(ctx context.Context, name string, accessToken string) (int32, error) {
+	userIdx, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofCycleStart, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return 0, errors.Join(constants.ErrProofCycleStart, constants.ErrTokenRoleAuth)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, errors.Join(constants.ErrProofCycleStart, constants.ErrProofCycleName)
+	}
+
+	activeCycle, err := c.proofQuery.ReadActiveCycle(ctx)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofCycleStart, err)
+	}
+
+	startedAt := time.Now()
+	cycle := &model.AssessmentCycle{
+		Name:           name,
+		StartedUserIdx: auth.StrToInt32(userIdx),
+		StartedAt:      startedAt,
+	}
+
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		// 진행 중인 주기를 먼저 종료해야 새 주기를 만들 수 있습니다.
+		startErr := c.proofCommand.EndCycle(ctx, activeCycle.Idx, startedAt, tx)
+		if startErr != nil {
+			return startErr
+		}
+
+		cycle.Idx, startErr = c.proofCommand.CreateCycle(ctx, cycle, tx)
+		if startErr != nil {
+			return startErr
+		}
+
+		_, startErr = c.proofCommand.CarryForwardProofs(ctx, activeCycle.Idx, cycle.Idx, cycle.StartedUserIdx, startedAt, tx)
+		return startErr
+	})
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofCycleStart, err)
+	}
+
+	return cycle.Idx, nil
+}
+
+// latestRevisionDigest method is returning the latest revision, the hashes of its attachments in position order, a digest of its attachments and an error,
+// accepting a context and a proof index. The hash of the first attachment is anchored on chain as the first hash as it always was,
+// and the digest is anchored as the second hash marked with the digest prefix, so that records anchored before can be told apart.
+// Every file is hashed again and has to match the hash recorded at upload, and the digest has to match the one recorded on the revision,
+// so that a file changed after the upload is never anchored as if it were genuine.
+func (c *ProofCommand) latestRevisionDigest(ctx context.Context, idx int32) (*model.ProofRevision, []string, string, error) {
+	revision, err := c.proofQuery.ReadLatestProofRevision(ctx, idx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	attachments, err := c.proofQuery.ListProofAttachments(ctx, revision.Idx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(attachments) == 0 {
+		return nil, nil, "", constants.ErrProofAttachmentEmpty
+	}
+
+	hashes := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		hashes[i], err = c.hashFile(ctx, attachment.Path)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		// 해시 없이 옮겨진 첨부 파일은 비교할 값이 없으므로 지금 계산한 해시를 씁니다.
+		if attachment.Hash != "" && hashes[i] != attachment.Hash {
+			log.Printf("Attachment %d of proof %d does not match the hash recorded at upload", attachment.Position, idx)
+			return nil, nil, "", constants.ErrFileTampered
+		}
+	}
+
+	digest := attachmentsDigest(attachments, hashes)
+	if revision.Digest != nil && *revision.Digest != digest {
+		log.Printf("Revision %d of proof %d does not match the digest recorded at upload", revision.Revision, idx)
+		return nil, nil, "", constants.ErrFileTampered
+	}
+	return revision, hashes, digest, nil
+}
+
+// uploadBlob method is returning an uploadedBlob, whether it is new to the upload and an error,
+// accepting a context, an attachment reader, whether it is a log, a created time and the blobs of the upload by digest.
+// A log is accepted as long as it is plain text.
+func (c *ProofCommand) uploadBlob(ctx context.Context, r io.Reader, isLog bool, createdAt time.Time, uploaded map[string]*uploadedBlob) (*uploadedBlob, bool, error) {
+	spooled, err := spoolAttachment(r, c.validator.MaxSize())
+	if err != nil {
+		return nil, false, err
+	}
+	defer spooled.Close()
+
+	// 파일 이름이나 요청 헤더가 아니라 내용에서 찾은 형식으로 검사합니다.
+	if isLog {
+		err = c.validator.ValidateText(spooled.mimeType, spooled.size)
+	} else {
+		err = c.validator.Validate(spooled.mimeType, spooled.size)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// 관리자가 열어볼 파일이므로 저장하기 전에 받은 그대로 검사합니다.
+	err = scanAttachment(ctx, c.scanner, spooled)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// 위치 정보를 지운 사본을 해시하고 저장하며, 촬영 시각과 기기 정보는 따로 남깁니다.
+	metadata := &filemanage.Metadata{}
+	if filemanage.IsImage(spooled.mimeType) {
+		stripped, imageMetadata, err := stripAttachment(spooled, c.validator.MaxSize())
+		if err != nil {
+			return nil, false, err
+		}
+		defer stripped.Close()
+		spooled, metadata = stripped, imageMetadata
+	}
+
+	// 같은 내용의 파일은 다이제스트가 같으므로 한 번만 저장합니다.
+	if existing, ok := uploaded[spooled.digest]; ok {
+		return existing, false, nil
+	}
+
+	var lines *int32
+	if filemanage.IsText(spooled.mimeType) {
+		lines, err = countLines(spooled.file)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	blob, reused, err := c.storeBlob(ctx, spooled, createdAt)
+	if err != nil {
+		return nil, false, err
+	}
+	stored := &uploadedBlob{blob: blob, thumbnailPath: storeThumbnail(ctx, c.storage, spooled), metadata: metadata, lines: lines, reused: reused}
+	uploaded[spooled.digest] = stored
+	return stored, true, nil
+}
+
+// storeBlob method is returning an EvidenceBlob, whether it was registered before and an error, accepting a context, a spooledFile and a created time.
+// A blob already registered under the digest is reused without writing the data again, and the upload locks it before referencing it.
+func (c *ProofCommand) storeBlob(ctx context.Context, spooled *spooledFile, createdAt time.Time) (*model.EvidenceBlob, bool, error) {
+	blob, err := c.proofQuery.ReadEvidenceBlob(ctx, spooled.digest)
+	if err == nil {
+		return blob, true, nil
+	}
+	if !errors.Is(err, constants.ErrItemNotFound) {
+		return nil, false, err
+	}
+
+	blob = &model.EvidenceBlob{
+		Digest:    spooled.digest,
+		Path:      storagemanage.DigestKey(spooled.digest),
+		MimeType:  spooled.mimeType,
+		Size:      spooled.size,
+		CreatedAt: createdAt,
+	}
+	err = c.storage.Put(ctx, blob.Path, spooled.file, blob.Size)
+	if err != nil {
+		return nil, false, err
+	}
+	return blob, false, nil
+}
+
+// hashFile method is returning a SHA-256 hex string and an error, accepting a context and a storage key.
+func (c *ProofCommand) hashFile(ctx context.Context, key string) (string, error) {
+	reader, err := c.storage.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Printf("Failed to close file: %v", closeErr)
+		}
+	}()
+
+	hash, err := filemanage.ReaderToHash(reader)
+	if err != nil {
+		return "", errors.Join(constants.ErrFileRead, err)
+	}
+	return hash, nil
+}
+
+// RejectProof method is returning an error, accepting a context, a rejecting index, a reason and an access token.
+// The assigned engineer can upload the proof again once it is rejected.
+func (c *ProofCommand) RejectProof(ctx context.Context, idx int32, reason string, accessToken string) error {
+	userIdx, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofReject, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return errors.Join(constants.ErrProofReject, constants.ErrTokenRoleAuth)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.Join(constants.ErrProofReject, constants.ErrProofRejectReason)
+	}
+
+	readProof, err := c.proofQuery.ReadProof(ctx, idx)
+	if err != nil {
+		return errors.Join(constants.ErrProofReject, err)
+	}
+
+	reject := &model.ProofReject{
+		ProofIdx:        idx,
+		Reason:          reason,
+		RejectedUserIdx: auth.StrToInt32(userIdx),
+		RejectedAt:      time.Now(),
+	}
+
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		rejectErr := c.transitProof(ctx, readProof, constants.StateRejected, reject.RejectedUserIdx, tx)
+		if rejectErr != nil {
+			return rejectErr
+		}
+
+		_, rejectErr = c.proofCommand.CreateProofReject(ctx, reject, tx)
+		return rejectErr
+	})
+	if err != nil {
+		return errors.Join(constants.ErrProofReject, err)
+	}
+
+	return nil
+}
+
+// ReviewProof method is returning an error, accepting a context, a reviewing index and an access token.
+func (c *ProofCommand) ReviewProof(ctx context.Context, idx int32, accessToken string) error {
+	err := c.changeState(ctx, idx, constants.StateInReview, accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofReview, err)
+	}
+	return nil
+}
+
+// ExpireProof method is returning an error, accepting a context, an expiring index and an access token.
+func (c *ProofCommand) ExpireProof(ctx context.Context, idx int32, accessToken string) error {
+	err := c.changeState(ctx, idx, constants.StateExpired, accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofExpire, err)
+	}
+	return nil
+}
+
+// ReopenProof method is returning an error, accepting a context, a reopening index and an access token.
+func (c *ProofCommand) ReopenProof(ctx context.Context, idx int32, accessToken string) error {
+	err := c.changeState(ctx, idx, constants.StateReopened, accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofReopen, err)
+	}
+	return nil
+}
+
+// changeState method is returning an error, accepting a context, an index, a target state and an access token.
+// Only an admin can move a proof between the review states.
+func (c *ProofCommand) changeState(ctx context.Context, idx int32, to int32, accessToken string) error {
+	userIdx, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	if role != constants.RoleAdmin {
+		return constants.ErrTokenRoleAuth
+	}
+
+	readProof, err := c.proofQuery.ReadProof(ctx, idx)
+	if err != nil {
+		return err
+	}
+
+	return c.withTx(ctx, func(tx *sql.Tx) error {
+		return c.transitProof(ctx, readProof, to, auth.StrToInt32(userIdx), tx)
+	})
+}
@@ -1,10 +1,19 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
@@ -15,8 +24,12 @@ import (
 	"security-proof/internal/db/security_proof/proof/model"
 	"security-proof/internal/proof/repository"
 	"security-proof/pkg/auth"
+	"security-proof/pkg/catalog"
 	"security-proof/pkg/constants"
 	chainmanage "security-proof/pkg/manage/chain"
+	filemanage "security-proof/pkg/manage/file"
+	scanmanage "security-proof/pkg/manage/scan"
+	storagemanage "security-proof/pkg/manage/storage"
 )
 
 var ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
@@ -38,6 +51,22 @@ func TestProofCommand_CreateProof(t *testing.T) {
 		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
 		assert.Equal(t, int32(1), idx, "테스트 증적이 정상적으로 생성되었습니다.")
 	})
+
+	t.Run("담당자가 없는 증적 추가 케이스", func(t *testing.T) {
+		var created *model.Proof
+		commander := *mockCommand
+		commander.CreateProofFn = func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error) {
+			created = proof
+			return 1, nil
+		}
+		unassigned := newMockCommand()
+		unassigned.proofCommand = &commander
+
+		_, err := unassigned.CreateProof(ctx, &apiv1.Proof{Category: "test", Description: "test"}, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Nil(t, created.UploadedUserIdx, "담당자가 없는 증적은 가져온 증적과 같이 NULL로 기록되었습니다.")
+		assert.Equal(t, constants.StateDraft, created.State)
+	})
 }
 
 func TestProofCommand_UpdateProof(t *testing.T) {
@@ -75,10 +104,400 @@ func TestProofCommand_UploadProof(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("증적 업로드 케이스", func(t *testing.T) {
-		idx, err := command.UploadProof(ctx, 1, []byte{}, []byte{}, accessToken)
+		command := newMockCommandInState(constants.StateAssigned)
+
+		idx, err := command.UploadProof(ctx, 1, []byte("first"), []byte("second"), accessToken)
 		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
 		assert.Equal(t, int32(1), idx, "테스트 증적이 정상적으로 업데이트되었습니다.")
 	})
+
+	t.Run("반려된 증적 재업로드 케이스", func(t *testing.T) {
+		resolved := false
+		commander := *mockCommand
+		commander.ResolveProofRejectFn = func(ctx context.Context, proofIdx int32, resolvedAt time.Time, tx *sql.Tx) error {
+			resolved = true
+			return nil
+		}
+		command := newMockCommandInState(constants.StateRejected)
+		command.proofCommand = &commander
+
+		idx, err := command.UploadProof(ctx, 1, []byte("first"), []byte("second"), accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, int32(1), idx)
+		assert.True(t, resolved, "재업로드 시 반려 건이 해결 처리되었습니다.")
+	})
+
+	t.Run("업로드 리비전 기록 케이스", func(t *testing.T) {
+		var created *model.ProofRevision
+		var attached []*model.ProofAttachment
+		commander := *mockCommand
+		commander.CreateProofRevisionFn = func(ctx context.Context, revision *model.ProofRevision, tx *sql.Tx) (int32, error) {
+			created = revision
+			return 7, nil
+		}
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+
+		_, err := command.UploadProof(ctx, 1, []byte("first"), []byte{}, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.NotNil(t, created, "업로드 시 리비전이 기록되었습니다.")
+		assert.Equal(t, int32(1), created.UploadedUserIdx)
+		assert.Len(t, attached, 1, "비어 있는 이미지는 첨부 파일로 기록되지 않았습니다.")
+		assert.Equal(t, int32(7), attached[0].RevisionIdx)
+		assert.Equal(t, filemanage.DataToHash([]byte("first")), attached[0].Hash)
+		assert.Equal(t, attachmentsDigest(attached, []string{attached[0].Hash}), *created.Digest, "업로드한 내용의 다이제스트가 리비전에 기록되었습니다.")
+	})
+
+	t.Run("첨부 파일 누락 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		_, err := command.UploadProof(ctx, 1, []byte{}, []byte{}, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofAttachmentEmpty)
+	})
+
+	t.Run("확정된 증적 업로드 실패 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateConfirmed)
+
+		_, err := command.UploadProof(ctx, 1, []byte("first"), []byte("second"), accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofTransition, "확정된 증적은 재오픈 전에 업로드할 수 없습니다.")
+	})
+}
+
+func TestProofCommand_UploadAttachments(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("여러 첨부 파일 업로드 케이스", func(t *testing.T) {
+		var attached []*model.ProofAttachment
+		commander := *mockCommand
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+
+		_, err := command.UploadAttachments(ctx, 1, []*Attachment{
+			{Label: "screenshot", Data: []byte("\x89PNG\r\n\x1a\n")},
+			{Label: " ", Data: []byte("second")},
+			{Label: "config", Data: []byte("{}")},
+		}, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, attached, 3, "첨부 파일이 모두 기록되었습니다.")
+		assert.Equal(t, "image/png", attached[0].MimeType)
+		assert.Equal(t, "2", attached[1].Label, "라벨이 없으면 순서가 라벨이 됩니다.")
+		assert.Equal(t, int32(3), attached[2].Position)
+	})
+
+	t.Run("같은 내용 첨부 파일 중복 저장 방지 케이스", func(t *testing.T) {
+		var attached []*model.ProofAttachment
+		var registered []*model.EvidenceBlob
+		commander := *mockCommand
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		commander.CreateEvidenceBlobsFn = func(ctx context.Context, blobs []*model.EvidenceBlob, tx *sql.Tx) error {
+			registered = blobs
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+
+		_, err := command.UploadAttachments(ctx, 1, []*Attachment{
+			{Label: "before", Data: []byte("screenshot")},
+			{Label: "after", Data: []byte("screenshot")},
+		}, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		digest := filemanage.DataToHash([]byte("screenshot"))
+		assert.Len(t, registered, 1, "같은 내용은 블롭 하나로 등록되었습니다.")
+		assert.Equal(t, storagemanage.DigestKey(digest), attached[0].Path, "다이제스트가 저장 경로가 되었습니다.")
+		assert.Equal(t, attached[0].Path, attached[1].Path)
+
+		objects, err := command.storage.List(ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, objects, 1, "파일은 한 번만 저장되었습니다.")
+	})
+
+	t.Run("이미 저장된 블롭 재사용 케이스", func(t *testing.T) {
+		var attached []*model.ProofAttachment
+		commander := *mockCommand
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+		querier := *command.proofQuery.(*repository.MockProofQuery)
+		querier.ReadEvidenceBlobFn = func(ctx context.Context, digest string) (*model.EvidenceBlob, error) {
+			return &model.EvidenceBlob{Digest: digest, Path: "1_1700000000_1", MimeType: "image/png", Size: 10}, nil
+		}
+		command.proofQuery = &querier
+
+		_, err := command.UploadAttachments(ctx, 1, []*Attachment{{Label: "first", Data: []byte("screenshot")}}, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "1_1700000000_1", attached[0].Path, "등록된 블롭의 경로가 사용되었습니다.")
+
+		objects, err := command.storage.List(ctx, "")
+		assert.NoError(t, err)
+		assert.Empty(t, objects, "등록된 블롭은 다시 저장되지 않았습니다.")
+	})
+
+	t.Run("재사용할 블롭이 정리된 케이스", func(t *testing.T) {
+		commander := *mockCommand
+		commander.CreateEvidenceBlobsFn = func(ctx context.Context, blobs []*model.EvidenceBlob, tx *sql.Tx) error {
+			assert.Empty(t, blobs, "이미 등록된 블롭은 다시 등록하지 않습니다.")
+			return nil
+		}
+		commander.LockEvidenceBlobsFn = func(ctx context.Context, digests []string, tx *sql.Tx) ([]string, error) {
+			// 블롭을 읽은 뒤 정리 작업이 블롭과 파일을 지웠습니다.
+			return []string{}, nil
+		}
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			t.Fatal("지워진 블롭을 참조하지 않아야 합니다.")
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+		querier := *command.proofQuery.(*repository.MockProofQuery)
+		querier.ReadEvidenceBlobFn = func(ctx context.Context, digest string) (*model.EvidenceBlob, error) {
+			return &model.EvidenceBlob{Digest: digest, Path: storagemanage.DigestKey(digest), MimeType: "image/png", Size: 10}, nil
+		}
+		command.proofQuery = &querier
+
+		_, err := command.UploadAttachments(ctx, 1, []*Attachment{{Label: "first", Data: []byte("screenshot")}}, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileRemoved, "지워진 블롭을 가리키는 첨부 파일은 남지 않았습니다.")
+	})
+}
+
+func TestProofCommand_UploadAttachmentStreams(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("스트림 첨부 파일 업로드 케이스", func(t *testing.T) {
+		var attached []*model.ProofAttachment
+		commander := *mockCommand
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+
+		data := strings.Repeat("evidence", 4096)
+		streams := attachmentStreams([]*Attachment{{Label: "log", Data: []byte(data)}})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, filemanage.DataToHash([]byte(data)), attached[0].Hash, "읽으면서 계산한 해시가 기록되었습니다.")
+		assert.Equal(t, int64(len(data)), attached[0].Size)
+		assert.Equal(t, "text/plain; charset=utf-8", attached[0].MimeType)
+	})
+
+	t.Run("텍스트 로그 업로드 케이스", func(t *testing.T) {
+		var attached []*model.ProofAttachment
+		var updated *model.Proof
+		commander := *mockCommand
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		commander.UploadProofFn = func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error) {
+			updated = proof
+			return proof.Idx, nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+
+		streams := logStreams(&AttachmentStream{Label: "screen", Reader: strings.NewReader("screen")}, &AttachmentStream{Reader: strings.NewReader("line 1\nline 2\n"), Log: true})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.False(t, attached[0].IsLog)
+		assert.True(t, attached[1].IsLog, "로그 첨부 파일로 기록되었습니다.")
+		assert.Equal(t, "log", attached[1].Label, "이름이 없는 로그는 기본 이름으로 기록되었습니다.")
+		assert.Equal(t, int32(2), *attached[1].LineCount, "로그의 줄 수가 기록되었습니다.")
+		assert.Equal(t, attached[1].Path, *updated.LogPath, "증적의 로그 경로가 로그 첨부 파일을 가리킵니다.")
+		assert.Equal(t, attached[0].Path, *updated.FirstImagePath, "로그는 이미지 경로에 쓰이지 않았습니다.")
+	})
+
+	t.Run("로그 중복 업로드 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		streams := logStreams(&AttachmentStream{Reader: strings.NewReader("first"), Log: true}, &AttachmentStream{Reader: strings.NewReader("second"), Log: true})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofLogDuplicate)
+	})
+
+	t.Run("텍스트가 아닌 로그 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		streams := logStreams(&AttachmentStream{Reader: bytes.NewReader([]byte("%PDF-1.4\n")), Log: true})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileType, "로그는 텍스트만 허용되었습니다.")
+	})
+
+	t.Run("이미지 썸네일 생성 케이스", func(t *testing.T) {
+		var attached []*model.ProofAttachment
+		commander := *mockCommand
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+
+		screenshot := testPNG(t, 512, 256)
+		streams := attachmentStreams([]*Attachment{
+			{Label: "screenshot", Data: screenshot},
+			{Label: "log", Data: []byte("connection refused")},
+		})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		digest := filemanage.DataToHash(screenshot)
+		assert.Equal(t, storagemanage.ThumbnailKey(digest), *attached[0].ThumbnailPath, "이미지의 썸네일 경로가 기록되었습니다.")
+		assert.Nil(t, attached[1].ThumbnailPath, "이미지가 아닌 파일은 썸네일이 없습니다.")
+
+		reader, err := command.storage.Get(ctx, *attached[0].ThumbnailPath)
+		assert.NoError(t, err)
+		thumbnail, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, filemanage.ThumbnailType, filemanage.DetectType(thumbnail), "썸네일이 함께 저장되었습니다.")
+	})
+
+	t.Run("이미지 메타데이터 추출 케이스", func(t *testing.T) {
+		var attached []*model.ProofAttachment
+		commander := *mockCommand
+		commander.CreateProofAttachmentsFn = func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+			attached = attachments
+			return nil
+		}
+		command := newMockCommandInState(constants.StateAssigned)
+		command.proofCommand = &commander
+
+		// GPS IFD에 위도 방향 하나만 있는 EXIF입니다.
+		exif := []byte("II*\x00\x08\x00\x00\x00" +
+			"\x01\x00\x25\x88\x04\x00\x01\x00\x00\x00\x1a\x00\x00\x00\x00\x00\x00\x00" +
+			"\x01\x00\x01\x00\x02\x00\x02\x00\x00\x00N\x00\x00\x00\x00\x00\x00\x00")
+		photo := testPNGWithChunks(t, 64, 64,
+			testPNGChunk("eXIf", exif),
+			testPNGChunk("tEXt", []byte("Source\x00Galaxy S24")),
+			testPNGChunk("tEXt", []byte("Creation Time\x002024-05-01T09:30:00+09:00")),
+		)
+		streams := attachmentStreams([]*Attachment{{Label: "photo", Data: photo}})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		assert.Equal(t, "Galaxy S24", *attached[0].DeviceModel, "기기 정보가 기록되었습니다.")
+		assert.Nil(t, attached[0].DeviceMake)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 30, 0, 0, time.UTC), attached[0].CapturedAt.UTC(), "촬영 시각이 기록되었습니다.")
+		assert.True(t, attached[0].LocationRemoved)
+		assert.NotEqual(t, filemanage.DataToHash(photo), attached[0].Hash, "위치 정보를 지운 사본이 해시되었습니다.")
+
+		reader, err := command.storage.Get(ctx, attached[0].Path)
+		assert.NoError(t, err)
+		stored, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, attached[0].Hash, filemanage.DataToHash(stored), "저장된 사본의 해시가 기록되었습니다.")
+		assert.Equal(t, len(photo), len(stored), "위치 정보는 길이를 유지한 채 지워졌습니다.")
+	})
+
+	t.Run("최대 크기 초과 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		streams := attachmentStreams([]*Attachment{{Label: "large", Data: bytes.Repeat([]byte("a"), mockMaxAttachmentSize+1)}})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileTooLarge)
+
+		objects, err := command.storage.List(ctx, "")
+		assert.NoError(t, err)
+		assert.Empty(t, objects, "최대 크기를 넘은 파일은 저장되지 않았습니다.")
+	})
+
+	t.Run("허용되지 않은 형식 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		streams := attachmentStreams([]*Attachment{{Label: "evidence.png", Data: []byte("<html><script>alert(1)</script>")}})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileType, "파일 이름과 관계없이 내용으로 형식을 검사했습니다.")
+
+		objects, err := command.storage.List(ctx, "")
+		assert.NoError(t, err)
+		assert.Empty(t, objects, "허용되지 않은 파일은 저장되지 않았습니다.")
+	})
+
+	t.Run("악성 코드가 있는 파일 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		streams := attachmentStreams([]*Attachment{
+			{Label: "log", Data: []byte("connection refused")},
+			{Label: "script", Data: []byte(scanmanage.EICAR)},
+		})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileInfected)
+		assert.ErrorContains(t, err, "Eicar-Test-Signature", "탐지된 시그니처가 에러에 남았습니다.")
+
+		objects, err := command.storage.List(ctx, "")
+		assert.NoError(t, err)
+		for _, object := range objects {
+			reader, err := command.storage.Get(ctx, object.Key)
+			assert.NoError(t, err)
+			data, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(data), scanmanage.EICAR, "감염된 파일은 저장되지 않았습니다.")
+		}
+	})
+
+	t.Run("스캐너를 사용할 수 없는 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+		command.scanner = &scanmanage.Fake{Err: errors.New("connection refused")}
+
+		streams := attachmentStreams([]*Attachment{{Label: "log", Data: []byte("connection refused")}})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileScan, "검사하지 못한 파일은 거부됩니다.")
+	})
+
+	t.Run("빈 파일 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		streams := attachmentStreams([]*Attachment{{Label: "empty", Data: []byte{}}})
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileEmpty)
+	})
+
+	t.Run("업로드 중단 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		disconnected := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(io.ErrUnexpectedEOF))
+		streams := func() (*AttachmentStream, error) {
+			return &AttachmentStream{Label: "partial", Reader: disconnected}, nil
+		}
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		objects, err := command.storage.List(ctx, "")
+		assert.NoError(t, err)
+		assert.Empty(t, objects, "중단된 파일은 저장되지 않았습니다.")
+	})
+
+	t.Run("업로드 전 상태 확인 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateConfirmed)
+
+		streams := func() (*AttachmentStream, error) {
+			t.Fatal("확정된 증적의 첨부 파일은 읽지 않아야 합니다.")
+			return nil, io.EOF
+		}
+		_, err := command.UploadAttachmentStreams(ctx, 1, streams, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofTransition)
+	})
 }
 
 func TestProofCommand_ConfirmProof(t *testing.T) {
@@ -88,10 +507,133 @@ func TestProofCommand_ConfirmProof(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("증적 확정 케이스", func(t *testing.T) {
+		command := newMockCommandWithFiles(t, constants.StateInReview)
+
 		err := command.ConfirmProof(ctx, 1, accessToken)
 		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
 		assert.Equal(t, err, nil, "테스트 증적이 정상적으로 컨펌되었습니다.")
 	})
+
+	t.Run("업로드 시 기록된 해시로 확정 케이스", func(t *testing.T) {
+		var anchored string
+		chain := *mockChainClient
+		chain.ConfirmProofFn = func(ctx context.Context, req *connect.Request[chainv1.ConfirmProofRequest]) (*connect.Response[chainv1.ConfirmProofResponse], error) {
+			assert.Equal(t, filemanage.DataToHash([]byte("first")), req.Msg.FirstImageHash, "첫 번째 해시는 이전처럼 첫 번째 첨부 파일의 해시입니다.")
+			anchored, _ = chainmanage.ParseDigestHash(req.Msg.SecondImageHash)
+			return connect.NewResponse(&chainv1.ConfirmProofResponse{TokenId: 1}), nil
+		}
+		attachments := []*model.ProofAttachment{
+			{Position: 1, Label: "first", Path: storagemanage.DigestKey(filemanage.DataToHash([]byte("first"))), Hash: filemanage.DataToHash([]byte("first"))},
+		}
+		command := newMockCommandInState(constants.StateInReview)
+		command.chain = &chain
+		querier := *command.proofQuery.(*repository.MockProofQuery)
+		querier.ListProofAttachmentsFn = func(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+			return attachments, nil
+		}
+		command.proofQuery = &querier
+		err := command.storage.Put(ctx, attachments[0].Path, strings.NewReader("first"), 5)
+		assert.NoError(t, err)
+
+		var recorded string
+		commander := *mockCommand
+		commander.ConfirmProofRevisionFn = func(ctx context.Context, revisionIdx int32, tokenID int32, digest string, tx *sql.Tx) error {
+			recorded = digest
+			return nil
+		}
+		command.proofCommand = &commander
+
+		err = command.ConfirmProof(ctx, 1, accessToken)
+		assert.NoError(t, err, "저장된 파일이 업로드할 때의 해시와 같아 확정되었습니다.")
+		assert.Equal(t, attachmentsDigest(attachments, []string{attachments[0].Hash}), anchored)
+		assert.Equal(t, anchored, recorded, "체인에 기록한 다이제스트가 리비전에도 기록되었습니다.")
+	})
+
+	t.Run("업로드 이후 변조된 파일 확정 실패 케이스", func(t *testing.T) {
+		anchored := false
+		chain := *mockChainClient
+		chain.ConfirmProofFn = func(ctx context.Context, req *connect.Request[chainv1.ConfirmProofRequest]) (*connect.Response[chainv1.ConfirmProofResponse], error) {
+			anchored = true
+			return connect.NewResponse(&chainv1.ConfirmProofResponse{TokenId: 1}), nil
+		}
+		command := newMockCommandWithFiles(t, constants.StateInReview)
+		command.chain = &chain
+		err := command.storage.Put(ctx, "1_2_1", strings.NewReader("changed"), 7)
+		assert.NoError(t, err)
+
+		err = command.ConfirmProof(ctx, 1, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileTampered)
+		assert.False(t, anchored, "변조된 파일은 체인에 기록되지 않았습니다.")
+	})
+
+	t.Run("리비전 다이제스트 불일치 확정 실패 케이스", func(t *testing.T) {
+		command := newMockCommandWithFiles(t, constants.StateInReview)
+		querier := *command.proofQuery.(*repository.MockProofQuery)
+		querier.ReadLatestProofRevisionFn = func(ctx context.Context, proofIdx int32) (*model.ProofRevision, error) {
+			digest := filemanage.DataToHash([]byte("other"))
+			return &model.ProofRevision{Idx: 2, ProofIdx: proofIdx, Revision: 2, Digest: &digest}, nil
+		}
+		command.proofQuery = &querier
+
+		err := command.ConfirmProof(ctx, 1, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileTampered, "업로드할 때 기록된 다이제스트와 다른 리비전은 확정되지 않았습니다.")
+	})
+
+	t.Run("로그를 포함한 해시로 확정 케이스", func(t *testing.T) {
+		var anchored string
+		chain := *mockChainClient
+		chain.ConfirmProofFn = func(ctx context.Context, req *connect.Request[chainv1.ConfirmProofRequest]) (*connect.Response[chainv1.ConfirmProofResponse], error) {
+			anchored, _ = chainmanage.ParseDigestHash(req.Msg.SecondImageHash)
+			return connect.NewResponse(&chainv1.ConfirmProofResponse{TokenId: 1}), nil
+		}
+		hash := filemanage.DataToHash([]byte("log"))
+		attachments := []*model.ProofAttachment{
+			{Position: 1, Label: "log", Path: storagemanage.DigestKey(hash), Hash: hash, IsLog: true},
+		}
+		command := newMockCommandInState(constants.StateInReview)
+		command.chain = &chain
+		querier := *command.proofQuery.(*repository.MockProofQuery)
+		querier.ListProofAttachmentsFn = func(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+			return attachments, nil
+		}
+		command.proofQuery = &querier
+		err := command.storage.Put(ctx, attachments[0].Path, strings.NewReader("log"), 3)
+		assert.NoError(t, err)
+
+		err = command.ConfirmProof(ctx, 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, attachmentsDigest(attachments, []string{hash}), anchored, "로그의 해시가 체인 해시에 포함되었습니다.")
+		assert.NotEqual(t, attachmentsDigest([]*model.ProofAttachment{{Position: 1, Label: "log", Hash: hash}}, []string{hash}), anchored, "로그는 일반 첨부 파일과 구분되었습니다.")
+	})
+
+	t.Run("검토를 거치지 않은 업로드 증적 확정 케이스", func(t *testing.T) {
+		command := newMockCommandWithFiles(t, constants.StateUploaded)
+		var transited [][2]int32
+		commander := *mockCommand
+		commander.TransitProofStateFn = func(ctx context.Context, history *model.ProofStateHistory, tx *sql.Tx) error {
+			transited = append(transited, [2]int32{history.FromState, history.ToState})
+			return nil
+		}
+		command.proofCommand = &commander
+
+		err := command.ConfirmProof(ctx, 1, accessToken)
+		assert.NoError(t, err, "기존 클라이언트는 검토를 시작하지 않고도 업로드된 증적을 확정할 수 있습니다.")
+		assert.Equal(t, [][2]int32{
+			{constants.StateUploaded, constants.StateInReview},
+			{constants.StateInReview, constants.StateConfirmed},
+		}, transited, "검토 상태를 거친 전이가 모두 기록되었습니다.")
+	})
+
+	t.Run("업로드 전 증적 확정 실패 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		err := command.ConfirmProof(ctx, 1, accessToken)
+
+		var transitionErr *TransitionError
+		assert.ErrorAs(t, err, &transitionErr, "업로드되지 않은 증적은 확정할 수 없습니다.")
+		assert.Equal(t, constants.StateAssigned, transitionErr.From)
+		assert.Equal(t, constants.StateConfirmed, transitionErr.To)
+	})
 }
 
 func TestProofCommand_ConfirmUpdateProof(t *testing.T) {
@@ -101,14 +643,278 @@ func TestProofCommand_ConfirmUpdateProof(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("증적 확정 케이스", func(t *testing.T) {
+		command := newMockCommandWithFiles(t, constants.StateInReview)
+
 		err := command.ConfirmUpdateProof(ctx, 1, accessToken)
 		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
 		assert.Equal(t, err, nil, "테스트 증적이 정상적으로 컨펌되었습니다.")
 	})
 }
 
+func TestProofCommand_RejectProof(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("증적 반려 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateInReview)
+
+		err := command.RejectProof(ctx, 1, "스크린샷에 설정 값이 보이지 않습니다.", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+	})
+
+	t.Run("반려 사유 누락 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateInReview)
+
+		err := command.RejectProof(ctx, 1, "  ", accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofRejectReason, "반려 사유는 필수입니다.")
+	})
+
+	t.Run("검토 전 증적 반려 실패 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		err := command.RejectProof(ctx, 1, "사유", accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofTransition, "검토 중인 증적만 반려할 수 있습니다.")
+	})
+}
+
+func TestProofCommand_ReviewProof(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("증적 검토 시작 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateUploaded)
+
+		err := command.ReviewProof(ctx, 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+	})
+
+	t.Run("엔지니어 검토 시작 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		command := newMockCommandInState(constants.StateUploaded)
+
+		err = command.ReviewProof(ctx, 1, engineerToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "관리자만 검토를 시작할 수 있습니다.")
+	})
+}
+
+func TestProofCommand_ExpireProof(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("증적 만료 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateConfirmed)
+
+		err := command.ExpireProof(ctx, 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+	})
+
+	t.Run("미확정 증적 만료 실패 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateAssigned)
+
+		err := command.ExpireProof(ctx, 1, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofTransition, "확정되지 않은 증적은 만료할 수 없습니다.")
+	})
+}
+
+func TestProofCommand_ReopenProof(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("만료된 증적 재오픈 케이스", func(t *testing.T) {
+		command := newMockCommandInState(constants.StateExpired)
+
+		err := command.ReopenProof(ctx, 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+	})
+}
+
+// testPNG function is returning a gray PNG image of a size.
+func testPNG(t *testing.T, width int, height int) []byte {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0x80
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buffer, img))
+	return buffer.Bytes()
+}
+
+// testPNGWithChunks function is returning a gray PNG image of a size with chunks inserted after the header chunk.
+func testPNGWithChunks(t *testing.T, width int, height int, chunks ...[]byte) []byte {
+	encoded := testPNG(t, width, height)
+
+	// 시그니처 8바이트와 IHDR 25바이트 뒤에 넣습니다.
+	data := append([]byte{}, encoded[:33]...)
+	for _, chunk := range chunks {
+		data = append(data, chunk...)
+	}
+	return append(data, encoded[33:]...)
+}
+
+// testPNGChunk function is returning a PNG chunk, accepting a chunk type and a data.
+func testPNGChunk(chunkType string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
 func newMockCommand() *ProofCommand {
-	return NewProofCommand(mockToken, mockCommand, mockQuery, mockChainClient)
+	return NewProofCommand(mockToken, mockCommand, mockQuery, mockChainClient, mockUserClient, storagemanage.NewMemory(), mockValidator, &scanmanage.Fake{})
+}
+
+func TestProofCommand_StartCycle(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("새 심사 주기 시작 케이스", func(t *testing.T) {
+		var fromCycle, toCycle int32
+		commander := *mockCommand
+		commander.CarryForwardProofsFn = func(ctx context.Context, fromCycleIdx int32, toCycleIdx int32, userIdx int32, createdAt time.Time, tx *sql.Tx) (int64, error) {
+			fromCycle, toCycle = fromCycleIdx, toCycleIdx
+			return 2, nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		idx, err := command.StartCycle(ctx, "2026", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, int32(3), idx)
+		assert.Equal(t, int32(2), fromCycle, "진행 중인 주기의 증적이 이월되었습니다.")
+		assert.Equal(t, int32(3), toCycle, "새 주기로 증적이 이월되었습니다.")
+	})
+
+	t.Run("주기 이름 누락 케이스", func(t *testing.T) {
+		_, err := newMockCommand().StartCycle(ctx, " ", accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofCycleName)
+	})
+
+	t.Run("엔지니어 주기 시작 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = newMockCommand().StartCycle(ctx, "2026", engineerToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth)
+	})
+}
+
+func TestProofCommand_ImportControls(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	controls := []*catalog.Control{
+		{Framework: "ISMS-P", ControlID: "2.1", Title: "정책, 조직, 자산 관리"},
+		{Framework: "ISMS-P", ControlID: "2.1.1", Title: "정책의 유지관리", ParentControlID: "2.1"},
+	}
+
+	t.Run("통제 항목 가져오기 케이스", func(t *testing.T) {
+		var imported []*model.Control
+		commander := *mockCommand
+		commander.UpsertControlsFn = func(ctx context.Context, controls []*model.Control, tx *sql.Tx) (int64, error) {
+			imported = controls
+			return int64(len(controls)), nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		count, err := command.ImportControls(ctx, controls, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, int64(2), count)
+		assert.Nil(t, imported[0].ParentControlID, "상위 항목이 없으면 비어 있습니다.")
+		assert.Equal(t, "2.1", *imported[1].ParentControlID)
+	})
+
+	t.Run("빈 카탈로그 케이스", func(t *testing.T) {
+		_, err := newMockCommand().ImportControls(ctx, nil, accessToken)
+		assert.ErrorIs(t, err, constants.ErrCatalogControl)
+	})
+
+	t.Run("엔지니어 통제 항목 가져오기 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = newMockCommand().ImportControls(ctx, controls, engineerToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth)
+	})
+}
+
+func TestProofCommand_MapProofControls(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("증적 통제 항목 매핑 케이스", func(t *testing.T) {
+		var mapped []int32
+		commander := *mockCommand
+		commander.ReplaceProofControlsFn = func(ctx context.Context, proofIdx int32, controlIdxs []int32, tx *sql.Tx) error {
+			mapped = controlIdxs
+			return nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		err := command.MapProofControls(ctx, 1, []int32{3, 1, 3}, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []int32{3, 1}, mapped, "중복된 통제 항목이 제거되었습니다.")
+	})
+
+	t.Run("없는 증적 매핑 케이스", func(t *testing.T) {
+		err := newMockCommand().MapProofControls(ctx, 2, []int32{1}, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofRead)
+	})
+}
+
+// logStreams function is returning AttachmentStreams over the given streams.
+func logStreams(streams ...*AttachmentStream) AttachmentStreams {
+	next := 0
+	return func() (*AttachmentStream, error) {
+		if next == len(streams) {
+			return nil, io.EOF
+		}
+		next++
+		return streams[next-1], nil
+	}
+}
+
+// newMockCommandInState function is returning a ProofCommand whose proof reads in the given state.
+func newMockCommandInState(state int32) *ProofCommand {
+	query := *mockQuery
+	query.ReadProofFn = func(ctx context.Context, idx int32) (*model.Proof, error) {
+		proof, err := mockQuery.ReadProof(ctx, idx)
+		if err != nil {
+			return nil, err
+		}
+		proof.State = state
+		return proof, nil
+	}
+	return NewProofCommand(mockToken, mockCommand, &query, mockChainClient, mockUserClient, storagemanage.NewMemory(), mockValidator, &scanmanage.Fake{})
+}
+
+// newMockCommandWithFiles function is returning a ProofCommand in the given state whose latest attachments exist in the storage.
+func newMockCommandWithFiles(t *testing.T, state int32) *ProofCommand {
+	command := newMockCommandInState(state)
+
+	for _, attachment := range mockAttachments[2] {
+		err := command.storage.Put(ctx, attachment.Path, strings.NewReader(attachment.Label), int64(len(attachment.Label)))
+		assert.NoError(t, err)
+	}
+
+	return command
 }
 
 var mockTokenRepo = &auth.MockTokenRepo{
@@ -119,6 +925,14 @@ var mockTokenRepo = &auth.MockTokenRepo{
 
 var mockToken = auth.NewToken(mockTokenRepo)
 
+// mockMaxAttachmentSize is the largest attachment size accepted by the mock ProofCommand.
+const mockMaxAttachmentSize = 1 << 20
+
+var mockValidator = filemanage.NewValidator(&filemanage.Config{
+	MaxSize:      mockMaxAttachmentSize,
+	AllowedTypes: []string{"image/png", "image/jpeg", "application/pdf", "text/plain"},
+})
+
 var mockCommand = &repository.MockProofCommand{
 	BeginFn: func(ctx context.Context) (tx *sql.Tx, err error) {
 		fmt.Println("mock begin")
@@ -132,6 +946,12 @@ var mockCommand = &repository.MockProofCommand{
 		fmt.Println("mock rollback")
 		return nil
 	},
+	SetRetentionPolicyFn: func(ctx context.Context, policy *model.RetentionPolicy, tx *sql.Tx) (int32, error) {
+		return 1, nil
+	},
+	UpdateProofLegalHoldFn: func(ctx context.Context, proof *model.Proof, tx *sql.Tx) error {
+		return nil
+	},
 	CreateProofFn: func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error) {
 
 		if proof.Category != "test" || proof.Description != "test" {
@@ -170,6 +990,71 @@ var mockCommand = &repository.MockProofCommand{
 		}
 		return nil
 	},
+	TransitProofStateFn: func(ctx context.Context, history *model.ProofStateHistory, tx *sql.Tx) error {
+		if history.ProofIdx == 0 {
+			return constants.ErrStateConflict
+		}
+		return nil
+	},
+	CreateProofRejectFn: func(ctx context.Context, reject *model.ProofReject, tx *sql.Tx) (int32, error) {
+		if reject.ProofIdx == 0 || reject.Reason == "" {
+			return 0, constants.ErrProofReject
+		}
+		return 1, nil
+	},
+	ResolveProofRejectFn: func(ctx context.Context, proofIdx int32, resolvedAt time.Time, tx *sql.Tx) error {
+		return nil
+	},
+	CreateProofRevisionFn: func(ctx context.Context, revision *model.ProofRevision, tx *sql.Tx) (int32, error) {
+		if revision.ProofIdx == 0 {
+			return 0, constants.ErrProofRevision
+		}
+		return 1, nil
+	},
+	ConfirmProofRevisionFn: func(ctx context.Context, revisionIdx int32, tokenID int32, digest string, tx *sql.Tx) error {
+		if revisionIdx == 0 {
+			return constants.ErrItemNotFound
+		}
+		return nil
+	},
+	CreateCycleFn: func(ctx context.Context, cycle *model.AssessmentCycle, tx *sql.Tx) (int32, error) {
+		if cycle.Name == "" {
+			return 0, constants.ErrProofCycleStart
+		}
+		return 3, nil
+	},
+	EndCycleFn: func(ctx context.Context, idx int32, endedAt time.Time, tx *sql.Tx) error {
+		if idx == 0 {
+			return constants.ErrStateConflict
+		}
+		return nil
+	},
+	CarryForwardProofsFn: func(ctx context.Context, fromCycleIdx int32, toCycleIdx int32, userIdx int32, createdAt time.Time, tx *sql.Tx) (int64, error) {
+		return 2, nil
+	},
+	CreateProofAttachmentsFn: func(ctx context.Context, attachments []*model.ProofAttachment, tx *sql.Tx) error {
+		for _, attachment := range attachments {
+			if attachment.RevisionIdx == 0 || attachment.Hash == "" {
+				return constants.ErrProofUpload
+			}
+		}
+		return nil
+	},
+	CreateEvidenceBlobsFn: func(ctx context.Context, blobs []*model.EvidenceBlob, tx *sql.Tx) error {
+		return nil
+	},
+	LockEvidenceBlobsFn: func(ctx context.Context, digests []string, tx *sql.Tx) ([]string, error) {
+		return digests, nil
+	},
+	UpsertControlsFn: func(ctx context.Context, controls []*model.Control, tx *sql.Tx) (int64, error) {
+		return int64(len(controls)), nil
+	},
+	ReplaceProofControlsFn: func(ctx context.Context, proofIdx int32, controlIdxs []int32, tx *sql.Tx) error {
+		if proofIdx == 0 {
+			return constants.ErrProofControlMap
+		}
+		return nil
+	},
 }
 
 var mockChainClient = &chainmanage.MockChain{
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/auth"
+	"security-proof/pkg/constants"
+)
+
+// CreateProofComment method is returning a comment index and an error, accepting a context, a proof index, a body and an access token.
+// The author is the subject of the access token.
+func (c *ProofCommand) CreateProofComment(ctx context.Context, proofIdx int32, body string, accessToken string) (int32, error) {
+	userIdx, _, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofCommentCreate, err)
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return 0, errors.Join(constants.ErrProofCommentCreate, constants.ErrProofCommentBody)
+	}
+
+	_, err = c.proofQuery.ReadProof(ctx, proofIdx)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofCommentCreate, err)
+	}
+
+	idx, err := c.proofCommand.CreateProofComment(ctx, &model.ProofComment{
+		ProofIdx:  proofIdx,
+		UserIdx:   auth.StrToInt32(userIdx),
+		Body:      body,
+		CreatedAt: time.Now(),
+	}, nil)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofCommentCreate, err)
+	}
+
+	return idx, nil
+}
+
+// EditProofComment method is returning an error, accepting a context, a comment index, a body and an access token.
+// Only the author can edit a comment.
+func (c *ProofCommand) EditProofComment(ctx context.Context, idx int32, body string, accessToken string) error {
+	userIdx, _, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofCommentUpdate, err)
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return errors.Join(constants.ErrProofCommentUpdate, constants.ErrProofCommentBody)
+	}
+
+	comment, err := c.proofQuery.ReadProofComment(ctx, idx)
+	if err != nil {
+		return errors.Join(constants.ErrProofCommentUpdate, err)
+	}
+
+	if comment.UserIdx != auth.StrToInt32(userIdx) {
+		return errors.Join(constants.ErrProofCommentUpdate, constants.ErrProofCommentAuthor)
+	}
+
+	err = c.proofCommand.UpdateProofComment(ctx, idx, body, time.Now(), nil)
+	if err != nil {
+		return errors.Join(constants.ErrProofCommentUpdate, err)
+	}
+
+	return nil
+}
+
+// DeleteProofComment method is returning an error, accepting a context, a comment index and an access token.
+// The author or an admin can delete a comment, which is only marked as deleted.
+func (c *ProofCommand) DeleteProofComment(ctx context.Context, idx int32, accessToken string) error {
+	userIdx, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofCommentDelete, err)
+	}
+
+	comment, err := c.proofQuery.ReadProofComment(ctx, idx)
+	if err != nil {
+		return errors.Join(constants.ErrProofCommentDelete, err)
+	}
+
+	if role != constants.RoleAdmin && comment.UserIdx != auth.StrToInt32(userIdx) {
+		return errors.Join(constants.ErrProofCommentDelete, constants.ErrProofCommentAuthor)
+	}
+
+	err = c.proofCommand.DeleteProofComment(ctx, idx, time.Now(), nil)
+	if err != nil {
+		return errors.Join(constants.ErrProofCommentDelete, err)
+	}
+
+	return nil
+}
+
+// ListProofComments method is returning comments and an error, accepting a context, a proof index and an access token.
+// The oldest comment comes first and deleted comments are left out.
+func (q *ProofQuery) ListProofComments(ctx context.Context, proofIdx int32, accessToken string) ([]*model.ProofComment, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofCommentList, err)
+	}
+
+	err = q.checkEvidenceAccess(ctx, proofIdx, userIdx, role)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofCommentList, err)
+	}
+
+	comments, err := q.proofQuery.ListProofComments(ctx, proofIdx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofCommentList, err)
+	}
+
+	return comments, nil
+}
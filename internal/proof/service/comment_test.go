@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/constants"
+)
+
+func TestProofCommand_CreateProofComment(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("댓글 작성 케이스", func(t *testing.T) {
+		var created *model.ProofComment
+		commander := *mockCommand
+		commander.CreateProofCommentFn = func(ctx context.Context, comment *model.ProofComment, tx *sql.Tx) (int32, error) {
+			created = comment
+			return 1, nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		idx, err := command.CreateProofComment(ctx, 1, " 설정 화면도 첨부해 주세요. ", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, int32(1), idx)
+		assert.Equal(t, int32(1), created.UserIdx, "토큰의 사용자가 작성자로 기록되었습니다.")
+		assert.Equal(t, "설정 화면도 첨부해 주세요.", created.Body, "본문의 공백이 제거되었습니다.")
+	})
+
+	t.Run("본문 누락 케이스", func(t *testing.T) {
+		_, err := newMockCommand().CreateProofComment(ctx, 1, "  ", accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofCommentBody)
+	})
+
+	t.Run("없는 증적 댓글 작성 실패 케이스", func(t *testing.T) {
+		_, err := newMockCommand().CreateProofComment(ctx, 9, "댓글", accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofCommentCreate)
+	})
+}
+
+func TestProofCommand_EditProofComment(t *testing.T) {
+	defer cancel()
+
+	t.Run("작성자 댓글 수정 케이스", func(t *testing.T) {
+		accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		var edited string
+		commander := *mockCommand
+		commander.UpdateProofCommentFn = func(ctx context.Context, idx int32, body string, editedAt time.Time, tx *sql.Tx) error {
+			edited = body
+			return nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		err = command.EditProofComment(ctx, 1, "수정된 댓글", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "수정된 댓글", edited)
+	})
+
+	t.Run("다른 사용자 댓글 수정 실패 케이스", func(t *testing.T) {
+		adminToken, _, err := mockToken.CreateToken(ctx, "2", constants.RoleAdmin)
+		assert.NoError(t, err)
+
+		err = newMockCommand().EditProofComment(ctx, 1, "수정된 댓글", adminToken)
+		assert.ErrorIs(t, err, constants.ErrProofCommentAuthor, "작성자만 댓글을 수정할 수 있습니다.")
+	})
+}
+
+func TestProofCommand_DeleteProofComment(t *testing.T) {
+	defer cancel()
+
+	commander := *mockCommand
+	commander.DeleteProofCommentFn = func(ctx context.Context, idx int32, deletedAt time.Time, tx *sql.Tx) error {
+		return nil
+	}
+	command := newMockCommand()
+	command.proofCommand = &commander
+
+	t.Run("관리자 댓글 삭제 케이스", func(t *testing.T) {
+		adminToken, _, err := mockToken.CreateToken(ctx, "2", constants.RoleAdmin)
+		assert.NoError(t, err)
+
+		err = command.DeleteProofComment(ctx, 1, adminToken)
+		assert.NoError(t, err, "관리자는 다른 사용자의 댓글을 삭제할 수 있습니다.")
+	})
+
+	t.Run("다른 엔지니어 댓글 삭제 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "3", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		err = command.DeleteProofComment(ctx, 1, engineerToken)
+		assert.ErrorIs(t, err, constants.ErrProofCommentAuthor)
+	})
+
+	t.Run("없는 댓글 삭제 실패 케이스", func(t *testing.T) {
+		accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		err = command.DeleteProofComment(ctx, 9, accessToken)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
+}
+
+func TestProofQuery_ListProofComments(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("증적 댓글 조회 케이스", func(t *testing.T) {
+		comments, err := query.ListProofComments(context.Background(), 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, comments, 2, "댓글이 정상적으로 조회되었습니다.")
+	})
+
+	t.Run("담당하지 않은 엔지니어 케이스", func(t *testing.T) {
+		otherToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = query.ListProofComments(context.Background(), 1, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 댓글은 조회할 수 없습니다.")
+	})
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/catalog"
+	"security-proof/pkg/constants"
+)
+
+// ImportControls method is returning an imported count and an error, accepting a context, catalog controls and an access token.
+// A control already in the catalog keeps its index, so the proofs mapped to it stay mapped.
+func (c *ProofCommand) ImportControls(ctx context.Context, controls []*catalog.Control, accessToken string) (int64, error) {
+	_, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofControlImport, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return 0, errors.Join(constants.ErrProofControlImport, constants.ErrTokenRoleAuth)
+	}
+
+	if len(controls) == 0 {
+		return 0, errors.Join(constants.ErrProofControlImport, constants.ErrCatalogControl)
+	}
+
+	models := make([]*model.Control, len(controls))
+	for i, control := range controls {
+		models[i] = controlToModel(control)
+	}
+
+	var count int64
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		var importErr error
+		count, importErr = c.proofCommand.UpsertControls(ctx, models, tx)
+		return importErr
+	})
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofControlImport, err)
+	}
+
+	return count, nil
+}
+
+// MapProofControls method is returning an error, accepting a context, a proof index, control indexes and an access token.
+// The given controls replace every control the proof was mapped to.
+func (c *ProofCommand) MapProofControls(ctx context.Context, idx int32, controlIdxs []int32, accessToken string) error {
+	_, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofControlMap, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return errors.Join(constants.ErrProofControlMap, constants.ErrTokenRoleAuth)
+	}
+
+	_, err = c.proofQuery.ReadProof(ctx, idx)
+	if err != nil {
+		return errors.Join(constants.ErrProofControlMap, err)
+	}
+
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		return c.proofCommand.ReplaceProofControls(ctx, idx, uniqueIdxs(controlIdxs), tx)
+	})
+	if err != nil {
+		return errors.Join(constants.ErrProofControlMap, err)
+	}
+
+	return nil
+}
+
+// ListControls method is returning controls and an error, accepting a context, a framework and an access token.
+// Every framework is listed when the framework is empty.
+func (q *ProofQuery) ListControls(ctx context.Context, framework string, accessToken string) ([]*model.Control, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofControlList, err)
+	}
+
+	controls, err := q.proofQuery.ListControls(ctx, framework)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofControlList, err)
+	}
+
+	return controls, nil
+}
+
+// ListUncoveredControls method is returning controls and an error, accepting a context, a framework and an access token.
+// A control is uncovered when no confirmed proof of the active cycle is mapped to it.
+func (q *ProofQuery) ListUncoveredControls(ctx context.Context, framework string, accessToken string) ([]*model.Control, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofControlList, err)
+	}
+
+	cycle, err := q.proofQuery.ReadActiveCycle(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofControlList, err)
+	}
+
+	controls, err := q.proofQuery.ListUncoveredControls(ctx, cycle.Idx, framework)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofControlList, err)
+	}
+
+	return controls, nil
+}
+
+// ListProofControls method is returning controls and an error, accepting a context, a proof index and an access token.
+func (q *ProofQuery) ListProofControls(ctx context.Context, idx int32, accessToken string) ([]*model.Control, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofControlList, err)
+	}
+
+	controls, err := q.proofQuery.ListProofControls(ctx, idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofControlList, err)
+	}
+
+	return controls, nil
+}
+
+// controlToModel function is returning a Control model, accepting a catalog control.
+func controlToModel(control *catalog.Control) *model.Control {
+	result := &model.Control{
+		Framework: control.Framework,
+		ControlID: control.ControlID,
+		Title:     control.Title,
+	}
+	if control.ParentControlID != "" {
+		parentControlID := control.ParentControlID
+		result.ParentControlID = &parentControlID
+	}
+	return result
+}
+
+// uniqueIdxs function is returning indexes without duplicates, accepting indexes.
+func uniqueIdxs(idxs []int32) []int32 {
+	seen := make(map[int32]bool, len(idxs))
+	result := make([]int32, 0, len(idxs))
+	for _, idx := range idxs {
+		if seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		result = append(result, idx)
+	}
+	return result
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/auth"
+	"security-proof/pkg/constants"
+	filemanage "security-proof/pkg/manage/file"
+)
+
+// Download struct is composed of the storage key of a file, its MIME type, its size, which is -1 when it is not known,
+// its modified time, its strong entity tag, which is empty when the file was stored without a hash, its file name,
+// whether it never changes, which is true when a revision was requested, and the hash recorded at upload, which is empty for thumbnails.
+type Download struct {
+	Key       string
+	MimeType  string
+	Size      int64
+	ModTime   time.Time
+	ETag      string
+	FileName  string
+	Immutable bool
+	Hash      string
+}
+
+// ReadAttachmentDownload method is returning a Download and an error, accepting a context, a reading index, a revision, a position and an access token.
+// The latest revision is used when the revision is 0, and the file is opened with OpenDownload.
+func (q *ProofQuery) ReadAttachmentDownload(ctx context.Context, idx int32, revision int32, position int32, accessToken string) (*Download, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	return q.readDownload(ctx, idx, revision, position, false)
+}
+
+// ReadThumbnailDownload method is returning a Download of the JPEG thumbnail of an image attachment and an error,
+// accepting a context, a reading index, a revision, a position and an access token.
+// Attachments that are not images have no thumbnail.
+func (q *ProofQuery) ReadThumbnailDownload(ctx context.Context, idx int32, revision int32, position int32, accessToken string) (*Download, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	return q.readDownload(ctx, idx, revision, position, true)
+}
+
+// CreateDownloadURL method is returning the query of a signed download url, its expiry time and an error,
+// accepting a context, a proof index, a revision, a position, whether the thumbnail is served, whether the url is single-use and an access token.
+// The latest revision is resolved when the revision is 0, so that the url keeps serving the same file.
+// Only users who can download the evidence are issued a url, and the url is the permission until it expires.
+func (q *ProofQuery) CreateDownloadURL(ctx context.Context, idx int32, revision int32, position int32, thumbnail bool, singleUse bool, accessToken string) (url.Values, time.Time, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, time.Time{}, errors.Join(constants.ErrProofDownloadURL, err)
+	}
+
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
+	if err != nil {
+		return nil, time.Time{}, errors.Join(constants.ErrProofDownloadURL, err)
+	}
+
+	proofRevision, attachment, err := q.readAttachment(ctx, idx, revision, position)
+	if err != nil {
+		return nil, time.Time{}, errors.Join(constants.ErrProofDownloadURL, err)
+	}
+	if thumbnail && attachment.ThumbnailPath == nil {
+		return nil, time.Time{}, errors.Join(constants.ErrProofDownloadURL, constants.ErrItemNotFound)
+	}
+
+	claims := &auth.URLClaims{
+		ProofIdx:  idx,
+		Revision:  proofRevision.Revision,
+		Position:  position,
+		Thumbnail: thumbnail,
+		UserIdx:   userIdx,
+		SingleUse: singleUse,
+	}
+	values, err := q.urlSigner.Sign(claims)
+	if err != nil {
+		return nil, time.Time{}, errors.Join(constants.ErrProofDownloadURL, err)
+	}
+
+	return values, claims.ExpiresAt, nil
+}
+
+// ReadSignedDownload method is returning a Download and an error, accepting a context, a proof index, a position and the query of a signed url.
+// The url replaces the access token, so that a browser can load it directly.
+func (q *ProofQuery) ReadSignedDownload(ctx context.Context, idx int32, position int32, values url.Values) (*Download, error) {
+	claims, err := q.urlSigner.Verify(ctx, idx, position, values)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	// 서명된 주소는 헤더 없이 쓰이므로 누구에게 발급된 주소인지 남깁니다.
+	log.Printf("Serving a signed download of proof %d position %d issued to user %s", idx, position, claims.UserIdx)
+	return q.readDownload(ctx, idx, claims.Revision, position, claims.Thumbnail)
+}
+
+// checkEvidenceAccess method is returning an error, accepting a context, a proof index, a user index and a role.
+// Evidence is only served to admins and to the engineer assigned to the proof, and the others are refused with ErrTokenRoleAuth.
+func (q *ProofQuery) checkEvidenceAccess(ctx context.Context, idx int32, userIdx string, role int32) error {
+	if role == constants.RoleAdmin {
+		return nil
+	}
+
+	proof, err := q.proofQuery.ReadProof(ctx, idx)
+	if err != nil {
+		return err
+	}
+	if auth.Pint32ToStr(proof.UploadedUserIdx) != userIdx {
+		return constants.ErrTokenRoleAuth
+	}
+	return nil
+}
+
+// OpenDownload method is returning a reader of a downloaded file and an error, accepting a context, a Download and whether the whole file is served.
+// A whole file that no longer matches the hash recorded at upload is refused, and the caller must close the reader.
+// A partial read is served without hashing the whole file again for every range, and the integrity scan checks the stored file instead.
+func (q *ProofQuery) OpenDownload(ctx context.Context, download *Download, whole bool) (io.ReadCloser, error) {
+	hash := download.Hash
+	if !whole {
+		hash = ""
+	}
+
+	reader, err := q.openVerified(ctx, download.Key, hash)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+	return reader, nil
+}
+
+// openVerified method is returning a reader of a stored file and an error, accepting a context, a storage key and the hash recorded at upload.
+// The file is hashed before it is served and refused with ErrFileTampered when it no longer matches, unless the hash is empty.
+func (q *ProofQuery) openVerified(ctx context.Context, key string, hash string) (io.ReadCloser, error) {
+	reader, err := q.storage.Get(ctx, key)
+	if err != nil || hash == "" {
+		return reader, err
+	}
+
+	current, err := filemanage.ReaderToHash(reader)
+	if err == nil && current != hash {
+		log.Printf("Refused to serve %s, which does not match the hash recorded at upload", key)
+		err = constants.ErrFileTampered
+	} else if err != nil {
+		err = errors.Join(constants.ErrFileRead, err)
+	}
+
+	// 탐색할 수 있는 저장소는 처음으로 되돌려 한 번만 엽니다.
+	if seeker, ok := reader.(io.Seeker); ok && err == nil {
+		_, err = seeker.Seek(0, io.SeekStart)
+		if err == nil {
+			return reader, nil
+		}
+	}
+	if closeErr := reader.Close(); closeErr != nil {
+		log.Printf("Failed to close file: %v", closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q.storage.Get(ctx, key)
+}
+
+// readDownload method is returning a Download and an error, accepting a context, a proof index, a revision, a position and whether the thumbnail is served.
+func (q *ProofQuery) readDownload(ctx context.Context, idx int32, revision int32, position int32, thumbnail bool) (*Download, error) {
+	_, attachment, err := q.readAttachment(ctx, idx, revision, position)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	proof, err := q.proofQuery.ReadProof(ctx, idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	// 번호가 없는 증적은 인덱스로 이름을 짓습니다.
+	num := fmt.Sprintf("proof-%d", idx)
+	if proof.Num != nil && strings.TrimSpace(*proof.Num) != "" {
+		num = *proof.Num
+	}
+
+	if !thumbnail {
+		download := &Download{
+			Key:       attachment.Path,
+			MimeType:  attachment.MimeType,
+			Size:      attachment.Size,
+			ModTime:   attachment.CreatedAt,
+			FileName:  downloadFileName(num, attachment.Position, attachment.Label, "", attachment.MimeType),
+			Immutable: revision > 0,
+			Hash:      attachment.Hash,
+		}
+		if attachment.Hash != "" {
+			download.ETag = `"` + attachment.Hash + `"`
+		}
+		return download, nil
+	}
+
+	if attachment.ThumbnailPath == nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, constants.ErrItemNotFound)
+	}
+
+	download := &Download{
+		Key:       *attachment.ThumbnailPath,
+		MimeType:  filemanage.ThumbnailType,
+		Size:      -1,
+		ModTime:   attachment.CreatedAt,
+		FileName:  downloadFileName(num, attachment.Position, attachment.Label, "thumbnail", filemanage.ThumbnailType),
+		Immutable: revision > 0,
+	}
+	// 썸네일은 원본 내용과 크기로 정해지므로 원본 해시에 크기를 붙여 구분합니다.
+	if attachment.Hash != "" {
+		download.ETag = fmt.Sprintf(`"thumbnail-%d-%s"`, filemanage.ThumbnailSize, attachment.Hash)
+	}
+	return download, nil
+}
+
+// readAttachment method is returning a revision, an attachment of it and an error, accepting a context, a proof index, a revision and a position.
+// The latest revision is used when the revision is 0.
+func (q *ProofQuery) readAttachment(ctx context.Context, idx int32, revision int32, position int32) (*model.ProofRevision, *model.ProofAttachment, error) {
+	proofRevision, err := q.readRevision(ctx, idx, revision)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := q.proofQuery.ReadProofAttachment(ctx, proofRevision.Idx, position)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proofRevision, attachment, nil
+}
+
+// downloadFileName function is returning a file name, accepting a proof number, a position, a label, a suffix and a MIME type.
+// The suffix is left out when it is empty.
+func downloadFileName(num string, position int32, label string, suffix string, mimeType string) string {
+	parts := []string{safeFileName(num), fmt.Sprint(position)}
+	for _, part := range []string{label, suffix} {
+		part = safeFileName(part)
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "_") + exportFileExt(mimeType)
+}
+
+// safeFileName function is returning a part of a file name, accepting a text.
+// Only letters, digits, '-', '_' and '.' are kept, and the leading and trailing dots and underscores are trimmed.
+func safeFileName(text string) string {
+	safe := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(text))
+
+	return strings.Trim(safe, "._")
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+func TestProofQuery_ReadAttachmentDownload(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	query := newMockQuery()
+	err = query.storage.Put(context.Background(), "1_2_3", strings.NewReader("{}"), 2)
+	assert.NoError(t, err)
+
+	t.Run("첨부 파일 내려받기 케이스", func(t *testing.T) {
+		download, err := query.ReadAttachmentDownload(context.Background(), 1, 0, 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, `"`+mockHash+`"`, download.ETag, "저장된 SHA-256으로 강한 ETag가 만들어졌습니다.")
+		assert.Equal(t, "2.1.1_1_first.png", download.FileName, "증적 번호로 파일 이름이 만들어졌습니다.")
+	})
+
+	t.Run("저장소 첨부 파일 열기 케이스", func(t *testing.T) {
+		download, err := query.ReadAttachmentDownload(context.Background(), 1, 0, 3, accessToken)
+		assert.NoError(t, err)
+		assert.Empty(t, download.ETag, "해시가 없는 첨부 파일은 ETag가 없습니다.")
+
+		reader, err := query.OpenDownload(context.Background(), download, true)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.NoError(t, reader.Close())
+		assert.Equal(t, "{}", string(data), "저장소의 파일 내용이 조회되었습니다.")
+	})
+
+	t.Run("기록된 해시와 같은 첨부 파일 열기 케이스", func(t *testing.T) {
+		err := query.storage.Put(context.Background(), "1_2_1", strings.NewReader("first"), 5)
+		assert.NoError(t, err)
+
+		download, err := query.ReadAttachmentDownload(context.Background(), 1, 0, 1, accessToken)
+		assert.NoError(t, err)
+		reader, err := query.OpenDownload(context.Background(), download, true)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.NoError(t, reader.Close())
+		assert.Equal(t, "first", string(data), "해시를 확인한 뒤 처음부터 조회되었습니다.")
+	})
+
+	t.Run("변조된 첨부 파일 열기 케이스", func(t *testing.T) {
+		err := query.storage.Put(context.Background(), "1_2_1", strings.NewReader("changed"), 7)
+		assert.NoError(t, err)
+
+		download, err := query.ReadAttachmentDownload(context.Background(), 1, 0, 1, accessToken)
+		assert.NoError(t, err)
+		_, err = query.OpenDownload(context.Background(), download, true)
+		assert.ErrorIs(t, err, constants.ErrFileTampered, "업로드할 때와 다른 파일은 내려주지 않았습니다.")
+	})
+
+	t.Run("변조된 첨부 파일 범위 요청 케이스", func(t *testing.T) {
+		download, err := query.ReadAttachmentDownload(context.Background(), 1, 0, 1, accessToken)
+		assert.NoError(t, err)
+		reader, err := query.OpenDownload(context.Background(), download, false)
+		assert.NoError(t, err, "범위 요청은 매번 전체 파일을 해시하지 않고 무결성 검사에 맡깁니다.")
+		assert.NoError(t, reader.Close())
+	})
+
+	t.Run("저장소에 없는 첨부 파일 케이스", func(t *testing.T) {
+		download, err := query.ReadAttachmentDownload(context.Background(), 1, 0, 2, accessToken)
+		assert.NoError(t, err)
+		_, err = query.OpenDownload(context.Background(), download, true)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
+
+	t.Run("잘못된 토큰 케이스", func(t *testing.T) {
+		_, err := query.ReadAttachmentDownload(context.Background(), 1, 0, 1, "invalid")
+		assert.ErrorIs(t, err, constants.ErrTokenValidate)
+	})
+
+	t.Run("담당하지 않은 엔지니어 케이스", func(t *testing.T) {
+		otherToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = query.ReadAttachmentDownload(context.Background(), 1, 0, 1, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적은 내려받을 수 없습니다.")
+
+		_, err = query.ReadThumbnailDownload(context.Background(), 1, 0, 1, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 썸네일도 내려받을 수 없습니다.")
+
+		_, _, err = query.CreateDownloadURL(context.Background(), 1, 0, 1, false, false, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 주소는 발급되지 않습니다.")
+	})
+
+	t.Run("관리자 케이스", func(t *testing.T) {
+		adminToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleAdmin)
+		assert.NoError(t, err)
+
+		_, err = query.ReadAttachmentDownload(context.Background(), 1, 0, 3, adminToken)
+		assert.NoError(t, err, "관리자는 모든 증적을 내려받을 수 있습니다.")
+	})
+}
+
+func TestProofQuery_ReadThumbnailDownload(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	query := newMockQuery()
+	err = query.storage.Put(context.Background(), mockThumbnailPath, strings.NewReader("thumbnail"), 9)
+	assert.NoError(t, err)
+
+	t.Run("썸네일 열기 케이스", func(t *testing.T) {
+		download, err := query.ReadThumbnailDownload(context.Background(), 1, 0, 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "2.1.1_1_first_thumbnail.jpg", download.FileName)
+		assert.NotEqual(t, `"`+mockHash+`"`, download.ETag, "썸네일은 원본과 다른 ETag를 가집니다.")
+
+		reader, err := query.OpenDownload(context.Background(), download, true)
+		assert.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.NoError(t, reader.Close())
+		assert.Equal(t, "thumbnail", string(data), "썸네일 파일이 조회되었습니다.")
+	})
+
+	t.Run("썸네일이 없는 첨부 파일 케이스", func(t *testing.T) {
+		_, err := query.ReadThumbnailDownload(context.Background(), 1, 0, 3, accessToken)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound, "이미지가 아닌 첨부 파일은 썸네일이 없습니다.")
+	})
+}
+
+func TestProofQuery_ReadSignedDownload(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	query := newMockQuery()
+	err = query.storage.Put(context.Background(), "1_2_3", strings.NewReader("{}"), 2)
+	assert.NoError(t, err)
+
+	t.Run("서명된 주소로 내려받기 케이스", func(t *testing.T) {
+		values, expiresAt, err := query.CreateDownloadURL(context.Background(), 1, 0, 3, false, false, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.True(t, expiresAt.After(time.Now()), "만료 시각이 설정되었습니다.")
+		assert.Equal(t, "2", values.Get("revision"), "최신 리비전이 주소에 고정되었습니다.")
+		assert.Equal(t, "1", values.Get("user"), "주소를 발급받은 사용자가 기록되었습니다.")
+
+		download, err := query.ReadSignedDownload(context.Background(), 1, 3, values)
+		assert.NoError(t, err, "헤더 없이 내려받을 수 있습니다.")
+		assert.Equal(t, "1_2_3", download.Key)
+		assert.True(t, download.Immutable, "리비전이 고정된 파일은 바뀌지 않습니다.")
+	})
+
+	t.Run("다른 첨부 파일 요청 케이스", func(t *testing.T) {
+		values, _, err := query.CreateDownloadURL(context.Background(), 1, 0, 3, false, false, accessToken)
+		assert.NoError(t, err)
+
+		_, err = query.ReadSignedDownload(context.Background(), 1, 1, values)
+		assert.ErrorIs(t, err, constants.ErrSignedURLInvalid, "주소는 하나의 첨부 파일에만 쓸 수 있습니다.")
+	})
+
+	t.Run("일회용 썸네일 주소 케이스", func(t *testing.T) {
+		values, _, err := query.CreateDownloadURL(context.Background(), 1, 0, 1, true, true, accessToken)
+		assert.NoError(t, err)
+
+		download, err := query.ReadSignedDownload(context.Background(), 1, 1, values)
+		assert.NoError(t, err)
+		assert.Equal(t, mockThumbnailPath, download.Key, "썸네일이 내려받아집니다.")
+
+		_, err = query.ReadSignedDownload(context.Background(), 1, 1, values)
+		assert.ErrorIs(t, err, constants.ErrSignedURLUsed, "일회용 주소는 다시 쓸 수 없습니다.")
+	})
+
+	t.Run("썸네일이 없는 첨부 파일 케이스", func(t *testing.T) {
+		_, _, err := query.CreateDownloadURL(context.Background(), 1, 0, 3, true, false, accessToken)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
+
+	t.Run("잘못된 토큰 케이스", func(t *testing.T) {
+		_, _, err := query.CreateDownloadURL(context.Background(), 1, 0, 3, false, false, "invalid")
+		assert.ErrorIs(t, err, constants.ErrTokenValidate)
+	})
+}
+
+func TestDownloadFileName(t *testing.T) {
+	assert.Equal(t, "2.1.1_2_설정_파일.txt", downloadFileName("2.1.1", 2, "설정 파일", "", "text/plain; charset=utf-8"))
+	assert.Equal(t, "etc_passwd_1.pdf", downloadFileName("../etc/passwd", 1, "", "", "application/pdf"), "경로 문자는 파일 이름에 남지 않습니다.")
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"buf.build/gen/go/wanho/security-proof-api/connectrpc/go/api/v1/apiv1connect"
+	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
+	"connectrpc.com/connect"
+
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/auth"
+	"security-proof/pkg/constants"
+	signmanage "security-proof/pkg/manage/sign"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// Export bundle entries next to the evidence files.
+const (
+	exportManifestJSON = "manifest.json"
+	exportManifestCSV  = "manifest.csv"
+	exportPublicKey    = "signing_key.pub"
+	exportSignatureExt = ".sig"
+)
+
+// ExportManifest struct is composed of a cycle index, a generated time and the exported proofs.
+type ExportManifest struct {
+	CycleIdx    int32          `json:"cycleIdx"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Proofs      []*ExportProof `json:"proofs"`
+}
+
+// ExportProof struct is composed of a confirmed proof, its uploader, its confirmed revision and its files.
+type ExportProof struct {
+	Idx             int32         `json:"idx"`
+	Num             string        `json:"num"`
+	Category        string        `json:"category"`
+	Description     string        `json:"description"`
+	UploadedUserIdx int32         `json:"uploadedUserIdx"`
+	UploadedUserID  string        `json:"uploadedUserId"`
+	ConfirmedAt     time.Time     `json:"confirmedAt"`
+	Revision        int32         `json:"revision"`
+	TokenID         int32         `json:"tokenId"`
+	Files           []*ExportFile `json:"files"`
+}
+
+// ExportFile struct is composed of a path in the bundle, a label, a MIME type, a size and a SHA-256 hex string.
+type ExportFile struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+	Hash     string `json:"sha256"`
+}
+
+// ProofExport struct is composed of a Token, a ProofQuerier, an UserServiceClient, a Signer and a Storage.
+type ProofExport struct {
+	token      *auth.Token
+	proofQuery repository.ProofQuerier
+	user       apiv1connect.UserServiceClient
+	signer     *signmanage.Signer
+	storage    storagemanage.Storage
+}
+
+// NewProofExport function is returning a ProofExport, accepting a Token, a ProofQuerier, an UserServiceClient, a Signer and a Storage.
+func NewProofExport(
+	token *auth.Token,
+	proofQuerier repository.ProofQuerier,
+	user apiv1connect.UserServiceClient,
+	signer *signmanage.Signer,
+	storage storagemanage.Storage,
+) *ProofExport {
+	return &ProofExport{token: token, proofQuery: proofQuerier, user: user, signer: signer, storage: storage}
+}
+
+// ExportEvidence method is returning an error, accepting a context, a writer, a cycle index and an access token.
+// The evidence files of every confirmed proof of the cycle are streamed into a ZIP bundle one by one,
+// followed by the manifests, their detached signatures and the public key to verify them with.
+// The active cycle is exported when the cycle index is 0.
+func (e *ProofExport) ExportEvidence(ctx context.Context, w io.Writer, cycleIdx int32, accessToken string) error {
+	_, role, err := e.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofExport, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return errors.Join(constants.ErrProofExport, constants.ErrTokenRoleAuth)
+	}
+
+	if cycleIdx == 0 {
+		cycle, err := e.proofQuery.ReadActiveCycle(ctx)
+		if err != nil {
+			return errors.Join(constants.ErrProofExport, err)
+		}
+		cycleIdx = cycle.Idx
+	}
+
+	proofs, err := e.proofQuery.ListConfirmedProofs(ctx, cycleIdx)
+	if err != nil {
+		return errors.Join(constants.ErrProofExport, err)
+	}
+
+	manifest := &ExportManifest{CycleIdx: cycleIdx, GeneratedAt: time.Now(), Proofs: make([]*ExportProof, 0, len(proofs))}
+	users := make(map[int32]string)
+
+	bundle := zip.NewWriter(w)
+	for _, proof := range proofs {
+		exportProof, err := e.exportProof(ctx, bundle, proof, users, accessToken)
+		if err != nil {
+			return errors.Join(constants.ErrProofExport, err)
+		}
+		manifest.Proofs = append(manifest.Proofs, exportProof)
+	}
+
+	err = e.writeManifest(bundle, manifest)
+	if err != nil {
+		return errors.Join(constants.ErrProofExport, err)
+	}
+
+	err = bundle.Close()
+	if err != nil {
+		return errors.Join(constants.ErrProofExport, err)
+	}
+
+	return nil
+}
+
+// exportProof method is returning a manifest entry and an error, accepting a context, a ZIP writer, a confirmed proof, resolved users and an access token.
+func (e *ProofExport) exportProof(ctx context.Context, bundle *zip.Writer, proof *repository.ConfirmedProof, users map[int32]string, accessToken string) (*ExportProof, error) {
+	revision, err := e.proofQuery.ReadLatestProofRevision(ctx, proof.Idx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := e.proofQuery.ListProofAttachments(ctx, revision.Idx)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadedUserID, err := e.readUserID(ctx, revision.UploadedUserIdx, users, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	exportProof := &ExportProof{
+		Idx:             proof.Idx,
+		Category:        proof.Category,
+		Description:     proof.Description,
+		UploadedUserIdx: revision.UploadedUserIdx,
+		UploadedUserID:  uploadedUserID,
+		ConfirmedAt:     proof.ConfirmedAt,
+		Revision:        revision.Revision,
+		Files:           make([]*ExportFile, 0, len(attachments)),
+	}
+	if proof.Num != nil {
+		exportProof.Num = *proof.Num
+	}
+	// 리비전에 토큰이 없으면 증적에 기록된 토큰을 사용합니다.
+	if revision.TokenID != nil {
+		exportProof.TokenID = *revision.TokenID
+	} else if proof.TokenID != nil {
+		exportProof.TokenID = *proof.TokenID
+	}
+
+	for _, attachment := range attachments {
+		file := &ExportFile{
+			Name:     exportFileName(proof.Idx, attachment.Position, attachment.Label, attachment.MimeType),
+			Label:    attachment.Label,
+			MimeType: attachment.MimeType,
+		}
+
+		file.Size, file.Hash, err = e.copyToBundle(ctx, bundle, file.Name, attachment.Path)
+		if err != nil {
+			return nil, err
+		}
+		// 업로드할 때 기록된 해시와 다른 파일은 진본처럼 내보내지 않습니다.
+		if attachment.Hash != "" && file.Hash != attachment.Hash {
+			log.Printf("Refused to export attachment %d of proof %d, which does not match the hash recorded at upload", attachment.Position, proof.Idx)
+			return nil, constants.ErrFileTampered
+		}
+		exportProof.Files = append(exportProof.Files, file)
+	}
+
+	return exportProof, nil
+}
+
+// readUserID method is returning a user ID and an error, accepting a context, a user index, resolved users and an access token.
+func (e *ProofExport) readUserID(ctx context.Context, idx int32, users map[int32]string, accessToken string) (string, error) {
+	id, ok := users[idx]
+	if ok {
+		return id, nil
+	}
+
+	req := connect.NewRequest(&apiv1.ReadUserRequest{Idx: idx})
+	req.Header().Set("accessToken", accessToken)
+	res, err := e.user.ReadUser(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	users[idx] = res.Msg.User.Id
+	return res.Msg.User.Id, nil
+}
+
+// writeManifest method is returning an error, accepting a ZIP writer and a manifest.
+// Both manifests are signed so that either one can be checked on its own.
+func (e *ProofExport) writeManifest(bundle *zip.Writer, manifest *ExportManifest) error {
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	manifestCSV, err := manifestToCSV(manifest)
+	if err != nil {
+		return err
+	}
+
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{exportManifestJSON, manifestJSON},
+		{exportManifestJSON + exportSignatureExt, []byte(base64.StdEncoding.EncodeToString(e.signer.Sign(manifestJSON)))},
+		{exportManifestCSV, manifestCSV},
+		{exportManifestCSV + exportSignatureExt, []byte(base64.StdEncoding.EncodeToString(e.signer.Sign(manifestCSV)))},
+		{exportPublicKey, e.signer.PublicKeyPEM()},
+	}
+	for _, entry := range entries {
+		writer, err := bundle.Create(entry.name)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(entry.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyToBundle method is returning a size, a SHA-256 hex string and an error, accepting a context, a ZIP writer, an entry name and a storage key.
+// The file is hashed while it is copied, which gives the same hash as filemanage.ReaderToHash without reading it twice.
+func (e *ProofExport) copyToBundle(ctx context.Context, bundle *zip.Writer, name string, key string) (int64, string, error) {
+	file, err := e.storage.Get(ctx, key)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			log.Printf("Failed to close file: %v", closeErr)
+		}
+	}()
+
+	writer, err := bundle.Create(name)
+	if err != nil {
+		return 0, "", err
+	}
+
+	digest := sha256.New()
+	size, err := io.Copy(io.MultiWriter(writer, digest), file)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// manifestToCSV function is returning a CSV with a row per exported file and an error, accepting a manifest.
+func manifestToCSV(manifest *ExportManifest) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+
+	err := writer.Write([]string{
+		"num", "category", "description", "uploaded_user_id", "confirmed_at", "token_id", "revision", "file", "label", "sha256",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, proof := range manifest.Proofs {
+		for _, file := range proof.Files {
+			err = writer.Write([]string{
+				proof.Num,
+				proof.Category,
+				proof.Description,
+				proof.UploadedUserID,
+				proof.ConfirmedAt.Format(time.RFC3339),
+				strconv.FormatInt(int64(proof.TokenID), 10),
+				strconv.FormatInt(int64(proof.Revision), 10),
+				file.Name,
+				file.Label,
+				file.Hash,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// exportFileName function is returning a path in the bundle, accepting a proof index, a position, a label and a MIME type.
+func exportFileName(proofIdx int32, position int32, label string, mimeType string) string {
+	safeLabel := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, label)
+
+	return fmt.Sprintf("proofs/%d/%d_%s%s", proofIdx, position, safeLabel, exportFileExt(mimeType))
+}
+
+// exportExtensions is the extension of the common evidence MIME types, which the system MIME table does not settle on.
+var exportExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+	"text/csv":        ".csv",
+}
+
+// exportFileExt function is returning a file extension, accepting a MIME type.
+func exportFileExt(mimeType string) string {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return ""
+	}
+
+	ext, ok := exportExtensions[mediaType]
+	if ok {
+		return ext
+	}
+
+	extensions, err := mime.ExtensionsByType(mediaType)
+	if err != nil || len(extensions) == 0 {
+		return ""
+	}
+	return extensions[0]
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	filemanage "security-proof/pkg/manage/file"
+	signmanage "security-proof/pkg/manage/sign"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+func TestProofExport_ExportEvidence(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	signer, err := signmanage.NewSigner(nil)
+	assert.NoError(t, err)
+
+	storage := storagemanage.NewMemory()
+	attachments := []*model.ProofAttachment{
+		{Idx: 1, ProofIdx: 1, RevisionIdx: 2, Position: 1, Label: "first", MimeType: "image/png", Path: "1_2_1"},
+		{Idx: 2, ProofIdx: 1, RevisionIdx: 2, Position: 2, Label: "설정 파일", MimeType: "text/plain; charset=utf-8", Path: "1_2_2"},
+	}
+	for _, attachment := range attachments {
+		err = storage.Put(context.Background(), attachment.Path, strings.NewReader(attachment.Label), -1)
+		assert.NoError(t, err)
+	}
+
+	num := "2.1.1"
+	tokenID := int32(7)
+	confirmedAt := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	query := *mockQuery
+	query.ListConfirmedProofsFn = func(ctx context.Context, cycleIdx int32) ([]*repository.ConfirmedProof, error) {
+		if cycleIdx != 2 {
+			return nil, nil
+		}
+		return []*repository.ConfirmedProof{
+			{Proof: model.Proof{Idx: 1, Num: &num, Category: "정책", Description: "정책 문서", TokenID: &tokenID}, ConfirmedAt: confirmedAt},
+		}, nil
+	}
+	query.ListProofAttachmentsFn = func(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+		return attachments, nil
+	}
+	query.ReadLatestProofRevisionFn = func(ctx context.Context, proofIdx int32) (*model.ProofRevision, error) {
+		return &model.ProofRevision{Idx: 2, ProofIdx: proofIdx, Revision: 2, UploadedUserIdx: 3}, nil
+	}
+	export := NewProofExport(mockToken, &query, mockUserClient, signer, storage)
+
+	t.Run("증적 패키지 내보내기 케이스", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := export.ExportEvidence(context.Background(), buf, 0, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		bundle, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+
+		entries := make(map[string][]byte)
+		for _, file := range bundle.File {
+			reader, err := file.Open()
+			assert.NoError(t, err)
+			entries[file.Name], err = io.ReadAll(reader)
+			assert.NoError(t, err)
+		}
+		assert.Contains(t, entries, "proofs/1/1_first.png")
+		assert.Contains(t, entries, "proofs/1/2_설정_파일.txt")
+
+		manifest := &ExportManifest{}
+		err = json.Unmarshal(entries[exportManifestJSON], manifest)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), manifest.CycleIdx, "진행 중인 주기가 내보내졌습니다.")
+		assert.Len(t, manifest.Proofs, 1)
+		assert.Equal(t, "user3", manifest.Proofs[0].UploadedUserID, "업로더 ID가 기록되었습니다.")
+		assert.Equal(t, int32(7), manifest.Proofs[0].TokenID, "체인 토큰이 기록되었습니다.")
+		assert.Equal(t, filemanage.DataToHash([]byte("first")), manifest.Proofs[0].Files[0].Hash, "파일 해시가 기록되었습니다.")
+
+		signature, err := base64.StdEncoding.DecodeString(string(entries[exportManifestJSON+exportSignatureExt]))
+		assert.NoError(t, err)
+		assert.True(t, signmanage.Verify(entries[exportPublicKey], entries[exportManifestJSON], signature), "매니페스트 서명이 검증되었습니다.")
+
+		signature, err = base64.StdEncoding.DecodeString(string(entries[exportManifestCSV+exportSignatureExt]))
+		assert.NoError(t, err)
+		assert.True(t, signmanage.Verify(entries[exportPublicKey], entries[exportManifestCSV], signature), "CSV 매니페스트 서명이 검증되었습니다.")
+	})
+
+	t.Run("엔지니어 내보내기 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		buf := &bytes.Buffer{}
+		err = export.ExportEvidence(context.Background(), buf, 0, engineerToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth)
+		assert.Zero(t, buf.Len(), "권한이 없으면 아무것도 쓰지 않습니다.")
+	})
+}
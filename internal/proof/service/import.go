@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
+	"connectrpc.com/connect"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/auth"
+	"security-proof/pkg/constants"
+)
+
+// ProofImportRow struct is composed of a line, a created index, a num, a category, a description and an assignee ID of an imported proof.
+type ProofImportRow struct {
+	Line        int
+	Idx         int32
+	Num         string
+	Category    string
+	Description string
+	AssigneeID  string
+	assigneeIdx int32
+}
+
+// ProofImportRowError struct is composed of a line, a num and an error of a rejected import row.
+type ProofImportRowError struct {
+	Line int
+	Num  string
+	Err  error
+}
+
+// Error method is returning an error message.
+func (e *ProofImportRowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap method is returning the error of the row so that callers can match it with errors.Is.
+func (e *ProofImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ProofImportResult struct is composed of created rows and rejected rows of an import.
+type ProofImportResult struct {
+	Created []*ProofImportRow
+	Errors  []*ProofImportRowError
+}
+
+// ImportProofs method is returning an import result and an error, accepting a context, a CSV reader and an access token.
+// The CSV has a header row of num, category, description and an optional assignee_id.
+// Rejected rows are reported one by one, and every valid row is created in the active cycle in a single transaction.
+func (c *ProofCommand) ImportProofs(ctx context.Context, r io.Reader, accessToken string) (*ProofImportResult, error) {
+	userIdx, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofImport, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return nil, errors.Join(constants.ErrProofImport, constants.ErrTokenRoleAuth)
+	}
+
+	rows, err := parseProofRows(r)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofImport, err)
+	}
+
+	cycle, err := c.proofQuery.ReadActiveCycle(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofImport, err)
+	}
+
+	existing, err := c.proofQuery.AllProofs(ctx, cycle.Idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofImport, err)
+	}
+
+	nums := make(map[string]bool, len(existing)+len(rows))
+	for _, proof := range existing {
+		if proof.Num != nil {
+			nums[*proof.Num] = true
+		}
+	}
+
+	result := &ProofImportResult{Created: make([]*ProofImportRow, 0), Errors: make([]*ProofImportRowError, 0)}
+	users := make(map[string]int32)
+	for _, row := range rows {
+		rowErr, err := c.validateProofRow(ctx, row, nums, users, accessToken)
+		if err != nil {
+			return nil, errors.Join(constants.ErrProofImport, err)
+		}
+		if rowErr != nil {
+			result.Errors = append(result.Errors, rowErr)
+			continue
+		}
+		result.Created = append(result.Created, row)
+	}
+
+	if len(result.Created) == 0 {
+		return result, nil
+	}
+
+	createdUserIdx := auth.StrToInt32(userIdx)
+	createdAt := time.Now()
+	err = c.withTx(ctx, func(tx *sql.Tx) error {
+		for _, row := range result.Created {
+			num := row.Num
+			proof := &model.Proof{
+				Num:            &num,
+				Category:       row.Category,
+				Description:    row.Description,
+				CreatedUserIdx: &createdUserIdx,
+				CreatedAt:      createdAt,
+				UpdatedAt:      &createdAt,
+				State:          constants.StateDraft,
+				CycleIdx:       cycle.Idx,
+			}
+			if row.assigneeIdx != 0 {
+				assigneeIdx := row.assigneeIdx
+				proof.UploadedUserIdx = &assigneeIdx
+			}
+
+			idx, createErr := c.proofCommand.CreateProof(ctx, proof, tx)
+			if createErr != nil {
+				return fmt.Errorf("line %d: %w", row.Line, createErr)
+			}
+			proof.Idx = idx
+			row.Idx = idx
+
+			if proof.UploadedUserIdx == nil {
+				continue
+			}
+			createErr = c.transitProof(ctx, proof, constants.StateAssigned, createdUserIdx, tx)
+			if createErr != nil {
+				return fmt.Errorf("line %d: %w", row.Line, createErr)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofImport, err)
+	}
+
+	return result, nil
+}
+
+// validateProofRow method is returning a row error and an error, accepting a context, a row, the nums taken so far, resolved users and an access token.
+// A row error rejects only the row, while an error aborts the whole import.
+// The num of the row is taken even when the row is rejected later, so that a repeated num is always reported.
+func (c *ProofCommand) validateProofRow(ctx context.Context, row *ProofImportRow, nums map[string]bool, users map[string]int32, accessToken string) (*ProofImportRowError, error) {
+	if row.Num == "" || row.Category == "" || row.Description == "" {
+		return &ProofImportRowError{Line: row.Line, Num: row.Num, Err: constants.ErrProofImportRow}, nil
+	}
+
+	if nums[row.Num] {
+		return &ProofImportRowError{Line: row.Line, Num: row.Num, Err: constants.ErrProofNumDuplicate}, nil
+	}
+	nums[row.Num] = true
+
+	if row.AssigneeID == "" {
+		return nil, nil
+	}
+
+	idx, ok := users[row.AssigneeID]
+	if !ok {
+		var err error
+		idx, err = c.readUserIdxByID(ctx, row.AssigneeID, accessToken)
+		if errors.Is(err, constants.ErrItemNotFound) {
+			return &ProofImportRowError{Line: row.Line, Num: row.Num, Err: errors.Join(constants.ErrProofAssignee, fmt.Errorf("user %q", row.AssigneeID))}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		users[row.AssigneeID] = idx
+	}
+	row.assigneeIdx = idx
+
+	return nil, nil
+}
+
+// readUserIdxByID method is returning a user index and an error, accepting a context, a user ID and an access token.
+func (c *ProofCommand) readUserIdxByID(ctx context.Context, id string, accessToken string) (int32, error) {
+	req := connect.NewRequest(&apiv1.ListUserRequest{Id: id})
+	req.Header().Set("accessToken", accessToken)
+
+	res, err := c.user.ListUser(ctx, req)
+	if connect.CodeOf(err) == connect.CodeNotFound {
+		return 0, constants.ErrItemNotFound
+	} else if err != nil {
+		return 0, err
+	}
+
+	// 사용자 검색은 부분 일치이므로 ID가 정확히 같은 사용자만 사용합니다.
+	for _, user := range res.Msg.Users {
+		if user.Id == id {
+			return user.Idx, nil
+		}
+	}
+
+	return 0, constants.ErrItemNotFound
+}
+
+// parseProofRows function is returning import rows and an error, accepting a reader of a CSV with a header row.
+func parseProofRows(r io.Reader) ([]*ProofImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofImportParse, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"num", "category", "description"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Join(constants.ErrProofImportParse, fmt.Errorf("missing column %q", name))
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]*ProofImportRow, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Join(constants.ErrProofImportParse, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, &ProofImportRow{
+			Line:        line,
+			Num:         field(record, "num"),
+			Category:    field(record, "category"),
+			Description: field(record, "description"),
+			AssigneeID:  field(record, "assignee_id"),
+		})
+	}
+
+	return rows, nil
+}
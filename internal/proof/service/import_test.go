@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/constants"
+)
+
+func TestProofCommand_ImportProofs(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("증적 일괄 등록 케이스", func(t *testing.T) {
+		created := make([]*model.Proof, 0)
+		commander := *mockCommand
+		commander.CreateProofFn = func(ctx context.Context, proof *model.Proof, tx *sql.Tx) (int32, error) {
+			created = append(created, proof)
+			return int32(len(created)), nil
+		}
+		existingNum := "1.1"
+		query := *mockQuery
+		query.AllProofsFn = func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+			return []*model.Proof{{Idx: 1, Num: &existingNum}}, nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+		command.proofQuery = &query
+
+		result, err := command.ImportProofs(ctx, strings.NewReader(
+			"num,category,description,assignee_id\n"+
+				"2.1,정책,정책 문서,engineer\n"+
+				"2.2,정책,정책 검토,\n"+
+				"1.1,정책,이미 등록된 번호,\n"+
+				"2.1,정책,중복 번호,\n"+
+				"2.3,,분류 누락,\n"+
+				"2.4,정책,담당자 없음,unknown\n",
+		), accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		assert.Len(t, result.Created, 2, "올바른 행만 등록되었습니다.")
+		assert.Len(t, created, 2, "하나의 트랜잭션에서 등록되었습니다.")
+		assert.Equal(t, int32(3), *created[0].UploadedUserIdx, "담당자 ID가 사용자 인덱스로 변환되었습니다.")
+		assert.Equal(t, constants.StateAssigned, created[0].State, "담당자가 있는 증적은 할당 상태입니다.")
+		assert.Nil(t, created[1].UploadedUserIdx)
+		assert.Equal(t, int32(2), created[1].CycleIdx, "진행 중인 주기에 등록되었습니다.")
+
+		assert.Len(t, result.Errors, 4, "행마다 에러가 보고되었습니다.")
+		assert.Equal(t, 4, result.Errors[0].Line)
+		assert.ErrorIs(t, result.Errors[0], constants.ErrProofNumDuplicate)
+		assert.ErrorIs(t, result.Errors[1], constants.ErrProofNumDuplicate)
+		assert.ErrorIs(t, result.Errors[2], constants.ErrProofImportRow)
+		assert.ErrorIs(t, result.Errors[3], constants.ErrProofAssignee)
+	})
+
+	t.Run("필수 컬럼 누락 케이스", func(t *testing.T) {
+		_, err := newMockCommand().ImportProofs(ctx, strings.NewReader("num,category\n1,정책\n"), accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofImportParse)
+	})
+
+	t.Run("엔지니어 일괄 등록 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = newMockCommand().ImportProofs(ctx, strings.NewReader(""), engineerToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth)
+	})
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"buf.build/gen/go/wanho/security-proof-api/connectrpc/go/chain/v1/chainv1connect"
+	chainv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/chain/v1"
+	"connectrpc.com/connect"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	chainmanage "security-proof/pkg/manage/chain"
+	filemanage "security-proof/pkg/manage/file"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// IntegrityReport struct is composed of the number of checked revisions and the number of results of each status.
+type IntegrityReport struct {
+	Revisions int
+	OK        int
+	Mismatch  int
+	Missing   int
+}
+
+// IntegrityScanner struct is composed of a ProofCommander, a ProofQuerier, a ProofServiceClient, a Storage and an integrity config.
+type IntegrityScanner struct {
+	proofCommand repository.ProofCommander
+	proofQuery   repository.ProofQuerier
+	chain        chainv1connect.ProofServiceClient
+	storage      storagemanage.Storage
+	config       *storagemanage.IntegrityConfig
+}
+
+// NewIntegrityScanner function is returning an IntegrityScanner, accepting a ProofCommander, a ProofQuerier, a ProofServiceClient, a Storage and an integrity config.
+func NewIntegrityScanner(
+	proofCommander repository.ProofCommander,
+	proofQuerier repository.ProofQuerier,
+	chain chainv1connect.ProofServiceClient,
+	storage storagemanage.Storage,
+	config *storagemanage.IntegrityConfig,
+) *IntegrityScanner {
+	return &IntegrityScanner{
+		proofCommand: proofCommander,
+		proofQuery:   proofQuerier,
+		chain:        chain,
+		storage:      storage,
+		config:       config,
+	}
+}
+
+// Run method is checking the integrity every config interval until the context is done, accepting a context.
+// Nothing is checked when the interval is 0.
+// A run is skipped while another instance holds the lock of the job.
+func (s *IntegrityScanner) Run(ctx context.Context) {
+	if s.config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		runLocked(ctx, s.proofCommand, integrityLockKey, func() {
+			report, err := s.Scan(ctx, time.Now())
+			if err != nil {
+				log.Printf("Failed to check evidence integrity: %v", err)
+			}
+			if report != nil {
+				log.Printf("Checked %d revisions, %d ok, %d mismatched and %d missing", report.Revisions, report.OK, report.Mismatch, report.Missing)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan method is returning an IntegrityReport and an error, accepting a context and the time of the check.
+// The latest anchored revision of every confirmed or expired proof is hashed again, and each file is compared with the hash recorded at upload,
+// the revision with the digest recorded at confirm and with the hash anchored on chain. Every result is recorded with the same check time.
+func (s *IntegrityScanner) Scan(ctx context.Context, checkedAt time.Time) (*IntegrityReport, error) {
+	revisions, err := s.proofQuery.ListAnchoredRevisions(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrIntegrityScan, err)
+	}
+
+	report := &IntegrityReport{}
+	var scanErr error
+	for _, revision := range revisions {
+		// 한 리비전을 끝까지 검사하지 못해도 나온 결과는 남기고 다음 리비전을 검사합니다.
+		checks, err := s.checkRevision(ctx, revision, checkedAt)
+		if err != nil {
+			scanErr = errors.Join(scanErr, err)
+		}
+		if len(checks) == 0 {
+			continue
+		}
+
+		err = s.proofCommand.CreateIntegrityChecks(ctx, checks, nil)
+		if err != nil {
+			scanErr = errors.Join(scanErr, err)
+			continue
+		}
+
+		report.Revisions++
+		for _, check := range checks {
+			switch check.Status {
+			case constants.IntegrityOK:
+				report.OK++
+				continue
+			case constants.IntegrityMismatch:
+				report.Mismatch++
+			case constants.IntegrityMissing:
+				report.Missing++
+			}
+			log.Printf("Integrity check of the %s of proof %d revision %d is %s", check.Target, check.ProofIdx, revision.Revision, check.Status)
+		}
+	}
+
+	if scanErr != nil {
+		return report, errors.Join(constants.ErrIntegrityScan, scanErr)
+	}
+	return report, nil
+}
+
+// checkRevision method is returning the results of an anchored revision and an error, accepting a context, a revision and the time of the check.
+// A chain record holding a digest is compared with the digest, and a legacy record with the hashes of the first and the second attachment.
+// The results checked before an error are returned with it.
+func (s *IntegrityScanner) checkRevision(ctx context.Context, revision *model.ProofRevision, checkedAt time.Time) ([]*model.IntegrityCheck, error) {
+	attachments, err := s.proofQuery.ListProofAttachments(ctx, revision.Idx)
+	if err != nil {
+		return nil, err
+	}
+
+	newCheck := func(target string, position *int32, expectedHash string) *model.IntegrityCheck {
+		return &model.IntegrityCheck{
+			ProofIdx:     revision.ProofIdx,
+			RevisionIdx:  revision.Idx,
+			Position:     position,
+			Target:       target,
+			ExpectedHash: expectedHash,
+			CheckedAt:    checkedAt,
+		}
+	}
+
+	checks := make([]*model.IntegrityCheck, 0, len(attachments)+2)
+	hashes := make([]string, len(attachments))
+	missing := false
+	for i, attachment := range attachments {
+		position := attachment.Position
+		check := newCheck(constants.IntegrityFile, &position, attachment.Hash)
+
+		hash, err := s.hashFile(ctx, attachment.Path)
+		if errors.Is(err, constants.ErrItemNotFound) {
+			check.Status = constants.IntegrityMissing
+			checks = append(checks, check)
+			missing = true
+			continue
+		} else if err != nil {
+			return checks, err
+		}
+		hashes[i] = hash
+
+		// 해시 없이 옮겨진 첨부 파일은 비교할 값이 없으므로 다이제스트로만 검사합니다.
+		if attachment.Hash == "" {
+			continue
+		}
+		check.ActualHash = &hash
+		check.Status = integrityStatus(attachment.Hash, hash)
+		checks = append(checks, check)
+	}
+
+	var digest *string
+	if !missing {
+		computed := attachmentsDigest(attachments, hashes)
+		digest = &computed
+	}
+
+	// 이 기능 이전에 확정된 리비전은 확정할 때의 다이제스트가 없으므로 체인 기록과만 비교합니다.
+	if revision.Digest != nil {
+		check := newCheck(constants.IntegrityDigest, nil, *revision.Digest)
+		check.ActualHash = digest
+		check.Status = constants.IntegrityMissing
+		if digest != nil {
+			check.Status = integrityStatus(*revision.Digest, *digest)
+		}
+		checks = append(checks, check)
+	}
+
+	res, err := s.chain.ReadLastImageHash(ctx, connect.NewRequest(&chainv1.ReadLastImageHashRequest{TokenId: *revision.TokenID}))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		return checks, err
+	}
+	if err != nil || res.Msg.FirstImageHash == "" {
+		check := newCheck(constants.IntegrityChain, nil, "")
+		check.ActualHash = digest
+		check.Status = constants.IntegrityMissing
+		return append(checks, check), nil
+	}
+
+	anchored, ok := chainmanage.ParseDigestHash(res.Msg.SecondImageHash)
+	if ok {
+		check := newCheck(constants.IntegrityChain, nil, anchored)
+		check.ActualHash = digest
+		check.Status = constants.IntegrityMissing
+		if digest != nil {
+			check.Status = integrityStatus(anchored, *digest)
+		}
+		return append(checks, check), nil
+	}
+
+	// 이전 체인 기록은 첫 번째, 두 번째 이미지의 해시이므로 위치 순서대로 첨부 파일의 해시와 비교합니다.
+	for i, hash := range []string{res.Msg.FirstImageHash, res.Msg.SecondImageHash} {
+		if hash == "" {
+			continue
+		}
+		position := int32(i + 1)
+		if i < len(attachments) {
+			position = attachments[i].Position
+		}
+		check := newCheck(constants.IntegrityChain, &position, hash)
+		check.Status = constants.IntegrityMissing
+		if i < len(attachments) && hashes[i] != "" {
+			check.ActualHash = &hashes[i]
+			check.Status = integrityStatus(hash, hashes[i])
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// hashFile method is returning a SHA-256 hex string and an error, accepting a context and a storage key.
+func (s *IntegrityScanner) hashFile(ctx context.Context, key string) (string, error) {
+	reader, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Printf("Failed to close file: %v", closeErr)
+		}
+	}()
+
+	hash, err := filemanage.ReaderToHash(reader)
+	if err != nil {
+		return "", errors.Join(constants.ErrFileRead, err)
+	}
+	return hash, nil
+}
+
+// integrityStatus function is returning the status of a check, accepting the expected hash and the actual hash.
+func integrityStatus(expectedHash string, actualHash string) string {
+	if expectedHash == actualHash {
+		return constants.IntegrityOK
+	}
+	return constants.IntegrityMismatch
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	chainv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/chain/v1"
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	chainmanage "security-proof/pkg/manage/chain"
+	filemanage "security-proof/pkg/manage/file"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// integrityAttachments is the attachments of the anchored revision checked by the test scanner.
+var integrityAttachments = []*model.ProofAttachment{
+	{Idx: 1, ProofIdx: 1, RevisionIdx: 2, Position: 1, Label: "first", Path: "sha256/first", Hash: filemanage.DataToHash([]byte("first"))},
+	{Idx: 2, ProofIdx: 1, RevisionIdx: 2, Position: 2, Label: "second", Path: "sha256/second", Hash: filemanage.DataToHash([]byte("second"))},
+}
+
+// integrityDigest is the digest of the anchored revision recorded at confirm and anchored on chain.
+var integrityDigest = attachmentsDigest(integrityAttachments, []string{integrityAttachments[0].Hash, integrityAttachments[1].Hash})
+
+// newTestScanner function is returning an IntegrityScanner over an anchored revision whose files are the given data,
+// and the checks it records. The chain answers with the given function.
+func newTestScanner(t *testing.T, files map[string]string, chain func() (*connect.Response[chainv1.ReadLastImageHashResponse], error)) (*IntegrityScanner, *[]*model.IntegrityCheck) {
+	storage := storagemanage.NewMemory()
+	for key, data := range files {
+		err := storage.Put(context.Background(), key, strings.NewReader(data), int64(len(data)))
+		assert.NoError(t, err)
+	}
+
+	query := *mockQuery
+	query.ListAnchoredRevisionsFn = func(ctx context.Context) ([]*model.ProofRevision, error) {
+		tokenID := int32(7)
+		return []*model.ProofRevision{{Idx: 2, ProofIdx: 1, Revision: 2, TokenID: &tokenID, Digest: &integrityDigest}}, nil
+	}
+	query.ListProofAttachmentsFn = func(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+		return integrityAttachments, nil
+	}
+
+	recorded := make([]*model.IntegrityCheck, 0)
+	commander := *mockCommand
+	commander.CreateIntegrityChecksFn = func(ctx context.Context, checks []*model.IntegrityCheck, tx *sql.Tx) error {
+		recorded = append(recorded, checks...)
+		return nil
+	}
+
+	chainClient := *mockChainClient
+	chainClient.ReadLastImageHashFn = func(ctx context.Context, req *connect.Request[chainv1.ReadLastImageHashRequest]) (*connect.Response[chainv1.ReadLastImageHashResponse], error) {
+		assert.Equal(t, int32(7), req.Msg.TokenId, "리비전의 체인 토큰으로 조회했습니다.")
+		return chain()
+	}
+
+	config := &storagemanage.IntegrityConfig{Interval: time.Hour}
+	return NewIntegrityScanner(&commander, &query, &chainClient, storage, config), &recorded
+}
+
+// anchoredHash function is returning a chain response anchoring the first attachment hash and the digest of the test revision.
+func anchoredHash() (*connect.Response[chainv1.ReadLastImageHashResponse], error) {
+	return connect.NewResponse(&chainv1.ReadLastImageHashResponse{
+		FirstImageHash:  integrityAttachments[0].Hash,
+		SecondImageHash: chainmanage.DigestHash(integrityDigest),
+	}), nil
+}
+
+// legacyHash function is returning a chain response anchoring the hashes of the first and the second image as records did before digests.
+func legacyHash() (*connect.Response[chainv1.ReadLastImageHashResponse], error) {
+	return connect.NewResponse(&chainv1.ReadLastImageHashResponse{
+		FirstImageHash:  integrityAttachments[0].Hash,
+		SecondImageHash: integrityAttachments[1].Hash,
+	}), nil
+}
+
+// withLegacyRevision function is returning the scanner checking a revision confirmed before digests were recorded, accepting a scanner.
+func withLegacyRevision(scanner *IntegrityScanner) *IntegrityScanner {
+	scanner.proofQuery.(*repository.MockProofQuery).ListAnchoredRevisionsFn = func(ctx context.Context) ([]*model.ProofRevision, error) {
+		tokenID := int32(7)
+		return []*model.ProofRevision{{Idx: 2, ProofIdx: 1, Revision: 2, TokenID: &tokenID}}, nil
+	}
+	return scanner
+}
+
+// integrityStatuses function is returning the status of each target, accepting checks.
+// The file targets are keyed by their position.
+func integrityStatuses(checks []*model.IntegrityCheck) map[string]string {
+	statuses := make(map[string]string, len(checks))
+	for _, check := range checks {
+		target := check.Target
+		if check.Position != nil {
+			target += string(rune('0' + *check.Position))
+		}
+		statuses[target] = check.Status
+	}
+	return statuses
+}
+
+func TestIntegrityScanner_Scan(t *testing.T) {
+	now := time.Now()
+	files := map[string]string{"sha256/first": "first", "sha256/second": "second"}
+
+	t.Run("정상 케이스", func(t *testing.T) {
+		scanner, recorded := newTestScanner(t, files, anchoredHash)
+
+		report, err := scanner.Scan(context.Background(), now)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, &IntegrityReport{Revisions: 1, OK: 4}, report, "파일, 다이제스트, 체인 기록이 모두 일치했습니다.")
+		assert.Equal(t, map[string]string{"file1": "ok", "file2": "ok", "digest": "ok", "chain": "ok"}, integrityStatuses(*recorded))
+		for _, check := range *recorded {
+			assert.Equal(t, now, check.CheckedAt, "같은 검사 시각으로 기록되었습니다.")
+		}
+	})
+
+	t.Run("변조된 파일 케이스", func(t *testing.T) {
+		scanner, recorded := newTestScanner(t, map[string]string{"sha256/first": "first", "sha256/second": "changed"}, anchoredHash)
+
+		report, err := scanner.Scan(context.Background(), now)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 3, report.Mismatch)
+		assert.Equal(t, map[string]string{"file1": "ok", "file2": "mismatch", "digest": "mismatch", "chain": "mismatch"}, integrityStatuses(*recorded))
+		assert.Equal(t, filemanage.DataToHash([]byte("changed")), *(*recorded)[1].ActualHash, "다시 계산한 해시가 기록되었습니다.")
+	})
+
+	t.Run("없어진 파일 케이스", func(t *testing.T) {
+		scanner, recorded := newTestScanner(t, map[string]string{"sha256/first": "first"}, anchoredHash)
+
+		report, err := scanner.Scan(context.Background(), now)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 3, report.Missing)
+		assert.Equal(t, map[string]string{"file1": "ok", "file2": "missing", "digest": "missing", "chain": "missing"}, integrityStatuses(*recorded))
+	})
+
+	t.Run("체인 기록이 없는 케이스", func(t *testing.T) {
+		scanner, recorded := newTestScanner(t, files, func() (*connect.Response[chainv1.ReadLastImageHashResponse], error) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("token not found"))
+		})
+
+		_, err := scanner.Scan(context.Background(), now)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "missing", integrityStatuses(*recorded)["chain"], "체인에 기록이 없는 리비전이 기록되었습니다.")
+	})
+
+	t.Run("이전 체인 기록 케이스", func(t *testing.T) {
+		scanner, recorded := newTestScanner(t, files, legacyHash)
+		scanner = withLegacyRevision(scanner)
+
+		report, err := scanner.Scan(context.Background(), now)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, &IntegrityReport{Revisions: 1, OK: 4}, report, "이전 체인 기록은 첨부 파일별 해시와 일치했습니다.")
+		assert.Equal(t, map[string]string{"file1": "ok", "file2": "ok", "chain1": "ok", "chain2": "ok"}, integrityStatuses(*recorded))
+	})
+
+	t.Run("이전 체인 기록의 변조된 파일 케이스", func(t *testing.T) {
+		scanner, recorded := newTestScanner(t, map[string]string{"sha256/first": "first", "sha256/second": "changed"}, legacyHash)
+		scanner = withLegacyRevision(scanner)
+
+		report, err := scanner.Scan(context.Background(), now)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 2, report.Mismatch)
+		assert.Equal(t, map[string]string{"file1": "ok", "file2": "mismatch", "chain1": "ok", "chain2": "mismatch"}, integrityStatuses(*recorded))
+	})
+
+	t.Run("체인에 연결할 수 없는 케이스", func(t *testing.T) {
+		scanner, recorded := newTestScanner(t, files, func() (*connect.Response[chainv1.ReadLastImageHashResponse], error) {
+			return nil, connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))
+		})
+
+		report, err := scanner.Scan(context.Background(), now)
+		assert.ErrorIs(t, err, constants.ErrIntegrityScan)
+		assert.Equal(t, 3, report.OK, "체인 외의 검사 결과는 기록되었습니다.")
+		assert.NotContains(t, integrityStatuses(*recorded), "chain", "체인 검사 결과는 없어진 것으로 기록되지 않았습니다.")
+	})
+}
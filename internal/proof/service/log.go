@@ -0,0 +1,206 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"security-proof/pkg/constants"
+)
+
+const (
+	// defaultLogLines is the number of lines of a page when no limit is given.
+	defaultLogLines = 200
+	// maxLogLines is the largest number of lines of a page.
+	maxLogLines = 1000
+	// maxLogLineSize is the largest size of a line, and a longer line is cut.
+	maxLogLineSize = 4096
+)
+
+// ProofLog struct is composed of the revision and the hash of a log, the lines of a page starting at an offset,
+// the offset of the next page, whether a next page exists, the number of lines of the whole log and whether a line of the page was cut.
+type ProofLog struct {
+	Revision   int32    `json:"revision"`
+	Hash       string   `json:"hash"`
+	Offset     int      `json:"offset"`
+	Lines      []string `json:"lines"`
+	NextOffset int      `json:"nextOffset"`
+	More       bool     `json:"more"`
+	TotalLines *int32   `json:"totalLines,omitempty"`
+	Truncated  bool     `json:"truncated"`
+}
+
+// ReadProofLog method is returning a page of the log and an error, accepting a context, a proof index, a revision, an offset, a limit and an access token.
+// The latest revision is used when the revision is 0, and the default number of lines is used when the limit is 0.
+// A log that no longer matches the hash recorded at upload is refused when the first page is read.
+func (q *ProofQuery) ReadProofLog(ctx context.Context, idx int32, revision int32, offset int, limit int, accessToken string) (*ProofLog, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadLog, err)
+	}
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadLog, err)
+	}
+	if offset < 0 || limit < 0 {
+		return nil, errors.Join(constants.ErrProofReadLog, constants.ErrProofLogRange)
+	}
+	if limit == 0 {
+		limit = defaultLogLines
+	}
+	limit = min(limit, maxLogLines)
+
+	proofRevision, err := q.readRevision(ctx, idx, revision)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadLog, err)
+	}
+
+	attachments, err := q.proofQuery.ListProofAttachments(ctx, proofRevision.Idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadLog, err)
+	}
+
+	for _, attachment := range attachments {
+		if !attachment.IsLog {
+			continue
+		}
+
+		// 첫 페이지를 읽을 때만 로그 전체를 해시하고, 다음 페이지는 주기적인 무결성 검사에 맡깁니다.
+		hash := attachment.Hash
+		if offset > 0 {
+			hash = ""
+		}
+		reader, err := q.openVerified(ctx, attachment.Path, hash)
+		if err != nil {
+			return nil, errors.Join(constants.ErrProofReadLog, err)
+		}
+		defer reader.Close()
+
+		lines, more, truncated, err := readLogLines(reader, offset, limit)
+		if err != nil {
+			return nil, errors.Join(constants.ErrProofReadLog, err)
+		}
+
+		proofLog := &ProofLog{
+			Revision:   proofRevision.Revision,
+			Hash:       attachment.Hash,
+			Offset:     offset,
+			Lines:      lines,
+			More:       more,
+			TotalLines: attachment.LineCount,
+			Truncated:  truncated,
+		}
+		if more {
+			proofLog.NextOffset = offset + len(lines)
+		}
+		return proofLog, nil
+	}
+
+	return nil, errors.Join(constants.ErrProofReadLog, constants.ErrItemNotFound)
+}
+
+// readLogLines function is returning lines, whether more lines follow, whether a line was cut and an error,
+// accepting a reader, the number of lines to skip and the number of lines to read.
+func readLogLines(r io.Reader, offset int, limit int) ([]string, bool, bool, error) {
+	reader := bufio.NewReader(r)
+	for range offset {
+		_, _, err := readLogLine(reader)
+		if errors.Is(err, io.EOF) {
+			return []string{}, false, false, nil
+		}
+		if err != nil {
+			return nil, false, false, err
+		}
+	}
+
+	lines := make([]string, 0, limit)
+	truncated := false
+	for len(lines) < limit {
+		line, cut, err := readLogLine(reader)
+		if errors.Is(err, io.EOF) {
+			return lines, false, truncated, nil
+		}
+		if err != nil {
+			return nil, false, false, err
+		}
+		lines = append(lines, line)
+		truncated = truncated || cut
+	}
+
+	_, err := reader.Peek(1)
+	if errors.Is(err, io.EOF) {
+		return lines, false, truncated, nil
+	}
+	if err != nil {
+		return nil, false, false, err
+	}
+	return lines, true, truncated, nil
+}
+
+// readLogLine function is returning a line without its line ending, whether it was cut and an error, accepting a buffered reader.
+// The error is io.EOF only when no line is left.
+func readLogLine(reader *bufio.Reader) (string, bool, error) {
+	line := make([]byte, 0)
+	cut := false
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		// 한 줄이 아주 길어도 정해진 크기까지만 메모리에 둡니다.
+		if room := maxLogLineSize - len(line); len(chunk) > room {
+			chunk, cut = chunk[:room], true
+		}
+		line = append(line, chunk...)
+
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if errors.Is(err, io.EOF) && (len(line) > 0 || cut) {
+			break
+		}
+		if err != nil {
+			return "", false, err
+		}
+		break
+	}
+
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	// 잘린 멀티바이트 문자나 깨진 인코딩은 대체 문자로 바꿔 JSON 응답이 깨지지 않게 합니다.
+	return strings.ToValidUTF8(string(line), "�"), cut, nil
+}
+
+// countLines function is returning the number of lines and an error, accepting a spooled file.
+// The file is rewound before and after counting, and a last line without a line ending is counted as well.
+func countLines(file *os.File) (*int32, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var count int32
+	last := byte('\n')
+	buffer := make([]byte, 32*1024)
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			count += int32(bytes.Count(buffer[:n], []byte("\n")))
+			last = buffer[n-1]
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if last != '\n' {
+		count++
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLogLines(t *testing.T) {
+	t.Run("긴 줄 자르기 케이스", func(t *testing.T) {
+		data := strings.Repeat("a", maxLogLineSize+10) + "\nnext\n"
+		lines, more, truncated, err := readLogLines(strings.NewReader(data), 0, 1)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, lines[0], maxLogLineSize, "정해진 크기까지만 읽었습니다.")
+		assert.True(t, truncated, "잘린 줄이 있습니다.")
+		assert.True(t, more, "다음 줄이 남아 있습니다.")
+
+		lines, _, truncated, err = readLogLines(strings.NewReader(data), 1, 1)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []string{"next"}, lines, "긴 줄을 건너뛴 다음 줄이 조회되었습니다.")
+		assert.False(t, truncated)
+	})
+
+	t.Run("깨진 인코딩 케이스", func(t *testing.T) {
+		lines, _, _, err := readLogLines(strings.NewReader("ok \xff\n"), 0, 10)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []string{"ok �"}, lines, "깨진 바이트가 대체 문자로 바뀌었습니다.")
+	})
+}
+
+func TestCountLines(t *testing.T) {
+	cases := map[string]int32{
+		"":                 0,
+		"one":              1,
+		"one\n":            1,
+		"one\ntwo":         2,
+		"one\r\ntwo\r\n\n": 3,
+	}
+	for data, expected := range cases {
+		file, err := os.Create(filepath.Join(t.TempDir(), "log"))
+		assert.NoError(t, err)
+		_, err = file.WriteString(data)
+		assert.NoError(t, err)
+
+		count, err := countLines(file)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, expected, *count, "%q의 줄 수가 계산되었습니다.", data)
+
+		position, err := file.Seek(0, 1)
+		assert.NoError(t, err)
+		assert.Zero(t, position, "파일을 처음으로 되돌렸습니다.")
+		assert.NoError(t, file.Close())
+	}
+}
@@ -12,21 +12,25 @@ import (
 	"security-proof/internal/proof/repository"
 	"security-proof/pkg/auth"
 	"security-proof/pkg/constants"
+	storagemanage "security-proof/pkg/manage/storage"
 )
 
-// ProofQuery struct is composed of a Token, a ProofQuerier and an UserServiceClient.
+// ProofQuery struct is composed of a Token, a ProofQuerier, an UserServiceClient, a Storage and a URLSigner.
 type ProofQuery struct {
 	token      *auth.Token
 	proofQuery repository.ProofQuerier
 	user       apiv1connect.UserServiceClient
+	storage    storagemanage.Storage
+	urlSigner  *auth.URLSigner
 }
 
-// NewProofQuery function is returning a ProofQuery accepting a Token, a ProofQuerier and an UserServiceClient.
-func NewProofQuery(token *auth.Token, proofQuery repository.ProofQuerier, user apiv1connect.UserServiceClient) *ProofQuery {
-	return &ProofQuery{token: token, proofQuery: proofQuery, user: user}
+// NewProofQuery function is returning a ProofQuery accepting a Token, a ProofQuerier, an UserServiceClient, a Storage and a URLSigner.
+func NewProofQuery(token *auth.Token, proofQuery repository.ProofQuerier, user apiv1connect.UserServiceClient, storage storagemanage.Storage, urlSigner *auth.URLSigner) *ProofQuery {
+	return &ProofQuery{token: token, proofQuery: proofQuery, user: user, storage: storage, urlSigner: urlSigner}
 }
 
 // ReadProof method is returning a Proof and an error, accepting a context, a reading index and an access token.
+// The assignee ID is empty for a proof without an assignee.
 func (q *ProofQuery) ReadProof(ctx context.Context, idx int32, accessToken string) (*apiv1.Proof, error) {
 	_, _, err := q.token.ValidateToken(accessToken)
 	if err != nil {
@@ -38,6 +42,12 @@ func (q *ProofQuery) ReadProof(ctx context.Context, idx int32, accessToken strin
 		return nil, errors.Join(constants.ErrProofRead, err)
 	}
 
+	result := conv.ModelToProto(proof)
+	// 담당자가 지정되지 않은 증적은 담당자 아이디를 비워 둡니다.
+	if proof.UploadedUserIdx == nil {
+		return result, nil
+	}
+
 	req := connect.NewRequest(&apiv1.ReadUserRequest{Idx: *proof.UploadedUserIdx})
 	req.Header().Set("accessToken", accessToken)
 	uploadUser, err := q.user.ReadUser(ctx, req)
@@ -46,57 +56,89 @@ func (q *ProofQuery) ReadProof(ctx context.Context, idx int32, accessToken strin
 		return nil, errors.Join(constants.ErrProofRead, err)
 	}
 
-	result := conv.ModelToProto(proof)
 	result.UploadedUserId = uploadUser.Msg.User.Id
 	return result, nil
 }
 
-// ReadFirstProofImage method is returning a first image path and an error, accepting a context, a reading index and an access token.
-func (q *ProofQuery) ReadFirstProofImage(ctx context.Context, idx int32, accessToken string) (string, error) {
-	_, _, err := q.token.ValidateToken(accessToken)
+// ReadProofAttachment method is returning an attachment and an error, accepting a context, a reading index, a revision, a position and an access token.
+// The latest revision is used when the revision is 0.
+func (q *ProofQuery) ReadProofAttachment(ctx context.Context, idx int32, revision int32, position int32, accessToken string) (*model.ProofAttachment, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
 	if err != nil {
-		return "", errors.Join(constants.ErrProofReadFirstImage, err)
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
 	}
 
-	proof, err := q.proofQuery.ReadFirstProofImage(ctx, idx)
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
 	if err != nil {
-		return "", errors.Join(constants.ErrProofReadFirstImage, err)
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
 	}
 
-	return *proof.FirstImagePath, nil
+	_, attachment, err := q.readAttachment(ctx, idx, revision, position)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofReadAttachment, err)
+	}
+
+	return attachment, nil
 }
 
-// ReadSecondProofImage method is returning a first image path and an error, accepting a context, a reading index and an access token.
-func (q *ProofQuery) ReadSecondProofImage(ctx context.Context, idx int32, accessToken string) (string, error) {
-	_, _, err := q.token.ValidateToken(accessToken)
+// ListProofAttachments method is returning attachments and an error, accepting a context, a proof index, a revision and an access token.
+// The latest revision is used when the revision is 0.
+func (q *ProofQuery) ListProofAttachments(ctx context.Context, idx int32, revision int32, accessToken string) ([]*model.ProofAttachment, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
 	if err != nil {
-		return "", errors.Join(constants.ErrProofReadSecondImage, err)
+		return nil, errors.Join(constants.ErrProofAttachmentList, err)
 	}
 
-	proof, err := q.proofQuery.ReadSecondProofImage(ctx, idx)
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
 	if err != nil {
-		return "", errors.Join(constants.ErrProofReadSecondImage, err)
+		return nil, errors.Join(constants.ErrProofAttachmentList, err)
 	}
 
-	return *proof.SecondImagePath, nil
+	proofRevision, err := q.readRevision(ctx, idx, revision)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofAttachmentList, err)
+	}
+
+	attachments, err := q.proofQuery.ListProofAttachments(ctx, proofRevision.Idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofAttachmentList, err)
+	}
+
+	return attachments, nil
+}
+
+// readRevision method is returning a revision and an error, accepting a context, a proof index and a revision.
+func (q *ProofQuery) readRevision(ctx context.Context, idx int32, revision int32) (*model.ProofRevision, error) {
+	if revision == 0 {
+		return q.proofQuery.ReadLatestProofRevision(ctx, idx)
+	}
+	return q.proofQuery.ReadProofRevision(ctx, idx, revision)
 }
 
-// ReadProofLog method is returning a proof and an error, accepting a context, a reading index and an access token.
-func (q *ProofQuery) ReadProofLog(ctx context.Context, idx int32, accessToken string) (*apiv1.Proof, error) {
+// ListedProof struct is composed of a Proof and the number of its comments.
+type ListedProof struct {
+	Proof        *apiv1.Proof
+	CommentCount int32
+}
+
+// ListProofs method is returning proofs of the active cycle and an error, accepting a context, a category and an access token.
+func (q *ProofQuery) ListProofs(ctx context.Context, category string, accessToken string) ([]*ListedProof, error) {
 	_, _, err := q.token.ValidateToken(accessToken)
 	if err != nil {
-		return nil, errors.Join(constants.ErrProofReadLog, err)
+		return nil, errors.Join(constants.ErrProofList, err)
 	}
 
-	proof, err := q.proofQuery.ReadProofLog(ctx, idx)
+	cycle, err := q.proofQuery.ReadActiveCycle(ctx)
 	if err != nil {
-		return nil, errors.Join(constants.ErrProofReadLog, err)
+		return nil, errors.Join(constants.ErrProofList, err)
 	}
-	return conv.ModelToProto(proof), nil
+
+	return q.ListCycleProofs(ctx, cycle.Idx, category, accessToken)
 }
 
-// ListProofs method is returning proofs and an error, accepting a context, a category and an access token.
-func (q *ProofQuery) ListProofs(ctx context.Context, category string, accessToken string) ([]*apiv1.Proof, error) {
+// ListCycleProofs method is returning proofs and an error, accepting a context, a cycle index, a category and an access token.
+// Each proof carries the number of its comments that are not deleted.
+func (q *ProofQuery) ListCycleProofs(ctx context.Context, cycleIdx int32, category string, accessToken string) ([]*ListedProof, error) {
 	_, _, err := q.token.ValidateToken(accessToken)
 	if err != nil {
 		return nil, errors.Join(constants.ErrProofList, err)
@@ -104,19 +146,105 @@ func (q *ProofQuery) ListProofs(ctx context.Context, category string, accessToke
 
 	var proofs []*model.Proof
 	if category == "" {
-		proofs, err = q.proofQuery.AllProofs(ctx)
+		proofs, err = q.proofQuery.AllProofs(ctx, cycleIdx)
 	} else {
-		proofs, err = q.proofQuery.SearchProofs(ctx, category)
+		proofs, err = q.proofQuery.SearchProofs(ctx, cycleIdx, category)
+	}
+
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofList, err)
 	}
 
+	counts, err := q.proofQuery.CountProofComments(ctx, cycleIdx)
 	if err != nil {
 		return nil, errors.Join(constants.ErrProofList, err)
 	}
 
-	result := make([]*apiv1.Proof, len(proofs))
+	result := make([]*ListedProof, len(proofs))
 	for i, proof := range proofs {
-		result[i] = conv.ModelToProto(proof)
+		result[i] = &ListedProof{
+			Proof:        conv.ModelToProto(proof),
+			CommentCount: counts[proof.Idx],
+		}
 	}
 
 	return result, nil
 }
+
+// ListCycles method is returning assessment cycles and an error, accepting a context and an access token.
+// The latest cycle comes first.
+func (q *ProofQuery) ListCycles(ctx context.Context, accessToken string) ([]*model.AssessmentCycle, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofCycleList, err)
+	}
+
+	cycles, err := q.proofQuery.ListCycles(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofCycleList, err)
+	}
+
+	return cycles, nil
+}
+
+// ListProofStateHistory method is returning state histories and an error, accepting a context, a proof index and an access token.
+func (q *ProofQuery) ListProofStateHistory(ctx context.Context, idx int32, accessToken string) ([]*model.ProofStateHistory, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofHistory, err)
+	}
+
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofHistory, err)
+	}
+
+	histories, err := q.proofQuery.ListProofStateHistory(ctx, idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofHistory, err)
+	}
+
+	return histories, nil
+}
+
+// ListProofRejects method is returning rejects and an error, accepting a context, a proof index and an access token.
+// The latest reject comes first so that an engineer can see why the proof was sent back.
+func (q *ProofQuery) ListProofRejects(ctx context.Context, idx int32, accessToken string) ([]*model.ProofReject, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofRejectList, err)
+	}
+
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofRejectList, err)
+	}
+
+	rejects, err := q.proofQuery.ListProofRejects(ctx, idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofRejectList, err)
+	}
+
+	return rejects, nil
+}
+
+// ListProofRevisions method is returning revisions and an error, accepting a context, a proof index and an access token.
+// The latest revision comes first.
+func (q *ProofQuery) ListProofRevisions(ctx context.Context, idx int32, accessToken string) ([]*model.ProofRevision, error) {
+	userIdx, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofRevisionList, err)
+	}
+
+	err = q.checkEvidenceAccess(ctx, idx, userIdx, role)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofRevisionList, err)
+	}
+
+	revisions, err := q.proofQuery.ListProofRevisions(ctx, idx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofRevisionList, err)
+	}
+
+	return revisions, nil
+}
@@ -2,45 +2,233 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
 
 	"security-proof/internal/db/security_proof/proof/model"
 	"security-proof/internal/proof/repository"
+	"security-proof/pkg/auth"
 	"security-proof/pkg/constants"
+	filemanage "security-proof/pkg/manage/file"
+	storagemanage "security-proof/pkg/manage/storage"
 	usermanage "security-proof/pkg/manage/user"
 )
 
 var query = newMockQuery()
 
 func TestProofQuery_ReadProof(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleAdmin)
+	assert.NoError(t, err)
 
+	t.Run("증적 조회 케이스", func(t *testing.T) {
+		proof, err := query.ReadProof(context.Background(), 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "user1", proof.UploadedUserId, "담당자 아이디가 채워졌습니다.")
+	})
+
+	t.Run("담당자가 없는 증적 조회 케이스", func(t *testing.T) {
+		querier := *mockQuery
+		querier.ReadProofFn = func(ctx context.Context, idx int32) (*model.Proof, error) {
+			return &model.Proof{Idx: idx, State: constants.StateDraft}, nil
+		}
+		unassigned := newMockQuery()
+		unassigned.proofQuery = &querier
+
+		proof, err := unassigned.ReadProof(context.Background(), 1, accessToken)
+		assert.NoError(t, err, "담당자가 없는 증적도 에러 없이 조회되었습니다.")
+		assert.Empty(t, proof.UploadedUserId, "담당자 아이디는 비어 있습니다.")
+	})
 }
 
-func TestProofQuery_ReadFirstProofImage(t *testing.T) {
+func TestProofQuery_ReadProofAttachment(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("최신 리비전 첨부 파일 조회 케이스", func(t *testing.T) {
+		attachment, err := query.ReadProofAttachment(context.Background(), 1, 0, 3, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "config", attachment.Label, "최신 리비전의 첨부 파일이 조회되었습니다.")
+	})
+
+	t.Run("이전 리비전 첨부 파일 조회 케이스", func(t *testing.T) {
+		attachment, err := query.ReadProofAttachment(context.Background(), 1, 1, 2, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "1_1_2", attachment.Path, "리비전의 첨부 파일 경로가 조회되었습니다.")
+	})
+
+	t.Run("존재하지 않는 첨부 파일 조회 케이스", func(t *testing.T) {
+		_, err := query.ReadProofAttachment(context.Background(), 1, 1, 3, accessToken)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
 
+	t.Run("존재하지 않는 리비전 조회 케이스", func(t *testing.T) {
+		_, err := query.ReadProofAttachment(context.Background(), 1, 3, 1, accessToken)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
 }
 
-func TestProofQuery_ReadSecondProofImage(t *testing.T) {
+func TestProofQuery_ListProofAttachments(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
 
+	t.Run("첨부 파일 목록 조회 케이스", func(t *testing.T) {
+		attachments, err := query.ListProofAttachments(context.Background(), 1, 0, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, attachments, 3, "최신 리비전의 첨부 파일이 모두 조회되었습니다.")
+		assert.Equal(t, int32(1), attachments[0].Position)
+	})
+
+	t.Run("담당하지 않은 엔지니어 케이스", func(t *testing.T) {
+		otherToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = query.ListProofAttachments(context.Background(), 1, 0, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 첨부 파일 목록은 조회할 수 없습니다.")
+	})
 }
 
 func TestProofQuery_ReadProofLog(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	repo := *mockQuery
+	repo.ListProofAttachmentsFn = func(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+		if revisionIdx != 2 {
+			return mockAttachments[revisionIdx], nil
+		}
+		lines := int32(3)
+		log := &model.ProofAttachment{Idx: 6, ProofIdx: 1, RevisionIdx: 2, Position: 4, Label: "log", MimeType: "text/plain; charset=utf-8", Hash: filemanage.DataToHash([]byte(mockLog)), Path: "1_2_4", IsLog: true, LineCount: &lines}
+		return append(append([]*model.ProofAttachment{}, mockAttachments[2]...), log), nil
+	}
+	logQuery := NewProofQuery(mockToken, &repo, mockUserClient, storagemanage.NewMemory(), nil)
+
+	err = logQuery.storage.Put(context.Background(), "1_2_4", strings.NewReader(mockLog), int64(len(mockLog)))
+	assert.NoError(t, err)
+
+	t.Run("첫 페이지 조회 케이스", func(t *testing.T) {
+		proofLog, err := logQuery.ReadProofLog(context.Background(), 1, 0, 0, 2, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []string{"first", "second"}, proofLog.Lines, "줄 끝 문자를 뺀 줄이 조회되었습니다.")
+		assert.True(t, proofLog.More, "다음 페이지가 있습니다.")
+		assert.Equal(t, 2, proofLog.NextOffset)
+		assert.Equal(t, int32(3), *proofLog.TotalLines, "전체 줄 수가 함께 조회되었습니다.")
+		assert.Equal(t, filemanage.DataToHash([]byte(mockLog)), proofLog.Hash)
+	})
 
+	t.Run("마지막 페이지 조회 케이스", func(t *testing.T) {
+		proofLog, err := logQuery.ReadProofLog(context.Background(), 1, 2, 2, 0, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []string{"third"}, proofLog.Lines, "줄 끝 문자가 없는 마지막 줄이 조회되었습니다.")
+		assert.False(t, proofLog.More, "다음 페이지가 없습니다.")
+		assert.Zero(t, proofLog.NextOffset)
+	})
+
+	t.Run("범위를 벗어난 페이지 조회 케이스", func(t *testing.T) {
+		proofLog, err := logQuery.ReadProofLog(context.Background(), 1, 0, 10, 0, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Empty(t, proofLog.Lines, "빈 페이지가 조회되었습니다.")
+		assert.False(t, proofLog.More)
+	})
+
+	t.Run("로그가 없는 리비전 조회 케이스", func(t *testing.T) {
+		_, err := logQuery.ReadProofLog(context.Background(), 1, 1, 0, 0, accessToken)
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
+
+	t.Run("변조된 로그 조회 케이스", func(t *testing.T) {
+		tampered := "first\nchanged\r\nthird"
+		err := logQuery.storage.Put(context.Background(), "1_2_4", strings.NewReader(tampered), int64(len(tampered)))
+		assert.NoError(t, err)
+		defer func() {
+			err := logQuery.storage.Put(context.Background(), "1_2_4", strings.NewReader(mockLog), int64(len(mockLog)))
+			assert.NoError(t, err)
+		}()
+
+		_, err = logQuery.ReadProofLog(context.Background(), 1, 0, 0, 0, accessToken)
+		assert.ErrorIs(t, err, constants.ErrFileTampered, "업로드할 때와 다른 로그는 조회되지 않았습니다.")
+	})
+
+	t.Run("음수 오프셋 조회 케이스", func(t *testing.T) {
+		_, err := logQuery.ReadProofLog(context.Background(), 1, 0, -1, 0, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofLogRange)
+	})
+
+	t.Run("담당하지 않은 엔지니어 케이스", func(t *testing.T) {
+		otherToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = logQuery.ReadProofLog(context.Background(), 1, 0, 0, 0, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 로그는 조회할 수 없습니다.")
+	})
 }
 
 func TestProofQuery_ListProofs(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("진행 중인 주기 증적 조회 케이스", func(t *testing.T) {
+		proofs, err := query.ListProofs(context.Background(), "", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, proofs, 2, "진행 중인 주기의 증적만 조회되었습니다.")
+		assert.Equal(t, int32(2), proofs[0].CommentCount, "댓글 수가 함께 조회되었습니다.")
+		assert.Zero(t, proofs[1].CommentCount, "댓글이 없는 증적은 0개로 조회되었습니다.")
+	})
 
+	t.Run("이전 주기 증적 조회 케이스", func(t *testing.T) {
+		proofs, err := query.ListCycleProofs(context.Background(), 1, "test", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Empty(t, proofs, "다른 주기의 증적은 섞이지 않았습니다.")
+	})
 }
 
 func TestProofQuery_ReadUserByIdx(t *testing.T) {
 
 }
 
+func TestProofQuery_ListProofStateHistory(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("증적 상태 이력 조회 케이스", func(t *testing.T) {
+		histories, err := query.ListProofStateHistory(context.Background(), 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, histories, 1, "상태 이력이 정상적으로 조회되었습니다.")
+		assert.Equal(t, "assigned", StateName(histories[0].ToState))
+	})
+
+	t.Run("담당하지 않은 엔지니어 케이스", func(t *testing.T) {
+		otherToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = query.ListProofStateHistory(context.Background(), 1, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 상태 이력은 조회할 수 없습니다.")
+	})
+}
+
 func newMockQuery() *ProofQuery {
-	return NewProofQuery(mockToken, mockQuery, mockUserClient)
+	used := make(map[string]bool)
+	nonceRepo := &auth.MockNonceRepo{
+		UseNonceFn: func(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+			if used[nonce] {
+				return true, nil
+			}
+			used[nonce] = true
+			return false, nil
+		},
+	}
+
+	urlSigner, err := auth.NewURLSigner(&auth.URLConfig{TTL: time.Minute}, nonceRepo)
+	if err != nil {
+		panic(err)
+	}
+	return NewProofQuery(mockToken, mockQuery, mockUserClient, storagemanage.NewMemory(), urlSigner)
 }
 
 var mockQuery = &repository.MockProofQuery{
@@ -51,28 +239,137 @@ var mockQuery = &repository.MockProofQuery{
 		if idx != 1 {
 			return nil, constants.ErrProofRead
 		}
+		num := "2.1.1"
 		return &model.Proof{
 			Idx:             1,
+			Num:             &num,
 			CreatedUserIdx:  &i,
 			UploadedUserIdx: &i,
 			UpdatedUserIdx:  &i,
 			TokenID:         &i,
 		}, nil
 	},
-	AllProofsFn: func(ctx context.Context) ([]*model.Proof, error) {
-		return nil, nil
+	AllProofsFn: func(ctx context.Context, cycleIdx int32) ([]*model.Proof, error) {
+		if cycleIdx != 2 {
+			return nil, nil
+		}
+		return []*model.Proof{{Idx: 1, Category: "test"}, {Idx: 2, Category: "other"}}, nil
 	},
-	SearchProofsFn: func(ctx context.Context, category string) ([]*model.Proof, error) {
-		return nil, nil
+	SearchProofsFn: func(ctx context.Context, cycleIdx int32, category string) ([]*model.Proof, error) {
+		if cycleIdx != 2 {
+			return nil, nil
+		}
+		return []*model.Proof{{Idx: 1, Category: category}}, nil
 	},
-	ReadFirstProofImageFn: func(ctx context.Context, idx int32) (proof *model.Proof, err error) {
-		return nil, nil
+	ListProofAttachmentsFn: func(ctx context.Context, revisionIdx int32) ([]*model.ProofAttachment, error) {
+		return mockAttachments[revisionIdx], nil
 	},
-	ReadSecondProofImageFn: func(ctx context.Context, idx int32) (proof *model.Proof, err error) {
-		return nil, nil
+	ReadProofAttachmentFn: func(ctx context.Context, revisionIdx int32, position int32) (*model.ProofAttachment, error) {
+		for _, attachment := range mockAttachments[revisionIdx] {
+			if attachment.Position == position {
+				return attachment, nil
+			}
+		}
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
 	},
-	ReadProofLogFn: func(ctx context.Context, idx int32) (*model.Proof, error) {
-		return nil, nil
+	ReadEvidenceBlobFn: func(ctx context.Context, digest string) (*model.EvidenceBlob, error) {
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	},
+	ListRetentionPoliciesFn: func(ctx context.Context) ([]*model.RetentionPolicy, error) {
+		return []*model.RetentionPolicy{}, nil
+	},
+	ListProofRejectsFn: func(ctx context.Context, proofIdx int32) ([]*model.ProofReject, error) {
+		return []*model.ProofReject{
+			{Idx: 1, ProofIdx: proofIdx, Reason: "해상도가 낮습니다.", RejectedUserIdx: 1},
+		}, nil
+	},
+	ListProofStateHistoryFn: func(ctx context.Context, proofIdx int32) ([]*model.ProofStateHistory, error) {
+		return []*model.ProofStateHistory{
+			{Idx: 1, ProofIdx: proofIdx, FromState: constants.StateDraft, ToState: constants.StateAssigned},
+		}, nil
+	},
+	ListProofRevisionsFn: func(ctx context.Context, proofIdx int32) ([]*model.ProofRevision, error) {
+		return []*model.ProofRevision{
+			{Idx: 2, ProofIdx: proofIdx, Revision: 2},
+			{Idx: 1, ProofIdx: proofIdx, Revision: 1},
+		}, nil
+	},
+	ReadProofRevisionFn: func(ctx context.Context, proofIdx int32, revision int32) (*model.ProofRevision, error) {
+		if revision != 1 && revision != 2 {
+			return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+		}
+		return &model.ProofRevision{Idx: revision, ProofIdx: proofIdx, Revision: revision}, nil
+	},
+	ReadLatestProofRevisionFn: func(ctx context.Context, proofIdx int32) (*model.ProofRevision, error) {
+		return &model.ProofRevision{Idx: 2, ProofIdx: proofIdx, Revision: 2}, nil
+	},
+	ReadActiveCycleFn: func(ctx context.Context) (*model.AssessmentCycle, error) {
+		return &model.AssessmentCycle{Idx: 2, Name: "2025"}, nil
+	},
+	ListCyclesFn: func(ctx context.Context) ([]*model.AssessmentCycle, error) {
+		return []*model.AssessmentCycle{{Idx: 2, Name: "2025"}, {Idx: 1, Name: "2024"}}, nil
+	},
+	ListControlsFn: func(ctx context.Context, framework string) ([]*model.Control, error) {
+		return mockControls, nil
+	},
+	ListUncoveredControlsFn: func(ctx context.Context, cycleIdx int32, framework string) ([]*model.Control, error) {
+		if cycleIdx != 2 {
+			return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+		}
+		return mockControls[1:], nil
+	},
+	ListProofControlsFn: func(ctx context.Context, proofIdx int32) ([]*model.Control, error) {
+		return mockControls[:1], nil
+	},
+	ListDueProofsFn: func(ctx context.Context, cycleIdx int32, before time.Time) ([]*model.Proof, error) {
+		dueAt := before.Add(-time.Hour)
+		return []*model.Proof{{Idx: 1, Category: "test", CycleIdx: cycleIdx, DueAt: &dueAt}}, nil
+	},
+	ReadProofCommentFn: func(ctx context.Context, idx int32) (*model.ProofComment, error) {
+		if idx != 1 {
+			return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+		}
+		return &model.ProofComment{Idx: 1, ProofIdx: 1, UserIdx: 1, Body: "설정 화면도 첨부해 주세요."}, nil
+	},
+	ListProofCommentsFn: func(ctx context.Context, proofIdx int32) ([]*model.ProofComment, error) {
+		return []*model.ProofComment{
+			{Idx: 1, ProofIdx: proofIdx, UserIdx: 1, Body: "설정 화면도 첨부해 주세요."},
+			{Idx: 2, ProofIdx: proofIdx, UserIdx: 3, Body: "첨부했습니다."},
+		}, nil
+	},
+	CountProofCommentsFn: func(ctx context.Context, cycleIdx int32) (map[int32]int32, error) {
+		if cycleIdx != 2 {
+			return map[int32]int32{}, nil
+		}
+		return map[int32]int32{1: 2}, nil
+	},
+}
+
+// mockControls is the control catalog of the mock repository.
+var mockControls = []*model.Control{
+	{Idx: 1, Framework: "ISMS-P", ControlID: "2.1", Title: "정책, 조직, 자산 관리"},
+	{Idx: 2, Framework: "ISMS-P", ControlID: "2.2", Title: "인적 보안"},
+}
+
+// mockThumbnailPath is the thumbnail of the first attachment of the latest mock revision.
+var mockThumbnailPath = "thumbnail/1_2_1"
+
+// mockHash is the hash of the first attachment of the latest mock revision, whose stored content is its label.
+var mockHash = filemanage.DataToHash([]byte("first"))
+
+// mockLog is the text log of the latest mock revision.
+const mockLog = "first\nsecond\r\nthird"
+
+// mockAttachments is the attachments of each mock revision index.
+var mockAttachments = map[int32][]*model.ProofAttachment{
+	1: {
+		{Idx: 1, ProofIdx: 1, RevisionIdx: 1, Position: 1, Label: "first", Path: "1_1_1"},
+		{Idx: 2, ProofIdx: 1, RevisionIdx: 1, Position: 2, Label: "second", Path: "1_1_2"},
+	},
+	2: {
+		{Idx: 3, ProofIdx: 1, RevisionIdx: 2, Position: 1, Label: "first", MimeType: "image/png", Hash: mockHash, Path: "1_2_1", ThumbnailPath: &mockThumbnailPath},
+		{Idx: 4, ProofIdx: 1, RevisionIdx: 2, Position: 2, Label: "second", Path: "1_2_2"},
+		{Idx: 5, ProofIdx: 1, RevisionIdx: 2, Position: 3, Label: "config", MimeType: "application/json", Path: "1_2_3"},
 	},
 }
 
@@ -81,4 +378,92 @@ var mockUserClient = &usermanage.MockUser{
 		fmt.Printf("Mock 유저 불러오기")
 		return nil, nil
 	},
+	ReadUserFn: func(ctx context.Context, req *connect.Request[apiv1.ReadUserRequest]) (*connect.Response[apiv1.ReadUserResponse], error) {
+		return connect.NewResponse(&apiv1.ReadUserResponse{
+			User: &apiv1.User{Idx: req.Msg.Idx, Id: fmt.Sprintf("user%d", req.Msg.Idx)},
+		}), nil
+	},
+	ListUserFn: func(ctx context.Context, req *connect.Request[apiv1.ListUserRequest]) (*connect.Response[apiv1.ListUserResponse], error) {
+		if req.Msg.Id != "engineer" {
+			return nil, connect.NewError(connect.CodeNotFound, constants.ErrItemNotFound)
+		}
+		return connect.NewResponse(&apiv1.ListUserResponse{
+			Users: []*apiv1.User{{Idx: 3, Id: "engineer"}},
+		}), nil
+	},
+}
+
+func TestProofQuery_ListProofRejects(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("증적 반려 사유 조회 케이스", func(t *testing.T) {
+		rejects, err := query.ListProofRejects(context.Background(), 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, rejects, 1, "반려 사유가 정상적으로 조회되었습니다.")
+		assert.Equal(t, "해상도가 낮습니다.", rejects[0].Reason)
+	})
+
+	t.Run("담당하지 않은 엔지니어 케이스", func(t *testing.T) {
+		otherToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = query.ListProofRejects(context.Background(), 1, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 반려 사유는 조회할 수 없습니다.")
+	})
+}
+
+func TestProofQuery_ListProofRevisions(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("증적 리비전 목록 조회 케이스", func(t *testing.T) {
+		revisions, err := query.ListProofRevisions(context.Background(), 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, revisions, 2, "리비전이 정상적으로 조회되었습니다.")
+		assert.Equal(t, int32(2), revisions[0].Revision, "최신 리비전이 먼저 조회되었습니다.")
+	})
+
+	t.Run("담당하지 않은 엔지니어 케이스", func(t *testing.T) {
+		otherToken, _, err := mockToken.CreateToken(context.Background(), "2", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = query.ListProofRevisions(context.Background(), 1, otherToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth, "담당하지 않은 증적의 리비전은 조회할 수 없습니다.")
+	})
+}
+
+func TestProofQuery_ListUncoveredControls(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("확정 증적이 없는 통제 항목 조회 케이스", func(t *testing.T) {
+		controls, err := query.ListUncoveredControls(context.Background(), "ISMS-P", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, controls, 1, "진행 중인 주기 기준으로 조회되었습니다.")
+		assert.Equal(t, "2.2", controls[0].ControlID)
+	})
+}
+
+func TestProofQuery_ListProofControls(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("증적 통제 항목 조회 케이스", func(t *testing.T) {
+		controls, err := query.ListProofControls(context.Background(), 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, controls, 1, "매핑된 통제 항목이 조회되었습니다.")
+	})
+}
+
+func TestProofQuery_ListOverdueProofs(t *testing.T) {
+	accessToken, _, err := mockToken.CreateToken(context.Background(), "1", constants.RoleEngineer)
+	assert.NoError(t, err)
+
+	t.Run("기한 초과 증적 조회 케이스", func(t *testing.T) {
+		proofs, err := query.ListOverdueProofs(context.Background(), accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, proofs, 1, "기한 초과 증적이 조회되었습니다.")
+		assert.Equal(t, int32(2), proofs[0].CycleIdx, "진행 중인 주기 기준으로 조회되었습니다.")
+	})
 }
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/convert"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/auth"
+	"security-proof/pkg/constants"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// SetRetentionPolicy method is returning a policy index and an error, accepting a context, a category, a cycle index, the days evidence is kept and an access token.
+// The policy applies to a category or a cycle, or is the default policy when both are empty, and it replaces the policy of the same scope.
+func (c *ProofCommand) SetRetentionPolicy(ctx context.Context, category string, cycleIdx int32, retentionDays int32, accessToken string) (int32, error) {
+	userIdx, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return 0, errors.Join(constants.ErrRetentionPolicy, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return 0, errors.Join(constants.ErrRetentionPolicy, constants.ErrTokenRoleAuth)
+	}
+
+	if retentionDays <= 0 {
+		return 0, errors.Join(constants.ErrRetentionPolicy, constants.ErrRetentionDays)
+	}
+
+	category = strings.TrimSpace(category)
+	if category != "" && cycleIdx != 0 {
+		return 0, errors.Join(constants.ErrRetentionPolicy, constants.ErrRetentionScope)
+	}
+
+	policy := &model.RetentionPolicy{
+		Category:       convert.StringToPString(category),
+		RetentionDays:  retentionDays,
+		CreatedUserIdx: auth.StrToInt32(userIdx),
+		CreatedAt:      time.Now(),
+	}
+	if cycleIdx != 0 {
+		policy.CycleIdx = &cycleIdx
+	}
+
+	idx, err := c.proofCommand.SetRetentionPolicy(ctx, policy, nil)
+	if err != nil {
+		return 0, errors.Join(constants.ErrRetentionPolicy, err)
+	}
+
+	return idx, nil
+}
+
+// DeleteRetentionPolicy method is returning an error, accepting a context, a policy index and an access token.
+func (c *ProofCommand) DeleteRetentionPolicy(ctx context.Context, idx int32, accessToken string) error {
+	_, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrRetentionPolicy, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return errors.Join(constants.ErrRetentionPolicy, constants.ErrTokenRoleAuth)
+	}
+
+	err = c.proofCommand.DeleteRetentionPolicy(ctx, idx, nil)
+	if err != nil {
+		return errors.Join(constants.ErrRetentionPolicy, err)
+	}
+
+	return nil
+}
+
+// SetLegalHold method is returning an error, accepting a context, a proof index, whether the proof is held, a reason and an access token.
+// A held proof can be neither deleted, updated, uploaded again nor purged, and a reason is required to hold it.
+func (c *ProofCommand) SetLegalHold(ctx context.Context, idx int32, hold bool, reason string, accessToken string) error {
+	userIdx, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrLegalHold, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return errors.Join(constants.ErrLegalHold, constants.ErrTokenRoleAuth)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if hold && reason == "" {
+		return errors.Join(constants.ErrLegalHold, constants.ErrLegalHoldReason)
+	}
+
+	readProof, err := c.proofQuery.ReadProof(ctx, idx)
+	if err != nil {
+		return errors.Join(constants.ErrLegalHold, err)
+	}
+	if readProof.PurgedAt != nil {
+		return errors.Join(constants.ErrLegalHold, constants.ErrProofPurged)
+	}
+
+	// 해제할 때도 누가 언제 해제했는지 남깁니다.
+	heldAt := time.Now()
+	heldUserIdx := auth.StrToInt32(userIdx)
+	err = c.proofCommand.UpdateProofLegalHold(ctx, &model.Proof{
+		Idx:              idx,
+		LegalHold:        hold,
+		LegalHoldReason:  convert.StringToPString(reason),
+		LegalHoldUserIdx: &heldUserIdx,
+		LegalHoldAt:      &heldAt,
+	}, nil)
+	if err != nil {
+		return errors.Join(constants.ErrLegalHold, err)
+	}
+
+	log.Printf("Legal hold of proof %d set to %t by user %s: %s", idx, hold, userIdx, reason)
+	return nil
+}
+
+// ListRetentionPolicies method is returning retention policies and an error, accepting a context and an access token.
+func (q *ProofQuery) ListRetentionPolicies(ctx context.Context, accessToken string) ([]*model.RetentionPolicy, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrRetentionPolicyList, err)
+	}
+
+	policies, err := q.proofQuery.ListRetentionPolicies(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrRetentionPolicyList, err)
+	}
+
+	return policies, nil
+}
+
+// ListPurgedEvidence method is returning the purged evidence and an error, accepting a context, the time the report starts from and an access token.
+// The hashes and chain tokens of purged attachments are kept, so that a proof can still be verified after its files are gone.
+func (q *ProofQuery) ListPurgedEvidence(ctx context.Context, purgedAfter time.Time, accessToken string) ([]*model.PurgedEvidence, error) {
+	_, role, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrRetentionReport, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return nil, errors.Join(constants.ErrRetentionReport, constants.ErrTokenRoleAuth)
+	}
+
+	evidence, err := q.proofQuery.ListPurgedEvidence(ctx, purgedAfter)
+	if err != nil {
+		return nil, errors.Join(constants.ErrRetentionReport, err)
+	}
+
+	return evidence, nil
+}
+
+// checkWritable function is returning an error, accepting a Proof.
+// A proof on legal hold or with purged evidence cannot be changed.
+func checkWritable(proof *model.Proof) error {
+	if proof.LegalHold {
+		return constants.ErrProofLegalHold
+	}
+	if proof.PurgedAt != nil {
+		return constants.ErrProofPurged
+	}
+	return nil
+}
+
+// checkRetention method is returning an error, accepting a context, a proof index and the current time.
+// Uploaded evidence cannot be deleted while the policy applying to it keeps it, which is until the retention days passed after its cycle ended.
+func (c *ProofCommand) checkRetention(ctx context.Context, idx int32, now time.Time) error {
+	policies, err := c.proofQuery.ListRetentionPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	proof, err := c.proofQuery.ReadRetainedProof(ctx, idx)
+	if err != nil {
+		return err
+	}
+
+	expiresAt, retained := retentionExpiry(policies, proof)
+	if retained && (expiresAt == nil || now.Before(*expiresAt)) {
+		return constants.ErrProofRetained
+	}
+	return nil
+}
+
+// retentionExpiry function is returning the time retained evidence expires and whether a policy applies to it, accepting policies and a RetainedProof.
+// A cycle policy takes precedence over a category policy, which takes precedence over the default policy.
+// The time is nil while the cycle of the proof is active, as the retention counts from the end of the cycle.
+func retentionExpiry(policies []*model.RetentionPolicy, proof *repository.RetainedProof) (*time.Time, bool) {
+	var cyclePolicy, categoryPolicy, defaultPolicy *model.RetentionPolicy
+	for _, policy := range policies {
+		switch {
+		case policy.CycleIdx != nil:
+			if *policy.CycleIdx == proof.CycleIdx {
+				cyclePolicy = policy
+			}
+		case policy.Category != nil:
+			if *policy.Category == proof.Category {
+				categoryPolicy = policy
+			}
+		default:
+			defaultPolicy = policy
+		}
+	}
+
+	policy := cyclePolicy
+	if policy == nil {
+		policy = categoryPolicy
+	}
+	if policy == nil {
+		policy = defaultPolicy
+	}
+	if policy == nil {
+		return nil, false
+	}
+
+	if proof.CycleEndedAt == nil {
+		return nil, true
+	}
+	expiresAt := proof.CycleEndedAt.AddDate(0, 0, int(policy.RetentionDays))
+	return &expiresAt, true
+}
+
+// PurgedProof struct is composed of the index, number and category of a purged proof, the time its retention expired
+// and the number of its attachments.
+type PurgedProof struct {
+	Idx         int32
+	Num         string
+	Category    string
+	ExpiredAt   time.Time
+	Attachments int
+}
+
+// PurgeReport struct is composed of the purged proofs, the number of expired proofs kept on legal hold,
+// and the files removed and the bytes reclaimed, which are 0 in a dry run.
+type PurgeReport struct {
+	Proofs         []*PurgedProof
+	Held           int
+	Removed        int
+	ReclaimedBytes int64
+}
+
+// RetentionPurger struct is composed of a ProofCommander, a ProofQuerier, a Storage and a retention config.
+type RetentionPurger struct {
+	proofCommand repository.ProofCommander
+	proofQuery   repository.ProofQuerier
+	storage      storagemanage.Storage
+	config       *storagemanage.RetentionConfig
+}
+
+// NewRetentionPurger function is returning a RetentionPurger, accepting a ProofCommander, a ProofQuerier, a Storage and a retention config.
+func NewRetentionPurger(
+	proofCommander repository.ProofCommander,
+	proofQuerier repository.ProofQuerier,
+	storage storagemanage.Storage,
+	config *storagemanage.RetentionConfig,
+) *RetentionPurger {
+	return &RetentionPurger{
+		proofCommand: proofCommander,
+		proofQuery:   proofQuerier,
+		storage:      storage,
+		config:       config,
+	}
+}
+
+// Run method is purging every config interval until the context is done, accepting a context.
+// Nothing is purged when the interval is 0.
+// A run is skipped while another instance holds the lock of the job.
+func (p *RetentionPurger) Run(ctx context.Context) {
+	if p.config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		runLocked(ctx, p.proofCommand, purgerLockKey, func() {
+			report, err := p.Purge(ctx, time.Now(), false)
+			if err != nil {
+				log.Printf("Failed to purge expired evidence: %v", err)
+			}
+			if report != nil {
+				log.Printf("Purged %d proofs, kept %d on legal hold and reclaimed %d bytes", len(report.Proofs), report.Held, report.ReclaimedBytes)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeLocked method is returning a PurgeReport and an error, accepting a context, the current time and whether it is a dry run.
+// It purges while holding the same lock as Run, and ErrLockHeld is returned while another instance purges.
+func (p *RetentionPurger) PurgeLocked(ctx context.Context, now time.Time, dryRun bool) (*PurgeReport, error) {
+	var report *PurgeReport
+	err := withLock(ctx, p.proofCommand, purgerLockKey, func() error {
+		var err error
+		report, err = p.Purge(ctx, now, dryRun)
+		return err
+	})
+	return report, err
+}
+
+// Purge method is returning a PurgeReport and an error, accepting a context, the current time and whether it is a dry run.
+// The attachments of expired proofs are recorded with their hashes and chain tokens and deleted, and the files no other proof shares are removed.
+// Proofs on legal hold are kept, and a dry run only reports the proofs that would be purged.
+func (p *RetentionPurger) Purge(ctx context.Context, now time.Time, dryRun bool) (*PurgeReport, error) {
+	report := &PurgeReport{Proofs: make([]*PurgedProof, 0)}
+
+	policies, err := p.proofQuery.ListRetentionPolicies(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrRetentionPurge, err)
+	}
+	if len(policies) == 0 {
+		return report, nil
+	}
+
+	proofs, err := p.proofQuery.ListRetainedProofs(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrRetentionPurge, err)
+	}
+
+	var purgeErr error
+	for _, proof := range proofs {
+		expiresAt, retained := retentionExpiry(policies, proof)
+		if !retained || expiresAt == nil || now.Before(*expiresAt) {
+			continue
+		}
+		if proof.LegalHold {
+			report.Held++
+			continue
+		}
+
+		attachments, err := p.proofQuery.ListProofEvidence(ctx, proof.Idx)
+		if err != nil {
+			purgeErr = errors.Join(purgeErr, fmt.Errorf("proof %d: %w", proof.Idx, err))
+			continue
+		}
+
+		purged := &PurgedProof{
+			Idx:         proof.Idx,
+			Num:         convert.PStringToString(proof.Num),
+			Category:    proof.Category,
+			ExpiredAt:   *expiresAt,
+			Attachments: len(attachments),
+		}
+		if dryRun {
+			report.Proofs = append(report.Proofs, purged)
+			continue
+		}
+
+		err = runInTx(ctx, p.proofCommand, func(tx *sql.Tx) error {
+			_, txErr := p.proofCommand.PurgeProofEvidence(ctx, proof.Idx, now, tx)
+			return txErr
+		})
+		// 목록을 읽은 뒤 법적 보존이 걸린 증적은 남겨둡니다.
+		if errors.Is(err, constants.ErrProofLegalHold) {
+			report.Held++
+			continue
+		}
+		// 목록을 읽은 뒤 이미 정리되었거나 삭제된 증적은 건너뜁니다.
+		if errors.Is(err, constants.ErrProofPurged) || errors.Is(err, constants.ErrItemNotFound) {
+			log.Printf("Skipped proof %d purged or deleted in the meantime", proof.Idx)
+			continue
+		}
+		if err != nil {
+			purgeErr = errors.Join(purgeErr, fmt.Errorf("proof %d: %w", proof.Idx, err))
+			continue
+		}
+		report.Proofs = append(report.Proofs, purged)
+		log.Printf("Purged %d attachments of proof %d expired at %s", len(attachments), proof.Idx, expiresAt.Format(time.RFC3339))
+
+		// 행은 이미 지워졌으므로 파일 하나를 지우지 못해도 나머지 파일은 계속 정리합니다.
+		err = p.removeFiles(ctx, attachments, report)
+		if err != nil {
+			purgeErr = errors.Join(purgeErr, fmt.Errorf("proof %d: %w", proof.Idx, err))
+		}
+	}
+
+	if purgeErr != nil {
+		return report, errors.Join(constants.ErrRetentionPurge, purgeErr)
+	}
+
+	return report, nil
+}
+
+// removeFiles method is returning an error, accepting a context, the purged attachments and a PurgeReport.
+// A blob is only removed when no attachment references it anymore, and the files of attachments stored before blobs are removed directly.
+func (p *RetentionPurger) removeFiles(ctx context.Context, attachments []*model.ProofAttachment, report *PurgeReport) error {
+	var removeErr error
+	seen := make(map[string]bool, len(attachments))
+	for _, attachment := range attachments {
+		if seen[attachment.Hash+attachment.Path] {
+			continue
+		}
+		seen[attachment.Hash+attachment.Path] = true
+
+		keys := make([]string, 0, 3)
+		size := attachment.Size
+
+		blob, err := p.proofQuery.ReadEvidenceBlob(ctx, attachment.Hash)
+		switch {
+		case err == nil:
+			keys = append(keys, blob.Path, storagemanage.ThumbnailKey(blob.Digest))
+			size = blob.Size
+		case errors.Is(err, constants.ErrItemNotFound):
+			blob = nil
+			keys = append(keys, attachment.Path)
+		default:
+			removeErr = errors.Join(removeErr, fmt.Errorf("%s: %w", attachment.Path, err))
+			continue
+		}
+		if attachment.ThumbnailPath != nil && !slices.Contains(keys, *attachment.ThumbnailPath) {
+			keys = append(keys, *attachment.ThumbnailPath)
+		}
+
+		removed, err := p.removeBlob(ctx, blob, keys)
+		if err != nil {
+			removeErr = errors.Join(removeErr, err)
+		}
+		if removed {
+			report.Removed++
+			report.ReclaimedBytes += size
+		}
+	}
+
+	return removeErr
+}
+
+// removeBlob method is returning whether the file was removed and an error, accepting a context,
+// the blob of the file, which is nil for a file stored before blobs, and the keys of the file and its thumbnails.
+// The blob is deleted in the same transaction as its file, so an upload reusing it either locks it first and keeps the file or sees it removed.
+func (p *RetentionPurger) removeBlob(ctx context.Context, blob *model.EvidenceBlob, keys []string) (bool, error) {
+	removed := false
+	var thumbnailErr error
+	err := runInTx(ctx, p.proofCommand, func(tx *sql.Tx) error {
+		if blob != nil {
+			// 다른 증적이 같은 내용을 참조하면 블롭이 지워지지 않으므로 파일도 남겨둡니다.
+			deleted, err := p.proofCommand.DeleteEvidenceBlob(ctx, blob.Digest, tx)
+			if err != nil {
+				return fmt.Errorf("%s: %w", blob.Digest, err)
+			}
+			if !deleted {
+				return nil
+			}
+		}
+
+		// 파일을 지우지 못하면 블롭을 되돌려 정리 작업이 다시 지우게 합니다.
+		err := p.storage.Delete(ctx, keys[0])
+		if err != nil {
+			return fmt.Errorf("%s: %w", keys[0], err)
+		}
+		removed = true
+
+		for _, key := range keys[1:] {
+			err = p.storage.Delete(ctx, key)
+			if err != nil {
+				thumbnailErr = errors.Join(thumbnailErr, fmt.Errorf("%s: %w", key, err))
+			}
+		}
+		return nil
+	})
+
+	return removed, errors.Join(err, thumbnailErr)
+}
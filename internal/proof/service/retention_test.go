@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "buf.build/gen/go/wanho/security-proof-api/protocolbuffers/go/api/v1"
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+func TestRetentionExpiry(t *testing.T) {
+	endedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	category := "access"
+	cycleIdx := int32(2)
+	policies := []*model.RetentionPolicy{
+		{Idx: 1, RetentionDays: 365},
+		{Idx: 2, Category: &category, RetentionDays: 30},
+		{Idx: 3, CycleIdx: &cycleIdx, RetentionDays: 10},
+	}
+
+	t.Run("심사 주기 정책 우선 케이스", func(t *testing.T) {
+		proof := &repository.RetainedProof{Proof: model.Proof{Category: category, CycleIdx: 2}, CycleEndedAt: &endedAt}
+		expiresAt, retained := retentionExpiry(policies, proof)
+		assert.True(t, retained)
+		assert.Equal(t, endedAt.AddDate(0, 0, 10), *expiresAt, "심사 주기 정책이 분류 정책보다 우선합니다.")
+	})
+
+	t.Run("분류 정책 케이스", func(t *testing.T) {
+		proof := &repository.RetainedProof{Proof: model.Proof{Category: category, CycleIdx: 1}, CycleEndedAt: &endedAt}
+		expiresAt, _ := retentionExpiry(policies, proof)
+		assert.Equal(t, endedAt.AddDate(0, 0, 30), *expiresAt)
+	})
+
+	t.Run("기본 정책 케이스", func(t *testing.T) {
+		proof := &repository.RetainedProof{Proof: model.Proof{Category: "other", CycleIdx: 1}, CycleEndedAt: &endedAt}
+		expiresAt, _ := retentionExpiry(policies, proof)
+		assert.Equal(t, endedAt.AddDate(0, 0, 365), *expiresAt)
+	})
+
+	t.Run("진행 중인 주기 케이스", func(t *testing.T) {
+		proof := &repository.RetainedProof{Proof: model.Proof{Category: "other", CycleIdx: 1}}
+		expiresAt, retained := retentionExpiry(policies, proof)
+		assert.True(t, retained)
+		assert.Nil(t, expiresAt, "주기가 끝나기 전에는 만료되지 않습니다.")
+	})
+
+	t.Run("정책 없음 케이스", func(t *testing.T) {
+		_, retained := retentionExpiry(nil, &repository.RetainedProof{CycleEndedAt: &endedAt})
+		assert.False(t, retained)
+	})
+}
+
+// newMockCommandOnHold function is returning a ProofCommand whose proof is on legal hold in the given state.
+func newMockCommandOnHold(state int32) *ProofCommand {
+	command := newMockCommandInState(state)
+	query := *command.proofQuery.(*repository.MockProofQuery)
+	readProof := query.ReadProofFn
+	query.ReadProofFn = func(ctx context.Context, idx int32) (*model.Proof, error) {
+		proof, err := readProof(ctx, idx)
+		if err != nil {
+			return nil, err
+		}
+		proof.LegalHold = true
+		return proof, nil
+	}
+	command.proofQuery = &query
+	return command
+}
+
+func TestProofCommand_SetLegalHold(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("법적 보존 설정 케이스", func(t *testing.T) {
+		var held *model.Proof
+		commander := *mockCommand
+		commander.UpdateProofLegalHoldFn = func(ctx context.Context, proof *model.Proof, tx *sql.Tx) error {
+			held = proof
+			return nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		err := command.SetLegalHold(ctx, 1, true, " 소송 대응 ", accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.True(t, held.LegalHold)
+		assert.Equal(t, "소송 대응", *held.LegalHoldReason)
+		assert.Equal(t, int32(1), *held.LegalHoldUserIdx, "보존을 설정한 사용자가 기록되었습니다.")
+	})
+
+	t.Run("사유 없는 법적 보존 실패 케이스", func(t *testing.T) {
+		err := newMockCommand().SetLegalHold(ctx, 1, true, " ", accessToken)
+		assert.ErrorIs(t, err, constants.ErrLegalHoldReason)
+	})
+
+	t.Run("엔지니어 법적 보존 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		err = newMockCommand().SetLegalHold(ctx, 1, true, "소송 대응", engineerToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth)
+	})
+
+	t.Run("법적 보존 중 삭제 실패 케이스", func(t *testing.T) {
+		err := newMockCommandOnHold(constants.StateConfirmed).DeleteProof(ctx, 1, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofLegalHold, "보존 중인 증적은 삭제할 수 없습니다.")
+	})
+
+	t.Run("법적 보존 중 수정 실패 케이스", func(t *testing.T) {
+		_, err := newMockCommandOnHold(constants.StateConfirmed).UpdateProof(ctx, &apiv1.Proof{Idx: 1}, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofLegalHold, "보존 중인 증적은 덮어쓸 수 없습니다.")
+	})
+
+	t.Run("법적 보존 중 업로드 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		_, err = newMockCommandOnHold(constants.StateAssigned).UploadProof(ctx, 1, []byte("first"), nil, engineerToken)
+		assert.ErrorIs(t, err, constants.ErrProofLegalHold)
+	})
+}
+
+func TestProofCommand_DeleteProofRetained(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	// 올린 증적이 있고 모든 증적을 30일 보관하는 정책이 있는 ProofCommand를 만듭니다.
+	newCommand := func(endedAt *time.Time) *ProofCommand {
+		command := newMockCommand()
+		query := *mockQuery
+		query.ReadProofFn = func(ctx context.Context, idx int32) (*model.Proof, error) {
+			proof, err := mockQuery.ReadProof(ctx, idx)
+			if err != nil {
+				return nil, err
+			}
+			uploadedAt := time.Now()
+			proof.UploadedAt = &uploadedAt
+			return proof, nil
+		}
+		query.ListRetentionPoliciesFn = func(ctx context.Context) ([]*model.RetentionPolicy, error) {
+			return []*model.RetentionPolicy{{Idx: 1, RetentionDays: 30}}, nil
+		}
+		query.ReadRetainedProofFn = func(ctx context.Context, idx int32) (*repository.RetainedProof, error) {
+			return &repository.RetainedProof{Proof: model.Proof{Idx: idx, CycleIdx: 1}, CycleEndedAt: endedAt}, nil
+		}
+		command.proofQuery = &query
+		return command
+	}
+
+	t.Run("보관 기간 중 삭제 실패 케이스", func(t *testing.T) {
+		endedAt := time.Now().AddDate(0, 0, -1)
+		err := newCommand(&endedAt).DeleteProof(ctx, 1, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofRetained, "보관 기간이 지나지 않은 증적은 삭제할 수 없습니다.")
+	})
+
+	t.Run("진행 중인 주기 삭제 실패 케이스", func(t *testing.T) {
+		err := newCommand(nil).DeleteProof(ctx, 1, accessToken)
+		assert.ErrorIs(t, err, constants.ErrProofRetained)
+	})
+
+	t.Run("보관 기간이 지난 증적 삭제 케이스", func(t *testing.T) {
+		endedAt := time.Now().AddDate(0, 0, -31)
+		err := newCommand(&endedAt).DeleteProof(ctx, 1, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+	})
+}
+
+func TestProofCommand_SetRetentionPolicy(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("분류 정책 설정 케이스", func(t *testing.T) {
+		var set *model.RetentionPolicy
+		commander := *mockCommand
+		commander.SetRetentionPolicyFn = func(ctx context.Context, policy *model.RetentionPolicy, tx *sql.Tx) (int32, error) {
+			set = policy
+			return 3, nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		idx, err := command.SetRetentionPolicy(ctx, "access", 0, 1825, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, int32(3), idx)
+		assert.Equal(t, "access", *set.Category)
+		assert.Nil(t, set.CycleIdx)
+		assert.Equal(t, int32(1825), set.RetentionDays)
+	})
+
+	t.Run("보관 기간 오류 케이스", func(t *testing.T) {
+		_, err := newMockCommand().SetRetentionPolicy(ctx, "", 0, 0, accessToken)
+		assert.ErrorIs(t, err, constants.ErrRetentionDays)
+	})
+
+	t.Run("분류와 주기를 함께 지정한 오류 케이스", func(t *testing.T) {
+		_, err := newMockCommand().SetRetentionPolicy(ctx, "access", 1, 30, accessToken)
+		assert.ErrorIs(t, err, constants.ErrRetentionScope)
+	})
+}
+
+// newTestPurger function is returning a RetentionPurger over an expired proof, a proof on legal hold and a proof still retained,
+// whose attachments are a blob shared with another proof, a blob of its own and a file stored before blobs.
+func newTestPurger(t *testing.T, now time.Time) (*RetentionPurger, storagemanage.Storage, *[]int32) {
+	storage := storagemanage.NewMemory()
+	files := map[string]string{
+		"sha256/aa/aaaa":    "shared",
+		"sha256/bb/bbbb":    "own",
+		"thumbnail/bb/bbbb": "thumbnail",
+		"1_1700000000_3":    "legacy",
+	}
+	for key, data := range files {
+		err := storage.Put(context.Background(), key, strings.NewReader(data), int64(len(data)))
+		assert.NoError(t, err)
+	}
+
+	expired := now.AddDate(0, 0, -31)
+	recent := now.AddDate(0, 0, -1)
+
+	query := *mockQuery
+	query.ListRetentionPoliciesFn = func(ctx context.Context) ([]*model.RetentionPolicy, error) {
+		return []*model.RetentionPolicy{{Idx: 1, RetentionDays: 30}}, nil
+	}
+	query.ListRetainedProofsFn = func(ctx context.Context) ([]*repository.RetainedProof, error) {
+		return []*repository.RetainedProof{
+			{Proof: model.Proof{Idx: 1, Category: "access", CycleIdx: 1}, CycleEndedAt: &expired},
+			{Proof: model.Proof{Idx: 2, CycleIdx: 1, LegalHold: true}, CycleEndedAt: &expired},
+			{Proof: model.Proof{Idx: 3, CycleIdx: 2}, CycleEndedAt: &recent},
+		}, nil
+	}
+	query.ListProofEvidenceFn = func(ctx context.Context, proofIdx int32) ([]*model.ProofAttachment, error) {
+		thumbnail := "thumbnail/bb/bbbb"
+		return []*model.ProofAttachment{
+			{Idx: 1, ProofIdx: proofIdx, Position: 1, Path: "sha256/aa/aaaa", Hash: "aaaa", Size: 6},
+			{Idx: 2, ProofIdx: proofIdx, Position: 2, Path: "sha256/bb/bbbb", Hash: "bbbb", Size: 3, ThumbnailPath: &thumbnail},
+			{Idx: 3, ProofIdx: proofIdx, Position: 3, Path: "1_1700000000_3", Hash: "cccc", Size: 6},
+			{Idx: 4, ProofIdx: proofIdx, Position: 1, Path: "sha256/bb/bbbb", Hash: "bbbb", Size: 3, ThumbnailPath: &thumbnail},
+		}, nil
+	}
+	query.ReadEvidenceBlobFn = func(ctx context.Context, digest string) (*model.EvidenceBlob, error) {
+		switch digest {
+		case "aaaa", "bbbb":
+			return &model.EvidenceBlob{Digest: digest, Path: "sha256/" + digest[:2] + "/" + digest, Size: int64(len(files["sha256/"+digest[:2]+"/"+digest]))}, nil
+		}
+		return nil, errors.Join(constants.ErrQuery, constants.ErrItemNotFound)
+	}
+
+	purged := make([]int32, 0)
+	commander := *mockCommand
+	commander.PurgeProofEvidenceFn = func(ctx context.Context, proofIdx int32, purgedAt time.Time, tx *sql.Tx) (int64, error) {
+		purged = append(purged, proofIdx)
+		return 4, nil
+	}
+	commander.DeleteEvidenceBlobFn = func(ctx context.Context, digest string, tx *sql.Tx) (bool, error) {
+		// 다른 증적이 함께 참조하는 블롭은 지워지지 않습니다.
+		return digest != "aaaa", nil
+	}
+
+	config := &storagemanage.RetentionConfig{Interval: time.Hour}
+	return NewRetentionPurger(&commander, &query, storage, config), storage, &purged
+}
+
+func TestRetentionPurger_Purge(t *testing.T) {
+	now := time.Now()
+
+	t.Run("드라이런 케이스", func(t *testing.T) {
+		purger, storage, purged := newTestPurger(t, now)
+
+		report, err := purger.Purge(context.Background(), now, true)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, report.Proofs, 1, "보관 기간이 지난 증적만 보고되었습니다.")
+		assert.Equal(t, int32(1), report.Proofs[0].Idx)
+		assert.Equal(t, 4, report.Proofs[0].Attachments)
+		assert.Equal(t, 1, report.Held, "법적 보존 중인 증적은 남겨둡니다.")
+		assert.Empty(t, *purged, "드라이런에서는 정리하지 않습니다.")
+
+		_, err = storage.Stat(context.Background(), "sha256/bb/bbbb")
+		assert.NoError(t, err, "드라이런에서는 파일이 남아 있습니다.")
+	})
+
+	t.Run("정리 케이스", func(t *testing.T) {
+		purger, storage, purged := newTestPurger(t, now)
+
+		report, err := purger.Purge(context.Background(), now, false)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, []int32{1}, *purged, "법적 보존 중이거나 보관 기간이 남은 증적은 정리하지 않습니다.")
+		assert.Equal(t, 1, report.Held)
+		assert.Equal(t, 2, report.Removed, "공유되지 않은 블롭과 블롭 이전 파일이 지워졌습니다.")
+		assert.Equal(t, int64(len("own")+len("legacy")), report.ReclaimedBytes)
+
+		for _, key := range []string{"sha256/bb/bbbb", "thumbnail/bb/bbbb", "1_1700000000_3"} {
+			_, err = storage.Stat(context.Background(), key)
+			assert.ErrorIs(t, err, constants.ErrItemNotFound, key)
+		}
+		_, err = storage.Stat(context.Background(), "sha256/aa/aaaa")
+		assert.NoError(t, err, "다른 증적이 참조하는 파일은 남아 있습니다.")
+	})
+
+	t.Run("블롭과 파일을 함께 정리하는 케이스", func(t *testing.T) {
+		purger, storage, _ := newTestPurger(t, now)
+		commander := purger.proofCommand.(*repository.MockProofCommand)
+		inTx := false
+		commander.BeginFn = func(ctx context.Context) (*sql.Tx, error) {
+			inTx = true
+			return nil, nil
+		}
+		commander.CommitFn = func(ctx context.Context, tx *sql.Tx) error {
+			inTx = false
+			return nil
+		}
+		commander.DeleteEvidenceBlobFn = func(ctx context.Context, digest string, tx *sql.Tx) (bool, error) {
+			assert.True(t, inTx, "업로드가 블롭을 다시 쓰지 못하도록 파일을 지우는 트랜잭션 안에서 블롭을 지웁니다.")
+			_, err := storage.Stat(ctx, "sha256/"+digest[:2]+"/"+digest)
+			assert.NoError(t, err, "블롭을 지운 뒤에 파일을 지웁니다.")
+			return digest != "aaaa", nil
+		}
+
+		_, err := purger.Purge(context.Background(), now, false)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.False(t, inTx, "트랜잭션이 끝났습니다.")
+	})
+
+	t.Run("정리 중 법적 보존 케이스", func(t *testing.T) {
+		purger, storage, _ := newTestPurger(t, now)
+		purger.proofCommand.(*repository.MockProofCommand).PurgeProofEvidenceFn = func(ctx context.Context, proofIdx int32, purgedAt time.Time, tx *sql.Tx) (int64, error) {
+			return 0, constants.ErrProofLegalHold
+		}
+
+		report, err := purger.Purge(context.Background(), now, false)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Empty(t, report.Proofs)
+		assert.Equal(t, 2, report.Held, "목록을 읽은 뒤 보존된 증적도 남겨둡니다.")
+
+		_, err = storage.Stat(context.Background(), "sha256/bb/bbbb")
+		assert.NoError(t, err)
+	})
+
+	t.Run("정리 중 이미 정리된 증적 케이스", func(t *testing.T) {
+		purger, storage, _ := newTestPurger(t, now)
+		purger.proofCommand.(*repository.MockProofCommand).PurgeProofEvidenceFn = func(ctx context.Context, proofIdx int32, purgedAt time.Time, tx *sql.Tx) (int64, error) {
+			if proofIdx == 1 {
+				return 0, constants.ErrProofPurged
+			}
+			return 0, constants.ErrItemNotFound
+		}
+
+		report, err := purger.Purge(context.Background(), now, false)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Empty(t, report.Proofs)
+		assert.Equal(t, 1, report.Held, "이미 정리되었거나 삭제된 증적은 법적 보존으로 세지 않습니다.")
+
+		_, err = storage.Stat(context.Background(), "sha256/bb/bbbb")
+		assert.NoError(t, err)
+	})
+
+	t.Run("정책 없음 케이스", func(t *testing.T) {
+		purger, _, _ := newTestPurger(t, now)
+		purger.proofQuery.(*repository.MockProofQuery).ListRetentionPoliciesFn = func(ctx context.Context) ([]*model.RetentionPolicy, error) {
+			return nil, nil
+		}
+
+		report, err := purger.Purge(context.Background(), now, false)
+		assert.NoError(t, err)
+		assert.Empty(t, report.Proofs, "정책이 없으면 아무것도 정리하지 않습니다.")
+	})
+}
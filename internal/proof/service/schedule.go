@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	notifymanage "security-proof/pkg/manage/notify"
+)
+
+// SetProofDueDate method is returning an error, accepting a context, a proof index, a due date and an access token.
+// The due date is cleared when it is nil.
+func (c *ProofCommand) SetProofDueDate(ctx context.Context, idx int32, dueAt *time.Time, accessToken string) error {
+	_, role, err := c.token.ValidateToken(accessToken)
+	if err != nil {
+		return errors.Join(constants.ErrProofDueDate, err)
+	}
+
+	if role != constants.RoleAdmin {
+		return errors.Join(constants.ErrProofDueDate, constants.ErrTokenRoleAuth)
+	}
+
+	err = c.proofCommand.UpdateProofDueAt(ctx, idx, dueAt, nil)
+	if err != nil {
+		return errors.Join(constants.ErrProofDueDate, err)
+	}
+
+	return nil
+}
+
+// ListOverdueProofs method is returning proofs and an error, accepting a context and an access token.
+// A proof is overdue when it is not confirmed by its due date in the active cycle.
+func (q *ProofQuery) ListOverdueProofs(ctx context.Context, accessToken string) ([]*model.Proof, error) {
+	_, _, err := q.token.ValidateToken(accessToken)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofOverdueList, err)
+	}
+
+	cycle, err := q.proofQuery.ReadActiveCycle(ctx)
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofOverdueList, err)
+	}
+
+	proofs, err := q.proofQuery.ListDueProofs(ctx, cycle.Idx, time.Now())
+	if err != nil {
+		return nil, errors.Join(constants.ErrProofOverdueList, err)
+	}
+
+	return proofs, nil
+}
+
+// ReminderScheduler struct is composed of a ProofCommander, a ProofQuerier, a Notifier and a reminder config.
+type ReminderScheduler struct {
+	proofCommand repository.ProofCommander
+	proofQuery   repository.ProofQuerier
+	notifier     notifymanage.Notifier
+	config       *notifymanage.Config
+}
+
+// NewReminderScheduler function is returning a ReminderScheduler, accepting a ProofCommander, a ProofQuerier, a Notifier and a reminder config.
+func NewReminderScheduler(
+	proofCommander repository.ProofCommander,
+	proofQuerier repository.ProofQuerier,
+	notifier notifymanage.Notifier,
+	config *notifymanage.Config,
+) *ReminderScheduler {
+	return &ReminderScheduler{
+		proofCommand: proofCommander,
+		proofQuery:   proofQuerier,
+		notifier:     notifier,
+		config:       config,
+	}
+}
+
+// Run method is reminding every config interval until the context is done, accepting a context.
+// A run is skipped while another instance holds the lock of the job.
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		runLocked(ctx, s.proofCommand, reminderLockKey, func() {
+			_, err := s.Remind(ctx, time.Now())
+			if err != nil {
+				log.Printf("Failed to remind proofs: %v", err)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Remind method is returning the number of sent reminders and an error, accepting a context and the current time.
+// A proof of the active cycle due within the due soon window is reminded at most once every repeat interval.
+func (s *ReminderScheduler) Remind(ctx context.Context, now time.Time) (int, error) {
+	cycle, err := s.proofQuery.ReadActiveCycle(ctx)
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofRemind, err)
+	}
+
+	proofs, err := s.proofQuery.ListDueProofs(ctx, cycle.Idx, now.Add(s.config.DueSoon))
+	if err != nil {
+		return 0, errors.Join(constants.ErrProofRemind, err)
+	}
+
+	reminded := make([]int32, 0, len(proofs))
+	var notifyErr error
+	for _, proof := range proofs {
+		if proof.RemindedAt != nil && now.Sub(*proof.RemindedAt) < s.config.Repeat {
+			continue
+		}
+
+		// 한 담당자에게 알림이 실패해도 나머지 증적은 계속 알립니다.
+		err = s.notifier.Notify(ctx, toReminder(proof, now))
+		if err != nil {
+			notifyErr = errors.Join(notifyErr, err)
+			continue
+		}
+		reminded = append(reminded, proof.Idx)
+	}
+
+	err = s.proofCommand.MarkProofsReminded(ctx, reminded, now, nil)
+	if err != nil {
+		return len(reminded), errors.Join(constants.ErrProofRemind, err)
+	}
+
+	if notifyErr != nil {
+		return len(reminded), errors.Join(constants.ErrProofRemind, notifyErr)
+	}
+
+	return len(reminded), nil
+}
+
+// toReminder function is returning a Reminder, accepting a proof with a due date and the current time.
+func toReminder(proof *model.Proof, now time.Time) *notifymanage.Reminder {
+	reminder := &notifymanage.Reminder{
+		ProofIdx: proof.Idx,
+		Category: proof.Category,
+		DueAt:    *proof.DueAt,
+		Overdue:  proof.DueAt.Before(now),
+	}
+	if proof.Num != nil {
+		reminder.Num = *proof.Num
+	}
+	if proof.UploadedUserIdx != nil {
+		reminder.AssigneeIdx = *proof.UploadedUserIdx
+	}
+	return reminder
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/constants"
+	notifymanage "security-proof/pkg/manage/notify"
+)
+
+func TestReminderScheduler_Remind(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	config := &notifymanage.Config{Interval: time.Hour, DueSoon: 72 * time.Hour, Repeat: 24 * time.Hour}
+
+	num := "1.1.1"
+	assignee := int32(3)
+	overdueAt := now.Add(-time.Hour)
+	dueSoonAt := now.Add(48 * time.Hour)
+	remindedAt := now.Add(-time.Hour)
+
+	query := *mockQuery
+	query.ListDueProofsFn = func(ctx context.Context, cycleIdx int32, before time.Time) ([]*model.Proof, error) {
+		assert.Equal(t, int32(2), cycleIdx, "진행 중인 주기에서 조회되었습니다.")
+		assert.Equal(t, now.Add(config.DueSoon), before, "임박 기간까지 조회되었습니다.")
+		return []*model.Proof{
+			{Idx: 1, Num: &num, Category: "test", UploadedUserIdx: &assignee, DueAt: &overdueAt},
+			{Idx: 2, Category: "test", DueAt: &dueSoonAt},
+			{Idx: 3, Category: "test", DueAt: &dueSoonAt, RemindedAt: &remindedAt},
+		}, nil
+	}
+
+	var marked []int32
+	commander := *mockCommand
+	commander.MarkProofsRemindedFn = func(ctx context.Context, idxs []int32, remindedAt time.Time, tx *sql.Tx) error {
+		marked = idxs
+		return nil
+	}
+
+	t.Run("기한 임박 및 초과 증적 알림 케이스", func(t *testing.T) {
+		notifier := notifymanage.NewMemoryNotifier()
+		scheduler := NewReminderScheduler(&commander, &query, notifier, config)
+
+		count, err := scheduler.Remind(context.Background(), now)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 2, count, "최근에 알린 증적은 제외되었습니다.")
+		assert.Equal(t, []int32{1, 2}, marked, "알림 시각이 기록되었습니다.")
+
+		reminders := notifier.Reminders()
+		assert.Len(t, reminders, 2)
+		assert.True(t, reminders[0].Overdue, "기한이 지난 증적입니다.")
+		assert.Equal(t, "1.1.1", reminders[0].Num)
+		assert.Equal(t, int32(3), reminders[0].AssigneeIdx)
+		assert.False(t, reminders[1].Overdue, "기한이 임박한 증적입니다.")
+	})
+
+	t.Run("진행 중인 주기 없음 케이스", func(t *testing.T) {
+		noCycle := query
+		noCycle.ReadActiveCycleFn = func(ctx context.Context) (*model.AssessmentCycle, error) {
+			return nil, constants.ErrItemNotFound
+		}
+		scheduler := NewReminderScheduler(&commander, &noCycle, notifymanage.NewMemoryNotifier(), config)
+
+		_, err := scheduler.Remind(context.Background(), now)
+		assert.ErrorIs(t, err, constants.ErrProofRemind)
+	})
+}
+
+func TestProofCommand_SetProofDueDate(t *testing.T) {
+	defer cancel()
+
+	accessToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleAdmin)
+	assert.NoError(t, err)
+
+	t.Run("제출 기한 설정 케이스", func(t *testing.T) {
+		var updated *time.Time
+		commander := *mockCommand
+		commander.UpdateProofDueAtFn = func(ctx context.Context, idx int32, dueAt *time.Time, tx *sql.Tx) error {
+			updated = dueAt
+			return nil
+		}
+		command := newMockCommand()
+		command.proofCommand = &commander
+
+		dueAt := time.Now().Add(24 * time.Hour)
+		err := command.SetProofDueDate(ctx, 1, &dueAt, accessToken)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, &dueAt, updated)
+	})
+
+	t.Run("엔지니어 제출 기한 설정 실패 케이스", func(t *testing.T) {
+		engineerToken, _, err := mockToken.CreateToken(ctx, "1", constants.RoleEngineer)
+		assert.NoError(t, err)
+
+		err = newMockCommand().SetProofDueDate(ctx, 1, nil, engineerToken)
+		assert.ErrorIs(t, err, constants.ErrTokenRoleAuth)
+	})
+}
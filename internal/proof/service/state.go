@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+)
+
+// Defines the advisory lock keys of the background jobs, so every instance runs each job one at a time.
+const (
+	reminderLockKey int64 = iota + 7001
+	collectorLockKey
+	purgerLockKey
+	integrityLockKey
+)
+
+// transitions is defining the states that each proof state can move to.
+var transitions = map[int32][]int32{
+	constants.StateDraft:     {constants.StateAssigned},
+	constants.StateAssigned:  {constants.StateUploaded},
+	constants.StateUploaded:  {constants.StateUploaded, constants.StateInReview},
+	constants.StateInReview:  {constants.StateRejected, constants.StateConfirmed},
+	constants.StateRejected:  {constants.StateUploaded},
+	constants.StateConfirmed: {constants.StateExpired, constants.StateReopened},
+	constants.StateExpired:   {constants.StateReopened},
+	constants.StateReopened:  {constants.StateUploaded},
+}
+
+var stateNames = map[int32]string{
+	constants.StateDraft:     "draft",
+	constants.StateAssigned:  "assigned",
+	constants.StateUploaded:  "uploaded",
+	constants.StateInReview:  "in_review",
+	constants.StateRejected:  "rejected",
+	constants.StateConfirmed: "confirmed",
+	constants.StateExpired:   "expired",
+	constants.StateReopened:  "reopened",
+}
+
+// StateName function is returning a state name, accepting a proof state.
+func StateName(state int32) string {
+	name, ok := stateNames[state]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// TransitionError struct is composed of a proof index, a from state and a to state of a rejected transition.
+type TransitionError struct {
+	Idx  int32
+	From int32
+	To   int32
+}
+
+// Error method is returning an error message.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("proof %d cannot move from %s to %s", e.Idx, StateName(e.From), StateName(e.To))
+}
+
+// Unwrap method is returning ErrProofTransition so that callers can match it with errors.Is.
+func (e *TransitionError) Unwrap() error {
+	return constants.ErrProofTransition
+}
+
+// checkTransition function is returning an error, accepting a proof and a target state.
+func checkTransition(proof *model.Proof, to int32) error {
+	for _, next := range transitions[proof.State] {
+		if next == to {
+			return nil
+		}
+	}
+	return &TransitionError{Idx: proof.Idx, From: proof.State, To: to}
+}
+
+// checkConfirm function is returning an error, accepting a proof.
+// An uploaded proof can be confirmed through the review state, so clients confirming without starting a review keep working.
+func checkConfirm(proof *model.Proof) error {
+	if proof.State == constants.StateUploaded {
+		return checkTransition(proof, constants.StateInReview)
+	}
+	return checkTransition(proof, constants.StateConfirmed)
+}
+
+// confirmProofState method is returning an error, accepting a context, a proof, a user index and a transaction.
+// An uploaded proof is moved to the review state first in the same transaction, so both transitions are kept in the history.
+func (c *ProofCommand) confirmProofState(ctx context.Context, proof *model.Proof, userIdx int32, tx *sql.Tx) error {
+	if proof.State == constants.StateUploaded {
+		err := c.transitProof(ctx, proof, constants.StateInReview, userIdx, tx)
+		if err != nil {
+			return err
+		}
+	}
+	return c.transitProof(ctx, proof, constants.StateConfirmed, userIdx, tx)
+}
+
+// transitProof method is returning an error, accepting a context, a proof, a target state, a user index and a transaction.
+// The proof state is updated in place after the transition is persisted.
+func (c *ProofCommand) transitProof(ctx context.Context, proof *model.Proof, to int32, userIdx int32, tx *sql.Tx) error {
+	err := checkTransition(proof, to)
+	if err != nil {
+		return err
+	}
+
+	err = c.proofCommand.TransitProofState(ctx, &model.ProofStateHistory{
+		ProofIdx:  proof.Idx,
+		FromState: proof.State,
+		ToState:   to,
+		UserIdx:   userIdx,
+		CreatedAt: time.Now(),
+	}, tx)
+	if err != nil {
+		return err
+	}
+
+	proof.State = to
+	return nil
+}
+
+// withTx method is returning an error, accepting a context and a function running inside a transaction.
+func (c *ProofCommand) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return runInTx(ctx, c.proofCommand, fn)
+}
+
+// runInTx function is returning an error, accepting a context, a ProofCommander and a function running inside a transaction.
+func runInTx(ctx context.Context, proofCommand repository.ProofCommander, fn func(tx *sql.Tx) error) error {
+	tx, err := proofCommand.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		return errors.Join(err, proofCommand.Rollback(ctx, tx))
+	}
+
+	return proofCommand.Commit(ctx, tx)
+}
+
+// runLocked function is running a function while holding the lock of the key, accepting a context, a ProofCommander, a lock key and a function.
+// The run is skipped when another instance holds the lock, and the job is tried again at its next interval.
+func runLocked(ctx context.Context, proofCommand repository.ProofCommander, key int64, fn func()) {
+	err := withLock(ctx, proofCommand, key, func() error {
+		fn()
+		return nil
+	})
+	if err != nil && !errors.Is(err, constants.ErrLockHeld) {
+		log.Printf("Failed to take lock %d: %v", key, err)
+	}
+}
+
+// withLock function is returning an error, accepting a context, a ProofCommander, a lock key and a function.
+// ErrLockHeld is returned without running the function when another instance holds the lock.
+func withLock(ctx context.Context, proofCommand repository.ProofCommander, key int64, fn func() error) error {
+	unlock, locked, err := proofCommand.TryLock(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return constants.ErrLockHeld
+	}
+	defer unlock()
+
+	return fn()
+}
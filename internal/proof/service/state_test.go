@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+)
+
+func TestCheckTransition(t *testing.T) {
+	t.Run("허용된 상태 전이 케이스", func(t *testing.T) {
+		allowed := [][2]int32{
+			{constants.StateDraft, constants.StateAssigned},
+			{constants.StateAssigned, constants.StateUploaded},
+			{constants.StateUploaded, constants.StateInReview},
+			{constants.StateInReview, constants.StateRejected},
+			{constants.StateRejected, constants.StateUploaded},
+			{constants.StateInReview, constants.StateConfirmed},
+			{constants.StateConfirmed, constants.StateExpired},
+			{constants.StateExpired, constants.StateReopened},
+			{constants.StateReopened, constants.StateUploaded},
+		}
+		for _, transition := range allowed {
+			err := checkTransition(&model.Proof{Idx: 1, State: transition[0]}, transition[1])
+			assert.NoError(t, err, "%s -> %s 전이가 허용되었습니다.", StateName(transition[0]), StateName(transition[1]))
+		}
+	})
+
+	t.Run("허용되지 않은 상태 전이 케이스", func(t *testing.T) {
+		err := checkTransition(&model.Proof{Idx: 1, State: constants.StateDraft}, constants.StateConfirmed)
+
+		var transitionErr *TransitionError
+		assert.True(t, errors.As(err, &transitionErr), "TransitionError 타입이 반환되었습니다.")
+		assert.ErrorIs(t, err, constants.ErrProofTransition)
+		assert.Equal(t, "proof 1 cannot move from draft to confirmed", err.Error())
+	})
+}
+
+func TestRunLocked(t *testing.T) {
+	t.Run("잠금을 얻은 케이스", func(t *testing.T) {
+		ran, unlocked := false, false
+		commander := &repository.MockProofCommand{
+			TryLockFn: func(ctx context.Context, key int64) (func(), bool, error) {
+				return func() { unlocked = true }, true, nil
+			},
+		}
+
+		runLocked(context.Background(), commander, collectorLockKey, func() { ran = true })
+		assert.True(t, ran, "잠금을 얻은 인스턴스가 작업을 실행했습니다.")
+		assert.True(t, unlocked, "작업이 끝나면 잠금을 풀었습니다.")
+	})
+
+	t.Run("다른 인스턴스가 잠금을 가진 케이스", func(t *testing.T) {
+		ran := false
+		commander := &repository.MockProofCommand{
+			TryLockFn: func(ctx context.Context, key int64) (func(), bool, error) {
+				return nil, false, nil
+			},
+		}
+
+		runLocked(context.Background(), commander, collectorLockKey, func() { ran = true })
+		assert.False(t, ran, "다른 인스턴스가 실행 중인 작업은 건너뛰었습니다.")
+	})
+
+	t.Run("잠금 에러 케이스", func(t *testing.T) {
+		ran := false
+		commander := &repository.MockProofCommand{
+			TryLockFn: func(ctx context.Context, key int64) (func(), bool, error) {
+				return nil, false, constants.ErrLock
+			},
+		}
+
+		runLocked(context.Background(), commander, collectorLockKey, func() { ran = true })
+		assert.False(t, ran, "잠금을 확인하지 못하면 작업을 건너뛰었습니다.")
+	})
+}
+
+func TestWithLock(t *testing.T) {
+	t.Run("잠금을 얻은 케이스", func(t *testing.T) {
+		unlocked := false
+		commander := &repository.MockProofCommand{
+			TryLockFn: func(ctx context.Context, key int64) (func(), bool, error) {
+				return func() { unlocked = true }, true, nil
+			},
+		}
+
+		err := withLock(context.Background(), commander, purgerLockKey, func() error { return constants.ErrRetentionPurge })
+		assert.ErrorIs(t, err, constants.ErrRetentionPurge, "작업의 에러가 그대로 반환되었습니다.")
+		assert.True(t, unlocked, "작업이 끝나면 잠금을 풀었습니다.")
+	})
+
+	t.Run("다른 인스턴스가 잠금을 가진 케이스", func(t *testing.T) {
+		ran := false
+		commander := &repository.MockProofCommand{
+			TryLockFn: func(ctx context.Context, key int64) (func(), bool, error) {
+				return nil, false, nil
+			},
+		}
+
+		err := withLock(context.Background(), commander, purgerLockKey, func() error {
+			ran = true
+			return nil
+		})
+		assert.ErrorIs(t, err, constants.ErrLockHeld)
+		assert.False(t, ran, "다른 인스턴스가 실행 중인 작업은 실행하지 않았습니다.")
+	})
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/internal/proof/repository"
+	"security-proof/pkg/constants"
+	filemanage "security-proof/pkg/manage/file"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+// thumbnailBatchSize is the number of attachments read at once while backfilling thumbnails.
+const thumbnailBatchSize = 100
+
+// ThumbnailBackfill struct is composed of a ProofCommander, a ProofQuerier, a Storage and a Validator of uploaded files.
+type ThumbnailBackfill struct {
+	proofCommand repository.ProofCommander
+	proofQuery   repository.ProofQuerier
+	storage      storagemanage.Storage
+	validator    *filemanage.Validator
+}
+
+// NewThumbnailBackfill function is returning a ThumbnailBackfill, accepting a ProofCommander, a ProofQuerier, a Storage and a Validator.
+func NewThumbnailBackfill(
+	proofCommander repository.ProofCommander,
+	proofQuerier repository.ProofQuerier,
+	storage storagemanage.Storage,
+	validator *filemanage.Validator,
+) *ThumbnailBackfill {
+	return &ThumbnailBackfill{
+		proofCommand: proofCommander,
+		proofQuery:   proofQuerier,
+		storage:      storage,
+		validator:    validator,
+	}
+}
+
+// Backfill method is returning the number of thumbnails recorded and an error, accepting a context.
+// Attachments uploaded before thumbnails existed are read from the storage once each, and a file that cannot be read
+// or is not an image is logged and skipped so that a single broken file does not stop the backfill.
+// The sniffed type of an attachment migrated without a detected type is recorded, so it is served inline again.
+func (b *ThumbnailBackfill) Backfill(ctx context.Context) (int, error) {
+	count := 0
+	afterIdx := int32(0)
+	for {
+		attachments, err := b.proofQuery.ListThumbnailCandidates(ctx, afterIdx, thumbnailBatchSize)
+		if errors.Is(err, constants.ErrItemNotFound) {
+			return count, nil
+		} else if err != nil {
+			return count, errors.Join(constants.ErrFileThumbnail, err)
+		}
+		if len(attachments) == 0 {
+			return count, nil
+		}
+
+		for _, attachment := range attachments {
+			// 실패한 첨부 파일도 다시 조회되지 않도록 커서를 먼저 옮깁니다.
+			afterIdx = attachment.Idx
+
+			thumbnailPath, mimeType := b.thumbnail(ctx, attachment)
+			if mimeType != "" && mimeType != attachment.MimeType {
+				err = b.proofCommand.UpdateProofAttachmentType(ctx, attachment.Idx, mimeType, nil)
+				if err != nil {
+					return count, errors.Join(constants.ErrFileThumbnail, err)
+				}
+			}
+			if thumbnailPath == nil {
+				continue
+			}
+
+			err = b.proofCommand.UpdateProofAttachmentThumbnail(ctx, attachment.Idx, *thumbnailPath, nil)
+			if err != nil {
+				return count, errors.Join(constants.ErrFileThumbnail, err)
+			}
+			count++
+		}
+	}
+}
+
+// BackfillHashes method is returning the number of hashes recorded and an error, accepting a context.
+// Attachments migrated before hashes were recorded are hashed from the stored file, so that they are verified when they are downloaded,
+// and the integrity scan still compares them with the chain record. A file that cannot be read is logged and skipped.
+func (b *ThumbnailBackfill) BackfillHashes(ctx context.Context) (int, error) {
+	count := 0
+	afterIdx := int32(0)
+	for {
+		attachments, err := b.proofQuery.ListUnhashedAttachments(ctx, afterIdx, thumbnailBatchSize)
+		if errors.Is(err, constants.ErrItemNotFound) {
+			return count, nil
+		} else if err != nil {
+			return count, errors.Join(constants.ErrFileRead, err)
+		}
+		if len(attachments) == 0 {
+			return count, nil
+		}
+
+		for _, attachment := range attachments {
+			// 실패한 첨부 파일도 다시 조회되지 않도록 커서를 먼저 옮깁니다.
+			afterIdx = attachment.Idx
+
+			hash := b.hash(ctx, attachment)
+			if hash == "" {
+				continue
+			}
+
+			err = b.proofCommand.UpdateProofAttachmentHash(ctx, attachment.Idx, hash, nil)
+			// 그 사이에 해시가 기록된 첨부 파일은 그대로 둡니다.
+			if errors.Is(err, constants.ErrItemNotFound) {
+				continue
+			} else if err != nil {
+				return count, errors.Join(constants.ErrFileRead, err)
+			}
+			count++
+		}
+	}
+}
+
+// hash method is returning the SHA-256 hex digest of a stored attachment, accepting a context and a ProofAttachment.
+// The digest is empty when the file cannot be read.
+func (b *ThumbnailBackfill) hash(ctx context.Context, attachment *model.ProofAttachment) string {
+	reader, err := b.storage.Get(ctx, attachment.Path)
+	if err != nil {
+		log.Printf("Failed to read attachment %d for hash: %v", attachment.Idx, err)
+		return ""
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Printf("Failed to close attachment: %v", closeErr)
+		}
+	}()
+
+	hash, err := filemanage.ReaderToHash(reader)
+	if err != nil {
+		log.Printf("Failed to read attachment %d for hash: %v", attachment.Idx, err)
+		return ""
+	}
+	return hash
+}
+
+// thumbnail method is returning the key of a stored thumbnail and the sniffed type, accepting a context and a ProofAttachment.
+// The type is sniffed again since attachments migrated from the file path carry no detected type.
+func (b *ThumbnailBackfill) thumbnail(ctx context.Context, attachment *model.ProofAttachment) (*string, string) {
+	reader, err := b.storage.Get(ctx, attachment.Path)
+	if err != nil {
+		log.Printf("Failed to read attachment %d for thumbnail: %v", attachment.Idx, err)
+		return nil, ""
+	}
+
+	spooled, err := spoolAttachment(reader, b.validator.MaxSize())
+	closeErr := reader.Close()
+	if closeErr != nil {
+		log.Printf("Failed to close attachment: %v", closeErr)
+	}
+	if err != nil {
+		log.Printf("Failed to read attachment %d for thumbnail: %v", attachment.Idx, err)
+		return nil, ""
+	}
+	defer spooled.Close()
+
+	return storeThumbnail(ctx, b.storage, spooled), spooled.mimeType
+}
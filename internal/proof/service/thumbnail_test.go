@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/internal/db/security_proof/proof/model"
+	"security-proof/pkg/constants"
+	filemanage "security-proof/pkg/manage/file"
+	storagemanage "security-proof/pkg/manage/storage"
+)
+
+func TestThumbnailBackfill_Backfill(t *testing.T) {
+	storage := storagemanage.NewMemory()
+	screenshot := testPNG(t, 300, 300)
+
+	err := storage.Put(context.Background(), "1_1700000000_1", strings.NewReader(string(screenshot)), int64(len(screenshot)))
+	assert.NoError(t, err)
+	err = storage.Put(context.Background(), "1_1700000000_2", strings.NewReader("connection refused"), 18)
+	assert.NoError(t, err)
+
+	candidates := make([]*model.ProofAttachment, 0, thumbnailBatchSize+2)
+	for i := int32(1); i <= thumbnailBatchSize; i++ {
+		candidates = append(candidates, &model.ProofAttachment{Idx: i, Path: "1_1700000000_2", MimeType: "application/octet-stream"})
+	}
+	candidates = append(candidates,
+		&model.ProofAttachment{Idx: thumbnailBatchSize + 1, Path: "1_1700000000_1", MimeType: "application/octet-stream"},
+		&model.ProofAttachment{Idx: thumbnailBatchSize + 2, Path: "missing", MimeType: "image/png"},
+	)
+
+	query := *mockQuery
+	query.ListThumbnailCandidatesFn = func(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+		result := make([]*model.ProofAttachment, 0)
+		for _, candidate := range candidates {
+			if candidate.Idx > afterIdx && int64(len(result)) < limit {
+				result = append(result, candidate)
+			}
+		}
+		return result, nil
+	}
+
+	updated := make(map[int32]string)
+	commander := *mockCommand
+	commander.UpdateProofAttachmentThumbnailFn = func(ctx context.Context, idx int32, thumbnailPath string, tx *sql.Tx) error {
+		updated[idx] = thumbnailPath
+		return nil
+	}
+
+	types := make(map[int32]string)
+	commander.UpdateProofAttachmentTypeFn = func(ctx context.Context, idx int32, mimeType string, tx *sql.Tx) error {
+		types[idx] = mimeType
+		return nil
+	}
+
+	backfill := NewThumbnailBackfill(&commander, &query, storage, mockValidator)
+
+	t.Run("기존 이미지 썸네일 생성 케이스", func(t *testing.T) {
+		count, err := backfill.Backfill(context.Background())
+		assert.NoError(t, err, "읽을 수 없는 파일이 있어도 에러 없이 끝났습니다.")
+		assert.Equal(t, 1, count, "여러 묶음을 거쳐 이미지 파일만 썸네일이 만들어졌습니다.")
+
+		key := storagemanage.ThumbnailKey(filemanage.DataToHash(screenshot))
+		assert.Equal(t, map[int32]string{thumbnailBatchSize + 1: key}, updated)
+		assert.Equal(t, "image/png", types[thumbnailBatchSize+1], "이전 이미지의 확인된 타입이 기록되어 다시 화면에 보입니다.")
+		assert.Equal(t, "text/plain; charset=utf-8", types[1], "이미지가 아닌 파일도 확인된 타입이 기록되었습니다.")
+		assert.NotContains(t, types, int32(thumbnailBatchSize+2), "읽을 수 없는 파일의 타입은 바꾸지 않았습니다.")
+
+		object, err := storage.Stat(context.Background(), key)
+		assert.NoError(t, err)
+		assert.NotZero(t, object.Size, "썸네일이 저장소에 저장되었습니다.")
+	})
+
+	t.Run("조회 실패 케이스", func(t *testing.T) {
+		query.ListThumbnailCandidatesFn = func(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+			return nil, constants.ErrQuery
+		}
+		_, err := backfill.Backfill(context.Background())
+		assert.ErrorIs(t, err, constants.ErrFileThumbnail)
+	})
+}
+
+func TestThumbnailBackfill_BackfillHashes(t *testing.T) {
+	storage := storagemanage.NewMemory()
+	err := storage.Put(context.Background(), "1_1700000000_1", strings.NewReader("legacy evidence"), 15)
+	assert.NoError(t, err)
+
+	candidates := []*model.ProofAttachment{
+		{Idx: 1, Path: "1_1700000000_1"},
+		{Idx: 2, Path: "missing"},
+		{Idx: 3, Path: "1_1700000000_1"},
+	}
+
+	query := *mockQuery
+	query.ListUnhashedAttachmentsFn = func(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+		result := make([]*model.ProofAttachment, 0)
+		for _, candidate := range candidates {
+			if candidate.Idx > afterIdx && int64(len(result)) < limit {
+				result = append(result, candidate)
+			}
+		}
+		return result, nil
+	}
+
+	hashes := make(map[int32]string)
+	commander := *mockCommand
+	commander.UpdateProofAttachmentHashFn = func(ctx context.Context, idx int32, hash string, tx *sql.Tx) error {
+		// 3번 첨부 파일은 그 사이에 해시가 기록된 경우입니다.
+		if idx == 3 {
+			return constants.ErrItemNotFound
+		}
+		hashes[idx] = hash
+		return nil
+	}
+
+	backfill := NewThumbnailBackfill(&commander, &query, storage, mockValidator)
+
+	t.Run("해시 없이 옮겨진 첨부 파일 해시 기록 케이스", func(t *testing.T) {
+		count, err := backfill.BackfillHashes(context.Background())
+		assert.NoError(t, err, "읽을 수 없는 파일이 있어도 에러 없이 끝났습니다.")
+		assert.Equal(t, 1, count, "읽을 수 있는 파일만 해시가 기록되었습니다.")
+		assert.Equal(t, map[int32]string{1: filemanage.DataToHash([]byte("legacy evidence"))}, hashes, "저장된 파일의 해시가 기록되었습니다.")
+	})
+
+	t.Run("조회 실패 케이스", func(t *testing.T) {
+		query.ListUnhashedAttachmentsFn = func(ctx context.Context, afterIdx int32, limit int64) ([]*model.ProofAttachment, error) {
+			return nil, constants.ErrQuery
+		}
+		_, err := backfill.BackfillHashes(context.Background())
+		assert.ErrorIs(t, err, constants.ErrQuery)
+	})
+}
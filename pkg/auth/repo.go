@@ -4,6 +4,7 @@ package auth
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 
@@ -62,3 +63,31 @@ func (r *tokenRepo) DeleteToken(ctx context.Context, idx string) error {
 
 	return nil
 }
+
+// NonceRepo interface is defining data related to manage single-use nonces.
+type NonceRepo interface {
+	UseNonce(ctx context.Context, nonce string, ttl time.Duration) (used bool, err error)
+}
+
+type nonceRepo struct {
+	rdb *redis.Client
+}
+
+// nonceKeyPrefix is the prefix of the nonce keys, which keeps them apart from the refresh tokens saved by the user index.
+const nonceKeyPrefix = "nonce:"
+
+// NewNonceRepo function is returning a NonceRepo accepting a redis client.
+func NewNonceRepo(rdb *redis.Client) NonceRepo {
+	return &nonceRepo{rdb: rdb}
+}
+
+// UseNonce method is returning whether a nonce was used before and an error, accepting a context, a nonce and a ttl.
+// The nonce is kept for the ttl, so it must outlive what it protects.
+func (r *nonceRepo) UseNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	set, err := r.rdb.SetNX(ctx, nonceKeyPrefix+nonce, 1, ttl).Result()
+	if err != nil {
+		return false, errors.Join(constants.ErrSignedURLNonce, err)
+	}
+
+	return !set, nil
+}
@@ -1,6 +1,9 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // MockTokenRepo struct is used for testing the tokenRepo structure.
 type MockTokenRepo struct {
@@ -23,3 +26,13 @@ func (m *MockTokenRepo) ReadTokenByIdx(ctx context.Context, idx string) (string,
 func (m *MockTokenRepo) DeleteToken(ctx context.Context, idx string) error {
 	return m.DeleteTokenFn(ctx, idx)
 }
+
+// MockNonceRepo struct is used for testing the nonceRepo structure.
+type MockNonceRepo struct {
+	UseNonceFn func(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// UseNonce method is the mock test function for UseNonce.
+func (m *MockNonceRepo) UseNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return m.UseNonceFn(ctx, nonce, ttl)
+}
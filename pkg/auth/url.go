@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Netflix/go-env"
+
+	"security-proof/pkg/constants"
+)
+
+// URLConfig struct is composed of a secret key, which falls back to the JWT secret key when it is empty,
+// a lifetime of a signed url and whether every signed url can be used only once.
+type URLConfig struct {
+	SecretKey string        `env:"SIGNED_URL_SECRET_KEY,default="`
+	TTL       time.Duration `env:"SIGNED_URL_TTL,default=5m"`
+	SingleUse bool          `env:"SIGNED_URL_SINGLE_USE,default=false"`
+}
+
+// FromEnv method is returning a URLConfig.
+func (c *URLConfig) FromEnv() *URLConfig {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
+
+// URLClaims struct is composed of the proof index, the revision and the position of an attachment, whether its thumbnail is served,
+// the index of the user the url is issued to, an expiry time, whether the url can be used only once and a nonce.
+type URLClaims struct {
+	ProofIdx  int32
+	Revision  int32
+	Position  int32
+	Thumbnail bool
+	UserIdx   string
+	ExpiresAt time.Time
+	SingleUse bool
+	Nonce     string
+}
+
+// URLSigner struct is composed of a URLConfig, an HMAC key and a NonceRepo.
+type URLSigner struct {
+	config    *URLConfig
+	key       []byte
+	nonceRepo NonceRepo
+}
+
+// NewURLSigner function is returning a URLSigner and an error, accepting a URLConfig and a NonceRepo.
+func NewURLSigner(config *URLConfig, nonceRepo NonceRepo) (*URLSigner, error) {
+	key := config.SecretKey
+	if key == "" {
+		jwtConfig := jwtConfig{}
+		_, err := env.UnmarshalFromEnviron(&jwtConfig)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSignedURLCreate, err)
+		}
+		key = jwtConfig.SecretKey
+	}
+
+	return &URLSigner{config: config, key: []byte(key), nonceRepo: nonceRepo}, nil
+}
+
+// Sign method is returning the query of a signed url and an error, accepting URLClaims.
+// The expiry time and the nonce are filled, and the url is single-use when the claims or the config ask for it.
+func (s *URLSigner) Sign(claims *URLClaims) (url.Values, error) {
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignedURLCreate, err)
+	}
+
+	claims.ExpiresAt = time.Now().Add(s.config.TTL).Truncate(time.Second)
+	claims.SingleUse = claims.SingleUse || s.config.SingleUse
+	claims.Nonce = base64.RawURLEncoding.EncodeToString(nonce)
+
+	values := url.Values{}
+	values.Set("revision", strconv.Itoa(int(claims.Revision)))
+	if claims.Thumbnail {
+		values.Set("thumbnail", "1")
+	}
+	values.Set("user", claims.UserIdx)
+	values.Set("expires", strconv.FormatInt(claims.ExpiresAt.Unix(), 10))
+	if claims.SingleUse {
+		values.Set("single", "1")
+	}
+	values.Set("nonce", claims.Nonce)
+	values.Set("signature", base64.RawURLEncoding.EncodeToString(s.mac(claims)))
+	return values, nil
+}
+
+// Verify method is returning URLClaims and an error, accepting a context, a proof index, a position and the query of a signed url.
+// A single-use url is spent by the first request that verifies it.
+func (s *URLSigner) Verify(ctx context.Context, proofIdx int32, position int32, values url.Values) (*URLClaims, error) {
+	revision, err := strconv.ParseInt(values.Get("revision"), 10, 32)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignedURLInvalid, err)
+	}
+	expires, err := strconv.ParseInt(values.Get("expires"), 10, 64)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignedURLInvalid, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(values.Get("signature"))
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignedURLInvalid, err)
+	}
+
+	claims := &URLClaims{
+		ProofIdx:  proofIdx,
+		Revision:  int32(revision),
+		Position:  position,
+		Thumbnail: values.Get("thumbnail") == "1",
+		UserIdx:   values.Get("user"),
+		ExpiresAt: time.Unix(expires, 0),
+		SingleUse: values.Get("single") == "1",
+		Nonce:     values.Get("nonce"),
+	}
+	if !hmac.Equal(signature, s.mac(claims)) {
+		return nil, constants.ErrSignedURLInvalid
+	}
+
+	// 서명이 맞는 주소만 만료와 사용 여부를 확인합니다.
+	ttl := time.Until(claims.ExpiresAt)
+	if ttl <= 0 {
+		return nil, constants.ErrSignedURLExpired
+	}
+
+	if claims.SingleUse {
+		used, err := s.nonceRepo.UseNonce(ctx, claims.Nonce, ttl)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			return nil, constants.ErrSignedURLUsed
+		}
+	}
+
+	return claims, nil
+}
+
+// mac method is returning the HMAC-SHA256 of URLClaims.
+func (s *URLSigner) mac(claims *URLClaims) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	// 다른 용도의 서명과 섞이지 않도록 용도를 앞에 붙입니다.
+	_, _ = fmt.Fprintf(mac, "download\n%d\n%d\n%d\n%t\n%s\n%d\n%t\n%s",
+		claims.ProofIdx, claims.Revision, claims.Position, claims.Thumbnail,
+		claims.UserIdx, claims.ExpiresAt.Unix(), claims.SingleUse, claims.Nonce)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+func TestURLSigner_Verify(t *testing.T) {
+	signer := initMockURLSigner(t, time.Minute)
+
+	t.Run("서명된 주소 검증 케이스", func(t *testing.T) {
+		values, err := signer.Sign(&URLClaims{ProofIdx: 1, Revision: 2, Position: 3, UserIdx: "7"})
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		claims, err := signer.Verify(context.Background(), 1, 3, values)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, int32(2), claims.Revision)
+		assert.Equal(t, "7", claims.UserIdx, "주소를 발급받은 사용자가 확인되었습니다.")
+
+		_, err = signer.Verify(context.Background(), 1, 3, values)
+		assert.NoError(t, err, "일회용이 아닌 주소는 다시 사용할 수 있습니다.")
+	})
+
+	t.Run("다른 첨부 파일 요청 케이스", func(t *testing.T) {
+		values, err := signer.Sign(&URLClaims{ProofIdx: 1, Revision: 2, Position: 3, UserIdx: "7"})
+		assert.NoError(t, err)
+
+		_, err = signer.Verify(context.Background(), 1, 2, values)
+		assert.ErrorIs(t, err, constants.ErrSignedURLInvalid, "서명된 첨부 파일만 내려받을 수 있습니다.")
+
+		values.Set("thumbnail", "1")
+		_, err = signer.Verify(context.Background(), 1, 3, values)
+		assert.ErrorIs(t, err, constants.ErrSignedURLInvalid)
+	})
+
+	t.Run("변조된 만료 시각 케이스", func(t *testing.T) {
+		values, err := signer.Sign(&URLClaims{ProofIdx: 1, Revision: 2, Position: 3, UserIdx: "7"})
+		assert.NoError(t, err)
+
+		values.Set("expires", "4102444800")
+		_, err = signer.Verify(context.Background(), 1, 3, values)
+		assert.ErrorIs(t, err, constants.ErrSignedURLInvalid)
+	})
+
+	t.Run("일회용 주소 케이스", func(t *testing.T) {
+		values, err := signer.Sign(&URLClaims{ProofIdx: 1, Revision: 2, Position: 3, UserIdx: "7", SingleUse: true})
+		assert.NoError(t, err)
+
+		_, err = signer.Verify(context.Background(), 1, 3, values)
+		assert.NoError(t, err, "처음 사용하는 주소는 검증됩니다.")
+
+		_, err = signer.Verify(context.Background(), 1, 3, values)
+		assert.ErrorIs(t, err, constants.ErrSignedURLUsed, "이미 사용한 주소는 거부됩니다.")
+	})
+
+	t.Run("만료된 주소 케이스", func(t *testing.T) {
+		expired := initMockURLSigner(t, -time.Second)
+		values, err := expired.Sign(&URLClaims{ProofIdx: 1, Revision: 2, Position: 3, UserIdx: "7"})
+		assert.NoError(t, err)
+
+		_, err = expired.Verify(context.Background(), 1, 3, values)
+		assert.ErrorIs(t, err, constants.ErrSignedURLExpired)
+	})
+}
+
+func initMockURLSigner(t *testing.T, ttl time.Duration) *URLSigner {
+	used := make(map[string]bool)
+	mockNonceRepo := &MockNonceRepo{
+		UseNonceFn: func(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+			if used[nonce] {
+				return true, nil
+			}
+			used[nonce] = true
+			return false, nil
+		},
+	}
+
+	signer, err := NewURLSigner(&URLConfig{TTL: ttl}, mockNonceRepo)
+	assert.NoError(t, err)
+	return signer
+}
@@ -0,0 +1,151 @@
+// Package catalog is a package for reading control framework catalogs.
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"security-proof/pkg/constants"
+)
+
+// Format constants is the supported catalog file format.
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// csvHeader is the header row expected in a CSV catalog.
+var csvHeader = []string{"framework", "control_id", "title", "parent_control_id"}
+
+// Control struct is composed of a framework, a control ID, a title and a parent control ID.
+type Control struct {
+	Framework       string `json:"framework"`
+	ControlID       string `json:"controlId"`
+	Title           string `json:"title"`
+	ParentControlID string `json:"parentControlId,omitempty"`
+}
+
+// Parse function is returning controls and an error, accepting a reader and a format.
+func Parse(r io.Reader, format string) ([]*Control, error) {
+	var controls []*Control
+	var err error
+
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		controls, err = parseJSON(r)
+	case FormatCSV:
+		controls, err = parseCSV(r)
+	default:
+		return nil, errors.Join(constants.ErrCatalogFormat, fmt.Errorf("unknown format %q", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate(controls)
+	if err != nil {
+		return nil, err
+	}
+
+	return controls, nil
+}
+
+// parseJSON function is returning controls and an error, accepting a reader of a JSON array.
+func parseJSON(r io.Reader) ([]*Control, error) {
+	controls := make([]*Control, 0)
+	err := json.NewDecoder(r).Decode(&controls)
+	if err != nil {
+		return nil, errors.Join(constants.ErrCatalogParse, err)
+	}
+	return controls, nil
+}
+
+// parseCSV function is returning controls and an error, accepting a reader of a CSV with a header row.
+func parseCSV(r io.Reader) ([]*Control, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Join(constants.ErrCatalogParse, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvHeader[:3] {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Join(constants.ErrCatalogParse, fmt.Errorf("missing column %q", name))
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	controls := make([]*Control, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Join(constants.ErrCatalogParse, err)
+		}
+
+		controls = append(controls, &Control{
+			Framework:       field(record, "framework"),
+			ControlID:       field(record, "control_id"),
+			Title:           field(record, "title"),
+			ParentControlID: field(record, "parent_control_id"),
+		})
+	}
+
+	return controls, nil
+}
+
+// validate function is returning an error, accepting parsed controls.
+// Values are trimmed in place, and a parent listed in the same catalog must not form a cycle.
+func validate(controls []*Control) error {
+	parents := make(map[string]string, len(controls))
+	for i, control := range controls {
+		control.Framework = strings.TrimSpace(control.Framework)
+		control.ControlID = strings.TrimSpace(control.ControlID)
+		control.Title = strings.TrimSpace(control.Title)
+		control.ParentControlID = strings.TrimSpace(control.ParentControlID)
+
+		if control.Framework == "" || control.ControlID == "" || control.Title == "" {
+			return errors.Join(constants.ErrCatalogControl, fmt.Errorf("row %d", i+1))
+		}
+
+		key := control.Framework + "\x00" + control.ControlID
+		if _, ok := parents[key]; ok {
+			return errors.Join(constants.ErrCatalogControl, fmt.Errorf("duplicated control %s %s", control.Framework, control.ControlID))
+		}
+		parents[key] = control.ParentControlID
+	}
+
+	for _, control := range controls {
+		seen := map[string]bool{control.ControlID: true}
+		parent := control.ParentControlID
+		for parent != "" {
+			if seen[parent] {
+				return errors.Join(constants.ErrCatalogControl, fmt.Errorf("cyclic parent of control %s %s", control.Framework, control.ControlID))
+			}
+			seen[parent] = true
+			parent = parents[control.Framework+"\x00"+parent]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("JSON 카탈로그 파싱 케이스", func(t *testing.T) {
+		controls, err := Parse(strings.NewReader(`[
+			{"framework": "ISMS-P", "controlId": "2.1", "title": "정책, 조직, 자산 관리"},
+			{"framework": "ISMS-P", "controlId": "2.1.1", "title": " 정책의 유지관리 ", "parentControlId": "2.1"}
+		]`), FormatJSON)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, controls, 2, "통제 항목이 모두 파싱되었습니다.")
+		assert.Equal(t, "정책의 유지관리", controls[1].Title, "값의 공백이 제거되었습니다.")
+		assert.Equal(t, "2.1", controls[1].ParentControlID)
+	})
+
+	t.Run("CSV 카탈로그 파싱 케이스", func(t *testing.T) {
+		controls, err := Parse(strings.NewReader(
+			"framework,control_id,title,parent_control_id\n"+
+				"ISO 27001,A.5,Organizational controls,\n"+
+				"ISO 27001,A.5.1,Policies for information security,A.5\n",
+		), "CSV")
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, controls, 2, "통제 항목이 모두 파싱되었습니다.")
+		assert.Equal(t, "A.5.1", controls[1].ControlID)
+		assert.Equal(t, "A.5", controls[1].ParentControlID)
+	})
+
+	t.Run("CSV 필수 컬럼 누락 케이스", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("framework,title\nISMS-P,정책\n"), FormatCSV)
+		assert.ErrorIs(t, err, constants.ErrCatalogParse)
+	})
+
+	t.Run("필수 값 누락 케이스", func(t *testing.T) {
+		_, err := Parse(strings.NewReader(`[{"framework": "ISMS-P", "controlId": "2.1"}]`), FormatJSON)
+		assert.ErrorIs(t, err, constants.ErrCatalogControl)
+	})
+
+	t.Run("중복 통제 항목 케이스", func(t *testing.T) {
+		_, err := Parse(strings.NewReader(`[
+			{"framework": "ISMS-P", "controlId": "2.1", "title": "a"},
+			{"framework": "ISMS-P", "controlId": "2.1", "title": "b"}
+		]`), FormatJSON)
+		assert.ErrorIs(t, err, constants.ErrCatalogControl)
+	})
+
+	t.Run("순환 상위 항목 케이스", func(t *testing.T) {
+		_, err := Parse(strings.NewReader(`[
+			{"framework": "ISMS-P", "controlId": "2.1", "title": "a", "parentControlId": "2.2"},
+			{"framework": "ISMS-P", "controlId": "2.2", "title": "b", "parentControlId": "2.1"}
+		]`), FormatJSON)
+		assert.ErrorIs(t, err, constants.ErrCatalogControl)
+	})
+
+	t.Run("지원하지 않는 형식 케이스", func(t *testing.T) {
+		_, err := Parse(strings.NewReader(""), "xml")
+		assert.ErrorIs(t, err, constants.ErrCatalogFormat)
+	})
+}
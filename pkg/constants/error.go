@@ -15,17 +15,13 @@ var (
 	ErrNewRedis  = errors.New("new redis error")
 )
 
-// Defines errors related to the elastic.
-var (
-	ErrElasticCountExist = errors.New("elastic count exist")
-	ErrElasticCountAll   = errors.New("elastic count all")
-)
-
 // Defines errors related to the transaction in database.
 var (
 	ErrBegin     = errors.New("begin tx error")
 	ErrCommit    = errors.New("commit error")
 	ErrRollback  = errors.New("rollback error")
+	ErrLock      = errors.New("lock error")
+	ErrLockHeld  = errors.New("lock held error")
 	ErrExecute   = errors.New("execute error")
 	ErrRowResult = errors.New("row result error")
 	ErrQuery     = errors.New("query error")
@@ -35,6 +31,7 @@ var (
 var (
 	ErrItemNotFound      = errors.New("item not found")
 	ErrRepositoryUnknown = errors.New("repository unknown")
+	ErrStateConflict     = errors.New("state conflict")
 )
 
 // Defines errors related to the user service.
@@ -63,6 +60,15 @@ var (
 	ErrTokenRoleAuth     = errors.New("role auth error")
 )
 
+// Defines errors related to the signed url.
+var (
+	ErrSignedURLCreate  = errors.New("create signed url error")
+	ErrSignedURLInvalid = errors.New("signed url is invalid")
+	ErrSignedURLExpired = errors.New("signed url is expired")
+	ErrSignedURLUsed    = errors.New("signed url is already used")
+	ErrSignedURLNonce   = errors.New("use signed url nonce error")
+)
+
 // Defines errors related to the proof service.
 var (
 	ErrProofCreate          = errors.New("create proof error")
@@ -71,11 +77,67 @@ var (
 	ErrProofUpload          = errors.New("upload proof error")
 	ErrProofRead            = errors.New("read proof error")
 	ErrProofList            = errors.New("list proof error")
-	ErrProofReadFirstImage  = errors.New("read first image error")
-	ErrProofReadSecondImage = errors.New("read second image error")
+	ErrProofReadAttachment  = errors.New("read attachment error")
+	ErrProofAttachmentList  = errors.New("list attachment error")
+	ErrProofDownloadURL     = errors.New("create download url error")
+	ErrProofAttachmentEmpty = errors.New("at least one attachment is required")
 	ErrProofReadLog         = errors.New("read log error")
+	ErrProofLogDuplicate    = errors.New("only one log is allowed per upload")
+	ErrProofLogRange        = errors.New("log offset and limit must not be negative")
 	ErrProofConfirm         = errors.New("confirm proof error")
 	ErrProofUpdateConfirm   = errors.New("confirm update proof error")
+	ErrProofReview          = errors.New("review proof error")
+	ErrProofExpire          = errors.New("expire proof error")
+	ErrProofReopen          = errors.New("reopen proof error")
+	ErrProofHistory         = errors.New("proof history error")
+	ErrProofTransition      = errors.New("illegal proof state transition")
+	ErrProofReject          = errors.New("reject proof error")
+	ErrProofRejectReason    = errors.New("reject reason is required")
+	ErrProofRejectList      = errors.New("list proof reject error")
+	ErrProofRevision        = errors.New("proof revision error")
+	ErrProofRevisionList    = errors.New("list proof revision error")
+	ErrProofCycleStart      = errors.New("start cycle error")
+	ErrProofCycleList       = errors.New("list cycle error")
+	ErrProofCycleName       = errors.New("cycle name is required")
+	ErrProofControlImport   = errors.New("import control error")
+	ErrProofControlMap      = errors.New("map proof control error")
+	ErrProofControlList     = errors.New("list control error")
+	ErrProofDueDate         = errors.New("set due date error")
+	ErrProofOverdueList     = errors.New("list overdue proof error")
+	ErrProofRemind          = errors.New("remind proof error")
+	ErrProofImport          = errors.New("import proof error")
+	ErrProofImportParse     = errors.New("parse proof import error")
+	ErrProofImportRow       = errors.New("num, category and description are required")
+	ErrProofNumDuplicate    = errors.New("duplicated proof num")
+	ErrProofAssignee        = errors.New("unknown assignee")
+	ErrProofExport          = errors.New("export proof error")
+	ErrProofCommentCreate   = errors.New("create proof comment error")
+	ErrProofCommentUpdate   = errors.New("update proof comment error")
+	ErrProofCommentDelete   = errors.New("delete proof comment error")
+	ErrProofCommentList     = errors.New("list proof comment error")
+	ErrProofCommentBody     = errors.New("comment body is required")
+	ErrProofCommentAuthor   = errors.New("comment belongs to another user")
+)
+
+// Defines errors related to the retention.
+var (
+	ErrRetentionPolicy     = errors.New("set retention policy error")
+	ErrRetentionPolicyList = errors.New("list retention policy error")
+	ErrRetentionDays       = errors.New("retention days must be positive")
+	ErrRetentionScope      = errors.New("retention policy is either for a category or a cycle")
+	ErrRetentionPurge      = errors.New("purge evidence error")
+	ErrRetentionReport     = errors.New("read purged evidence error")
+	ErrLegalHold           = errors.New("set legal hold error")
+	ErrLegalHoldReason     = errors.New("legal hold reason is required")
+	ErrProofLegalHold      = errors.New("proof is on legal hold")
+	ErrProofRetained       = errors.New("proof is within its retention period")
+	ErrProofPurged         = errors.New("proof evidence is purged")
+)
+
+// Defines errors related to the integrity check.
+var (
+	ErrIntegrityScan = errors.New("scan evidence integrity error")
+	ErrIntegrityRead = errors.New("read integrity check error")
 )
 
 // Defines errors related to the dashboard service.
@@ -89,4 +151,42 @@ var (
 	ErrFileSave          = errors.New("save file error")
 	ErrFilePath          = errors.New("file path error")
 	ErrFilePathTraversal = errors.New("file path traversal error")
+	ErrFileRead          = errors.New("read file error")
+	ErrFileStat          = errors.New("stat file error")
+	ErrFileDelete        = errors.New("delete file error")
+	ErrFileList          = errors.New("list file error")
+	ErrFileSize          = errors.New("file size mismatch")
+	ErrFileEmpty         = errors.New("file is empty")
+	ErrFileTooLarge      = errors.New("file is too large")
+	ErrFileType          = errors.New("file type is not allowed")
+	ErrFileThumbnail     = errors.New("create thumbnail error")
+	ErrFileMetadata      = errors.New("read image metadata error")
+	ErrFileInfected      = errors.New("file is infected")
+	ErrFileScan          = errors.New("scan file error")
+	ErrFileTampered      = errors.New("file does not match its recorded hash")
+	ErrFileRemoved       = errors.New("stored file was removed during upload")
+	ErrFileMultipart     = errors.New("read multipart form error")
+)
+
+// Defines errors related to the storage.
+var (
+	ErrStorageBackend = errors.New("unknown storage backend")
+	ErrStorageConfig  = errors.New("invalid storage config")
+	ErrStorageKey     = errors.New("invalid storage master key")
+	ErrStorageEncrypt = errors.New("encrypt file error")
+	ErrStorageDecrypt = errors.New("decrypt file error")
+	ErrStorageRotate  = errors.New("rotate data key error")
+	ErrStorageCollect = errors.New("collect orphaned file error")
+)
+
+// Defines errors related to the control catalog.
+var (
+	ErrCatalogFormat  = errors.New("unknown catalog format")
+	ErrCatalogParse   = errors.New("parse catalog error")
+	ErrCatalogControl = errors.New("invalid catalog control")
+)
+
+// Defines errors related to the signature.
+var (
+	ErrSignKey = errors.New("signing key error")
 )
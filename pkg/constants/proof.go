@@ -5,3 +5,29 @@ var (
 	NotConfirm = int32(0)
 	Confirm    = int32(1)
 )
+
+// Defines state related to the proof lifecycle.
+var (
+	StateDraft     = int32(0)
+	StateAssigned  = int32(1)
+	StateUploaded  = int32(2)
+	StateInReview  = int32(3)
+	StateRejected  = int32(4)
+	StateConfirmed = int32(5)
+	StateExpired   = int32(6)
+	StateReopened  = int32(7)
+)
+
+// Defines targets compared by the integrity check of confirmed evidence.
+var (
+	IntegrityFile   = "file"
+	IntegrityDigest = "digest"
+	IntegrityChain  = "chain"
+)
+
+// Defines results of the integrity check of confirmed evidence.
+var (
+	IntegrityOK       = "ok"
+	IntegrityMismatch = "mismatch"
+	IntegrityMissing  = "missing"
+)
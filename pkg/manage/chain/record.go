@@ -0,0 +1,19 @@
+package chain
+
+import "strings"
+
+// DigestPrefix is marking the second hash of a record as the digest of every attachment of a revision.
+// Records anchored before revisions had several attachments hold the SHA-256 of the second image there without the prefix,
+// so a record is told apart by the prefix alone. The first hash is the SHA-256 of the first attachment in both records.
+const DigestPrefix = "attachments-sha256-v1:"
+
+// DigestHash function is returning the second hash of a record, accepting the digest of every attachment of a revision.
+func DigestHash(digest string) string {
+	return DigestPrefix + digest
+}
+
+// ParseDigestHash function is returning the digest of every attachment and whether the record holds one, accepting the second hash of a record.
+// A legacy record holds the SHA-256 of the second image, which is not a digest.
+func ParseDigestHash(hash string) (string, bool) {
+	return strings.CutPrefix(hash, DigestPrefix)
+}
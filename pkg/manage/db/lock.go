@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"security-proof/pkg/constants"
+)
+
+// Locker interface is defining data to take an advisory lock shared by every instance.
+type Locker interface {
+	TryLock(ctx context.Context, key int64) (unlock func(), locked bool, err error)
+}
+
+// TryLock function is returning a function releasing the lock, whether the lock is taken and an error, accepting a context, a DB and a lock key.
+// The advisory lock is held by one connection until it is released, so only one instance runs the work guarded by the same key.
+func TryLock(ctx context.Context, db *sql.DB, key int64) (func(), bool, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, false, errors.Join(constants.ErrLock, err)
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked)
+	if err != nil || !locked {
+		if closeErr := conn.Close(); closeErr != nil {
+			log.Printf("Failed to close connection: %v", closeErr)
+		}
+		if err != nil {
+			return nil, false, errors.Join(constants.ErrLock, err)
+		}
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		// 잠금을 풀지 못해도 연결을 닫으면 세션과 함께 풀립니다.
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		if err != nil {
+			log.Printf("Failed to release lock %d: %v", key, err)
+		}
+		if err := conn.Close(); err != nil {
+			log.Printf("Failed to close connection: %v", err)
+		}
+	}
+	return unlock, true, nil
+}
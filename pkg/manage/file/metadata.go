@@ -0,0 +1,534 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+	"time"
+
+	"security-proof/pkg/constants"
+)
+
+const (
+	// maxMetadataChunkSize is the largest PNG metadata chunk read into memory.
+	maxMetadataChunkSize = 8 << 20
+	// maxMetadataTextSize is the largest decompressed PNG text read for metadata.
+	maxMetadataTextSize = 64 << 10
+	// exifTimeLayout is the layout of the date and time tags of EXIF.
+	exifTimeLayout = "2006:01:02 15:04:05"
+)
+
+// EXIF tags read or stripped.
+const (
+	exifTagMake               = 0x010f
+	exifTagModel              = 0x0110
+	exifTagSoftware           = 0x0131
+	exifTagDateTime           = 0x0132
+	exifTagExifIFD            = 0x8769
+	exifTagGPSIFD             = 0x8825
+	exifTagDateTimeOriginal   = 0x9003
+	exifTagOffsetTimeOriginal = 0x9011
+)
+
+var (
+	jpegExifHeader = []byte("Exif\x00\x00")
+	jpegXMPHeaders = [][]byte{[]byte("http://ns.adobe.com/xap/1.0/\x00"), []byte("http://ns.adobe.com/xmp/extension/\x00")}
+	pngSignature   = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// Metadata struct is composed of the capture time and the device fields embedded in an image,
+// and whether location data was removed from the stored copy.
+type Metadata struct {
+	CapturedAt      *time.Time
+	DeviceMake      string
+	DeviceModel     string
+	Software        string
+	LocationRemoved bool
+}
+
+// StripLocation function is returning the Metadata of an image and an error, accepting a writer, a reader and a detected MIME type.
+// The image is copied to the writer with the GPS data of its EXIF zeroed and its XMP packets, which may repeat the location, dropped.
+// Every other byte is copied as it is, so an image without location data is copied unchanged.
+func StripLocation(w io.Writer, r io.Reader, mimeType string) (*Metadata, error) {
+	var metadata *Metadata
+	var err error
+	switch mediaType(mimeType) {
+	case "image/jpeg":
+		metadata, err = stripJPEG(w, bufio.NewReader(r))
+	case "image/png":
+		metadata, err = stripPNG(w, bufio.NewReader(r))
+	default:
+		return nil, errors.Join(constants.ErrFileMetadata, fmt.Errorf("type %q", mimeType))
+	}
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileMetadata, err)
+	}
+	return metadata, nil
+}
+
+// stripJPEG function is returning the Metadata of a JPEG image and an error, accepting a writer and a reader.
+// The segments before the scan data are rewritten and the rest is copied as it is.
+func stripJPEG(w io.Writer, r *bufio.Reader) (*Metadata, error) {
+	metadata := &Metadata{}
+
+	soi := make([]byte, 2)
+	_, err := io.ReadFull(r, soi)
+	if err != nil {
+		return nil, err
+	}
+	if soi[0] != 0xff || soi[1] != 0xd8 {
+		return nil, errors.New("missing JPEG start of image")
+	}
+	_, err = w.Write(soi)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		marker, err := readJPEGMarker(r)
+		if err != nil {
+			return nil, err
+		}
+
+		// 길이가 없는 마커는 그대로 씁니다.
+		if marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7) {
+			_, err = w.Write([]byte{0xff, marker})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// 이미지 데이터가 시작되거나 끝나면 나머지는 그대로 복사합니다.
+		if marker == 0xd9 {
+			_, err = w.Write([]byte{0xff, marker})
+			if err != nil {
+				return nil, err
+			}
+			_, err = io.Copy(w, r)
+			return metadata, err
+		}
+
+		length := make([]byte, 2)
+		_, err = io.ReadFull(r, length)
+		if err != nil {
+			return nil, err
+		}
+		size := int(binary.BigEndian.Uint16(length))
+		if size < 2 {
+			return nil, fmt.Errorf("invalid JPEG segment length %d", size)
+		}
+		segment := make([]byte, size-2)
+		_, err = io.ReadFull(r, segment)
+		if err != nil {
+			return nil, err
+		}
+
+		if marker == 0xe1 && isXMP(segment) {
+			continue
+		}
+		if marker == 0xe1 && bytes.HasPrefix(segment, jpegExifHeader) {
+			err = stripExif(segment[len(jpegExifHeader):], metadata)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = w.Write(append([]byte{0xff, marker}, length...))
+		if err != nil {
+			return nil, err
+		}
+		_, err = w.Write(segment)
+		if err != nil {
+			return nil, err
+		}
+
+		if marker == 0xda {
+			_, err = io.Copy(w, r)
+			return metadata, err
+		}
+	}
+}
+
+// readJPEGMarker function is returning the next JPEG marker and an error, accepting a reader.
+// Fill bytes before a marker are skipped.
+func readJPEGMarker(r *bufio.Reader) (byte, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b != 0xff {
+		return 0, fmt.Errorf("invalid JPEG marker prefix %#x", b)
+	}
+	for {
+		b, err = r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != 0xff {
+			return b, nil
+		}
+	}
+}
+
+// isXMP function is returning whether a JPEG APP1 segment is an XMP packet, accepting the segment.
+func isXMP(segment []byte) bool {
+	for _, header := range jpegXMPHeaders {
+		if bytes.HasPrefix(segment, header) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripPNG function is returning the Metadata of a PNG image and an error, accepting a writer and a reader.
+// The image data chunks are streamed and only the metadata chunks are read into memory.
+func stripPNG(w io.Writer, r *bufio.Reader) (*Metadata, error) {
+	metadata := &Metadata{}
+
+	signature := make([]byte, len(pngSignature))
+	_, err := io.ReadFull(r, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(signature, pngSignature) {
+		return nil, errors.New("missing PNG signature")
+	}
+	_, err = w.Write(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		header := make([]byte, 8)
+		_, err = io.ReadFull(r, header)
+		if errors.Is(err, io.EOF) {
+			return metadata, nil
+		} else if err != nil {
+			return nil, err
+		}
+		length := binary.BigEndian.Uint32(header[:4])
+		chunkType := string(header[4:])
+
+		switch chunkType {
+		case "eXIf", "tEXt", "zTXt", "iTXt":
+			if length > maxMetadataChunkSize {
+				return nil, fmt.Errorf("PNG %s chunk of %d bytes", chunkType, length)
+			}
+			chunk := make([]byte, length+4)
+			_, err = io.ReadFull(r, chunk)
+			if err != nil {
+				return nil, err
+			}
+			data := chunk[:length]
+
+			keep, err := readPNGMetadata(chunkType, data, metadata)
+			if err != nil {
+				return nil, err
+			}
+			if !keep {
+				continue
+			}
+
+			// 위치 정보를 지웠을 수 있으므로 CRC를 다시 계산합니다.
+			crc := crc32.NewIEEE()
+			_, _ = crc.Write(header[4:])
+			_, _ = crc.Write(data)
+			binary.BigEndian.PutUint32(chunk[length:], crc.Sum32())
+
+			_, err = w.Write(header)
+			if err != nil {
+				return nil, err
+			}
+			_, err = w.Write(chunk)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			_, err = w.Write(header)
+			if err != nil {
+				return nil, err
+			}
+			_, err = io.CopyN(w, r, int64(length)+4)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// 이미지가 끝난 뒤의 데이터는 그대로 복사합니다.
+		if chunkType == "IEND" {
+			_, err = io.Copy(w, r)
+			return metadata, err
+		}
+	}
+}
+
+// readPNGMetadata function is returning whether a PNG metadata chunk is kept and an error, accepting a chunk type, its data and a Metadata to fill.
+// The GPS data of an eXIf chunk is zeroed in place, and XMP and raw profile text chunks are dropped.
+func readPNGMetadata(chunkType string, data []byte, metadata *Metadata) (bool, error) {
+	if chunkType == "eXIf" {
+		return true, stripExif(data, metadata)
+	}
+
+	keyword, text, err := pngText(chunkType, data)
+	if err != nil {
+		return false, err
+	}
+
+	switch keyword {
+	case "XML:com.adobe.xmp", "Raw profile type exif", "Raw profile type APP1", "Raw profile type xmp":
+		return false, nil
+	case "Creation Time":
+		if capturedAt, ok := parsePNGTime(text); ok && metadata.CapturedAt == nil {
+			metadata.CapturedAt = &capturedAt
+		}
+	case "Software":
+		if metadata.Software == "" {
+			metadata.Software = text
+		}
+	case "Source":
+		if metadata.DeviceModel == "" {
+			metadata.DeviceModel = text
+		}
+	}
+	return true, nil
+}
+
+// pngText function is returning the keyword, the text and an error, accepting a tEXt, zTXt or iTXt chunk type and its data.
+// The text of a compressed chunk is only read up to maxMetadataTextSize.
+func pngText(chunkType string, data []byte) (string, string, error) {
+	keyword, rest, ok := bytes.Cut(data, []byte{0})
+	if !ok {
+		return "", "", fmt.Errorf("PNG %s chunk without keyword", chunkType)
+	}
+
+	compressed := false
+	switch chunkType {
+	case "zTXt":
+		if len(rest) < 1 {
+			return "", "", errors.New("PNG zTXt chunk without compression method")
+		}
+		compressed, rest = true, rest[1:]
+	case "iTXt":
+		if len(rest) < 2 {
+			return "", "", errors.New("PNG iTXt chunk without compression flag")
+		}
+		compressed = rest[0] == 1
+		// 언어 태그와 번역된 키워드를 건너뜁니다.
+		fields := bytes.SplitN(rest[2:], []byte{0}, 3)
+		if len(fields) != 3 {
+			return "", "", errors.New("PNG iTXt chunk without text")
+		}
+		rest = fields[2]
+	}
+
+	if !compressed {
+		return string(keyword), strings.TrimSpace(string(rest)), nil
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(rest))
+	if err != nil {
+		return "", "", err
+	}
+	defer reader.Close()
+	text, err := io.ReadAll(io.LimitReader(reader, maxMetadataTextSize))
+	if err != nil {
+		return "", "", err
+	}
+	return string(keyword), strings.TrimSpace(string(text)), nil
+}
+
+// parsePNGTime function is returning a time and whether it is parsed, accepting the text of a Creation Time chunk.
+// The PNG specification recommends RFC 1123, but EXIF and ISO 8601 layouts are common as well.
+func parsePNGTime(text string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123, time.RFC3339, "2006-01-02T15:04:05", exifTimeLayout} {
+		parsed, err := time.Parse(layout, text)
+		if err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// tiff struct is composed of a TIFF structure of EXIF and its byte order.
+type tiff struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// tiffEntry struct is composed of a tag, a type, a count and the offsets of an IFD entry and of its value.
+type tiffEntry struct {
+	tag         uint16
+	kind        uint16
+	count       uint32
+	offset      int
+	valueOffset int
+	valueSize   int
+}
+
+// stripExif function is returning an error, accepting a TIFF structure of EXIF and a Metadata to fill.
+// The entries and values of the GPS IFD are zeroed in place so that the length of the structure does not change.
+func stripExif(data []byte, metadata *Metadata) error {
+	if len(data) < 8 {
+		return errors.New("EXIF is too short")
+	}
+	t := &tiff{data: data}
+	switch string(data[:4]) {
+	case "II*\x00":
+		t.order = binary.LittleEndian
+	case "MM\x00*":
+		t.order = binary.BigEndian
+	default:
+		return errors.New("invalid EXIF byte order")
+	}
+
+	ifd0, err := t.entries(int(t.order.Uint32(data[4:8])))
+	if err != nil {
+		return err
+	}
+
+	var dateTime, dateTimeOriginal, offsetTimeOriginal string
+	for _, entry := range ifd0 {
+		switch entry.tag {
+		case exifTagMake:
+			metadata.DeviceMake = t.ascii(entry)
+		case exifTagModel:
+			metadata.DeviceModel = t.ascii(entry)
+		case exifTagSoftware:
+			metadata.Software = t.ascii(entry)
+		case exifTagDateTime:
+			dateTime = t.ascii(entry)
+		case exifTagExifIFD:
+			exifIFD, err := t.entries(int(t.order.Uint32(t.data[entry.valueOffset:])))
+			if err != nil {
+				return err
+			}
+			for _, exifEntry := range exifIFD {
+				switch exifEntry.tag {
+				case exifTagDateTimeOriginal:
+					dateTimeOriginal = t.ascii(exifEntry)
+				case exifTagOffsetTimeOriginal:
+					offsetTimeOriginal = t.ascii(exifEntry)
+				}
+			}
+		case exifTagGPSIFD:
+			removed, err := t.zeroIFD(int(t.order.Uint32(t.data[entry.valueOffset:])))
+			if err != nil {
+				return err
+			}
+			metadata.LocationRemoved = metadata.LocationRemoved || removed
+		}
+	}
+
+	// 촬영 시각이 없으면 파일이 바뀐 시각을 씁니다.
+	if dateTimeOriginal == "" {
+		dateTimeOriginal, offsetTimeOriginal = dateTime, ""
+	}
+	if capturedAt, ok := parseExifTime(dateTimeOriginal, offsetTimeOriginal); ok {
+		metadata.CapturedAt = &capturedAt
+	}
+	return nil
+}
+
+// entries method is returning the entries of an IFD and an error, accepting the offset of the IFD.
+func (t *tiff) entries(offset int) ([]*tiffEntry, error) {
+	if offset < 8 || offset+2 > len(t.data) {
+		return nil, fmt.Errorf("EXIF IFD offset %d out of range", offset)
+	}
+	count := int(t.order.Uint16(t.data[offset:]))
+	if offset+2+count*12 > len(t.data) {
+		return nil, fmt.Errorf("EXIF IFD of %d entries out of range", count)
+	}
+
+	entries := make([]*tiffEntry, 0, count)
+	for i := 0; i < count; i++ {
+		entryOffset := offset + 2 + i*12
+		entry := &tiffEntry{
+			tag:    t.order.Uint16(t.data[entryOffset:]),
+			kind:   t.order.Uint16(t.data[entryOffset+2:]),
+			count:  t.order.Uint32(t.data[entryOffset+4:]),
+			offset: entryOffset,
+		}
+
+		size := uint64(tiffTypeSize(entry.kind)) * uint64(entry.count)
+		entry.valueOffset = entryOffset + 8
+		if size > 4 {
+			entry.valueOffset = int(t.order.Uint32(t.data[entryOffset+8:]))
+		}
+		if size > uint64(len(t.data)) || entry.valueOffset+int(size) > len(t.data) {
+			return nil, fmt.Errorf("EXIF tag %#x value out of range", entry.tag)
+		}
+		entry.valueSize = int(size)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// zeroIFD method is returning whether the IFD had entries and an error, accepting the offset of the IFD.
+// The IFD is left empty, and its entries and their values are overwritten with zeros.
+func (t *tiff) zeroIFD(offset int) (bool, error) {
+	entries, err := t.entries(offset)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		clear(t.data[entry.valueOffset : entry.valueOffset+entry.valueSize])
+		clear(t.data[entry.offset : entry.offset+12])
+	}
+	t.order.PutUint16(t.data[offset:], 0)
+	return len(entries) > 0, nil
+}
+
+// ascii method is returning the text of an ASCII entry without padding, accepting a tiffEntry.
+func (t *tiff) ascii(entry *tiffEntry) string {
+	if entry.kind != 2 {
+		return ""
+	}
+	value := t.data[entry.valueOffset : entry.valueOffset+entry.valueSize]
+	if end := bytes.IndexByte(value, 0); end >= 0 {
+		value = value[:end]
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// tiffTypeSize function is returning the size of a value of a TIFF type, accepting the type.
+// An unknown type has no size, so that only its entry is zeroed.
+func tiffTypeSize(kind uint16) int {
+	switch kind {
+	case 1, 2, 6, 7:
+		return 1
+	case 3, 8:
+		return 2
+	case 4, 9, 11:
+		return 4
+	case 5, 10, 12:
+		return 8
+	}
+	return 0
+}
+
+// parseExifTime function is returning a time and whether it is parsed, accepting an EXIF date time and an optional offset such as "+09:00".
+// A time without an offset is taken as UTC since EXIF records the local time of the device.
+func parseExifTime(dateTime string, offset string) (time.Time, bool) {
+	if dateTime == "" {
+		return time.Time{}, false
+	}
+	if offset != "" {
+		parsed, err := time.Parse(exifTimeLayout+"-07:00", dateTime+offset)
+		if err == nil {
+			return parsed, true
+		}
+	}
+	parsed, err := time.Parse(exifTimeLayout, dateTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
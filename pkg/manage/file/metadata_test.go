@@ -0,0 +1,232 @@
+package file
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+// testLatitude is the GPS latitude of the test images, 37° 33' 59".
+var testLatitude = []byte{37, 0, 0, 0, 1, 0, 0, 0, 33, 0, 0, 0, 1, 0, 0, 0, 59, 0, 0, 0, 1, 0, 0, 0}
+
+// testIFDEntry struct is composed of a tag, a type, a count and a value of an IFD entry to build.
+type testIFDEntry struct {
+	tag   uint16
+	kind  uint16
+	count uint32
+	value []byte
+}
+
+// testASCII function is returning an ASCII testIFDEntry, accepting a tag and a text.
+func testASCII(tag uint16, text string) testIFDEntry {
+	return testIFDEntry{tag: tag, kind: 2, count: uint32(len(text) + 1), value: append([]byte(text), 0)}
+}
+
+// testExif function is returning a little endian TIFF structure, accepting the entries of IFD0, the Exif IFD and the GPS IFD.
+// The pointers to the Exif IFD and the GPS IFD are added to IFD0 when they are not nil.
+func testExif(ifd0 []testIFDEntry, exifIFD []testIFDEntry, gpsIFD []testIFDEntry) []byte {
+	ifds := [][]testIFDEntry{ifd0, exifIFD, gpsIFD}
+	pointerTags := []uint16{0, exifTagExifIFD, exifTagGPSIFD}
+
+	offsets := make([]uint32, len(ifds))
+	offset := uint32(8)
+	for i, ifd := range ifds {
+		if i > 0 && ifd == nil {
+			continue
+		}
+		offsets[i] = offset
+		count := len(ifd)
+		if i == 0 {
+			count += len(pointerTags) - 1
+		}
+		offset += uint32(2 + count*12 + 4)
+	}
+
+	data := &bytes.Buffer{}
+	values := &bytes.Buffer{}
+	data.Write([]byte("II*\x00"))
+	_ = binary.Write(data, binary.LittleEndian, uint32(8))
+
+	for i, ifd := range ifds {
+		if i > 0 && ifd == nil {
+			continue
+		}
+		entries := append([]testIFDEntry{}, ifd...)
+		if i == 0 {
+			for j := 1; j < len(ifds); j++ {
+				if ifds[j] != nil {
+					pointer := binary.LittleEndian.AppendUint32(nil, offsets[j])
+					entries = append(entries, testIFDEntry{tag: pointerTags[j], kind: 4, count: 1, value: pointer})
+				}
+			}
+		}
+
+		_ = binary.Write(data, binary.LittleEndian, uint16(len(entries)))
+		for _, entry := range entries {
+			_ = binary.Write(data, binary.LittleEndian, entry.tag)
+			_ = binary.Write(data, binary.LittleEndian, entry.kind)
+			_ = binary.Write(data, binary.LittleEndian, entry.count)
+			if len(entry.value) <= 4 {
+				data.Write(append(entry.value, make([]byte, 4-len(entry.value))...))
+				continue
+			}
+			_ = binary.Write(data, binary.LittleEndian, offset+uint32(values.Len()))
+			values.Write(entry.value)
+		}
+		_ = binary.Write(data, binary.LittleEndian, uint32(0))
+	}
+
+	data.Write(values.Bytes())
+	return data.Bytes()
+}
+
+// testPhotoExif function is returning the EXIF of a phone photo taken at a location.
+func testPhotoExif() []byte {
+	return testExif(
+		[]testIFDEntry{testASCII(exifTagMake, "Apple"), testASCII(exifTagModel, "iPhone 15"), testASCII(exifTagDateTime, "2024:06:01 00:00:00")},
+		[]testIFDEntry{testASCII(exifTagDateTimeOriginal, "2024:05:01 09:30:00"), testASCII(exifTagOffsetTimeOriginal, "+09:00")},
+		[]testIFDEntry{testASCII(1, "N"), {tag: 2, kind: 5, count: 3, value: testLatitude}},
+	)
+}
+
+// testImage function is returning a small gray image.
+func testImage() image.Image {
+	img := image.NewGray(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		img.Pix[i] = 0x80
+	}
+	return img
+}
+
+// testJPEG function is returning a JPEG image with APP1 segments inserted after the start of image, accepting the segments.
+func testJPEG(t *testing.T, segments ...[]byte) []byte {
+	encoded := &bytes.Buffer{}
+	assert.NoError(t, jpeg.Encode(encoded, testImage(), nil))
+
+	data := append([]byte{}, encoded.Bytes()[:2]...)
+	for _, segment := range segments {
+		data = append(data, 0xff, 0xe1)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(segment)+2))
+		data = append(data, segment...)
+	}
+	return append(data, encoded.Bytes()[2:]...)
+}
+
+// testPNGChunk function is returning a PNG chunk, accepting a chunk type and a data.
+func testPNGChunk(chunkType string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// testPNGWithChunks function is returning a PNG image with chunks inserted after the header chunk, accepting the chunks.
+func testPNGWithChunks(t *testing.T, chunks ...[]byte) []byte {
+	encoded := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(encoded, testImage()))
+
+	// 시그니처 8바이트와 IHDR 25바이트 뒤에 넣습니다.
+	data := append([]byte{}, encoded.Bytes()[:33]...)
+	for _, chunk := range chunks {
+		data = append(data, chunk...)
+	}
+	return append(data, encoded.Bytes()[33:]...)
+}
+
+func TestStripLocation(t *testing.T) {
+	t.Run("JPEG 위치 정보 제거 케이스", func(t *testing.T) {
+		xmp := append([]byte("http://ns.adobe.com/xap/1.0/\x00"), `<exif:GPSLatitude>37,33.98N</exif:GPSLatitude>`...)
+		photo := testJPEG(t, append(append([]byte{}, jpegExifHeader...), testPhotoExif()...), xmp)
+
+		stripped := &bytes.Buffer{}
+		metadata, err := StripLocation(stripped, bytes.NewReader(photo), "image/jpeg")
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		assert.Equal(t, "Apple", metadata.DeviceMake)
+		assert.Equal(t, "iPhone 15", metadata.DeviceModel)
+		assert.True(t, metadata.LocationRemoved)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 30, 0, 0, time.UTC), metadata.CapturedAt.UTC(), "촬영 시각이 시간대와 함께 읽혔습니다.")
+
+		assert.False(t, bytes.Contains(stripped.Bytes(), testLatitude), "좌표가 저장될 사본에서 지워졌습니다.")
+		assert.False(t, bytes.Contains(stripped.Bytes(), []byte("GPSLatitude")), "XMP가 제거되었습니다.")
+		assert.Equal(t, len(photo)-len(xmp)-4, stripped.Len(), "EXIF는 길이를 유지한 채 지워졌습니다.")
+
+		_, err = jpeg.Decode(bytes.NewReader(stripped.Bytes()))
+		assert.NoError(t, err, "위치 정보를 지운 사본도 이미지로 읽힙니다.")
+
+		again := &bytes.Buffer{}
+		metadata, err = StripLocation(again, bytes.NewReader(stripped.Bytes()), "image/jpeg")
+		assert.NoError(t, err)
+		assert.False(t, metadata.LocationRemoved)
+		assert.Equal(t, "iPhone 15", metadata.DeviceModel, "기기 정보는 사본에 남았습니다.")
+		assert.Equal(t, stripped.Bytes(), again.Bytes(), "이미 지운 사본은 바뀌지 않습니다.")
+	})
+
+	t.Run("PNG 위치 정보 제거 케이스", func(t *testing.T) {
+		screenshot := testPNGWithChunks(t,
+			testPNGChunk("eXIf", testPhotoExif()),
+			testPNGChunk("tEXt", []byte("Software\x00Snipping Tool")),
+			testPNGChunk("tEXt", []byte("Creation Time\x00Wed, 01 May 2024 09:30:00 +0900")),
+			testPNGChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<exif:GPSLatitude/>")),
+		)
+
+		stripped := &bytes.Buffer{}
+		metadata, err := StripLocation(stripped, bytes.NewReader(screenshot), "image/png")
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		assert.Equal(t, "Apple", metadata.DeviceMake)
+		assert.Equal(t, "iPhone 15", metadata.DeviceModel)
+		assert.Equal(t, "Snipping Tool", metadata.Software, "텍스트 청크에서 소프트웨어를 읽었습니다.")
+		assert.True(t, metadata.LocationRemoved)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 30, 0, 0, time.UTC), metadata.CapturedAt.UTC())
+
+		assert.False(t, bytes.Contains(stripped.Bytes(), testLatitude), "좌표가 저장될 사본에서 지워졌습니다.")
+		assert.False(t, bytes.Contains(stripped.Bytes(), []byte("GPSLatitude")), "XMP가 제거되었습니다.")
+
+		_, err = png.Decode(bytes.NewReader(stripped.Bytes()))
+		assert.NoError(t, err, "CRC가 다시 계산되어 이미지로 읽힙니다.")
+	})
+
+	t.Run("메타데이터가 없는 이미지 케이스", func(t *testing.T) {
+		encoded := &bytes.Buffer{}
+		assert.NoError(t, png.Encode(encoded, testImage()))
+
+		stripped := &bytes.Buffer{}
+		metadata, err := StripLocation(stripped, bytes.NewReader(encoded.Bytes()), "image/png")
+		assert.NoError(t, err)
+		assert.Nil(t, metadata.CapturedAt)
+		assert.Empty(t, metadata.DeviceModel)
+		assert.Equal(t, encoded.Bytes(), stripped.Bytes(), "메타데이터가 없으면 그대로 복사됩니다.")
+	})
+
+	t.Run("잘린 EXIF 케이스", func(t *testing.T) {
+		exif := testPhotoExif()
+		photo := testJPEG(t, append(append([]byte{}, jpegExifHeader...), exif[:40]...))
+
+		_, err := StripLocation(&bytes.Buffer{}, bytes.NewReader(photo), "image/jpeg")
+		assert.ErrorIs(t, err, constants.ErrFileMetadata, "위치 정보를 지웠는지 알 수 없는 파일은 거부합니다.")
+	})
+
+	t.Run("이미지가 아닌 케이스", func(t *testing.T) {
+		_, err := StripLocation(&bytes.Buffer{}, bytes.NewReader([]byte("%PDF-1.7")), "application/pdf")
+		assert.ErrorIs(t, err, constants.ErrFileMetadata)
+	})
+}
+
+func TestParseExifTime(t *testing.T) {
+	parsed, ok := parseExifTime("2024:05:01 09:30:00", "")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC), parsed, "시간대가 없으면 UTC로 읽습니다.")
+
+	_, ok = parseExifTime("0000:00:00 00:00:00", "")
+	assert.False(t, ok, "비어 있는 날짜는 읽지 않습니다.")
+}
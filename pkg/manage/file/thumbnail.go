@@ -0,0 +1,105 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	"security-proof/pkg/constants"
+)
+
+const (
+	// ThumbnailSize is the largest width and height of a thumbnail in pixels.
+	ThumbnailSize = 256
+	// ThumbnailType is the MIME type of every thumbnail.
+	ThumbnailType = "image/jpeg"
+	// thumbnailQuality is the JPEG quality of a thumbnail.
+	thumbnailQuality = 80
+	// maxThumbnailPixels is the largest image decoded for a thumbnail, which bounds the memory used by a decode.
+	maxThumbnailPixels = 50_000_000
+)
+
+// IsImage function is returning whether a thumbnail can be made, accepting a detected MIME type.
+func IsImage(mimeType string) bool {
+	switch mediaType(mimeType) {
+	case "image/png", "image/jpeg":
+		return true
+	}
+	return false
+}
+
+// Thumbnail function is returning a JPEG thumbnail and an error, accepting a PNG or JPEG image.
+// The image is scaled down to fit in ThumbnailSize keeping its aspect ratio and is never scaled up.
+// Transparent pixels are drawn over white since JPEG has no alpha channel.
+func Thumbnail(r io.ReadSeeker) ([]byte, error) {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileThumbnail, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > maxThumbnailPixels {
+		return nil, errors.Join(constants.ErrFileThumbnail, fmt.Errorf("image of %dx%d pixels", config.Width, config.Height))
+	}
+
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileThumbnail, err)
+	}
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileThumbnail, err)
+	}
+
+	buffer := &bytes.Buffer{}
+	err = jpeg.Encode(buffer, scaleDown(src, ThumbnailSize), &jpeg.Options{Quality: thumbnailQuality})
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileThumbnail, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// scaleDown function is returning an image fitting in a square, accepting an image and the side of the square.
+// Each pixel of the result is the average of the source pixels it covers.
+func scaleDown(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if width > size || height > size {
+		if width >= height {
+			dstWidth, dstHeight = size, max(1, height*size/width)
+		} else {
+			dstWidth, dstHeight = max(1, width*size/height), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := bounds.Min.Y+y*height/dstHeight, bounds.Min.Y+max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := bounds.Min.X+x*width/dstWidth, bounds.Min.X+max((x+1)*width/dstWidth, x*width/dstWidth+1)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+
+			// 미리 곱해진 색상에 투명한 만큼 흰색을 더합니다.
+			white := 0xffff*count - a
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r + white) / count >> 8),
+				G: uint8((g + white) / count >> 8),
+				B: uint8((b + white) / count >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
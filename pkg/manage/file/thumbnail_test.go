@@ -0,0 +1,74 @@
+package file
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+// testPNG function is returning a PNG image of a size filled with a color.
+func testPNG(t *testing.T, width int, height int, fill color.Color) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buffer, img))
+	return buffer.Bytes()
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Run("축소 케이스", func(t *testing.T) {
+		thumbnail, err := Thumbnail(bytes.NewReader(testPNG(t, 1024, 512, color.NRGBA{R: 255, A: 255})))
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, ThumbnailType, DetectType(thumbnail))
+
+		config, format, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, ThumbnailSize, config.Width, "가로 세로 비율을 유지하며 축소되었습니다.")
+		assert.Equal(t, ThumbnailSize/2, config.Height)
+	})
+
+	t.Run("작은 이미지 케이스", func(t *testing.T) {
+		thumbnail, err := Thumbnail(bytes.NewReader(testPNG(t, 10, 20, color.NRGBA{A: 255})))
+		assert.NoError(t, err)
+
+		config, _, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+		assert.NoError(t, err)
+		assert.Equal(t, 10, config.Width, "작은 이미지는 확대하지 않습니다.")
+		assert.Equal(t, 20, config.Height)
+	})
+
+	t.Run("투명 이미지 케이스", func(t *testing.T) {
+		thumbnail, err := Thumbnail(bytes.NewReader(testPNG(t, 8, 8, color.NRGBA{})))
+		assert.NoError(t, err)
+
+		img, _, err := image.Decode(bytes.NewReader(thumbnail))
+		assert.NoError(t, err)
+		r, g, b, _ := img.At(4, 4).RGBA()
+		assert.Greater(t, r>>8, uint32(0xf0), "투명한 픽셀은 흰색이 되었습니다.")
+		assert.Greater(t, g>>8, uint32(0xf0))
+		assert.Greater(t, b>>8, uint32(0xf0))
+	})
+
+	t.Run("이미지가 아닌 케이스", func(t *testing.T) {
+		_, err := Thumbnail(bytes.NewReader([]byte("connection refused")))
+		assert.ErrorIs(t, err, constants.ErrFileThumbnail)
+	})
+}
+
+func TestIsImage(t *testing.T) {
+	assert.True(t, IsImage("image/png"))
+	assert.True(t, IsImage("image/jpeg"))
+	assert.False(t, IsImage("application/pdf"), "PDF는 썸네일을 만들지 않습니다.")
+	assert.False(t, IsImage("text/plain; charset=utf-8"))
+}
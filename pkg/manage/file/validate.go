@@ -0,0 +1,103 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Netflix/go-env"
+
+	"security-proof/pkg/constants"
+)
+
+// SniffSize is the number of leading bytes DetectType looks at.
+const SniffSize = 512
+
+// Config struct is composed of the largest file size and the MIME types accepted on upload.
+type Config struct {
+	MaxSize      int64    `env:"UPLOAD_MAX_FILE_SIZE,default=52428800"`
+	AllowedTypes []string `env:"UPLOAD_ALLOWED_TYPES,default=image/png|image/jpeg|application/pdf|text/plain"`
+}
+
+// FromEnv method is returning a Config.
+func (c *Config) FromEnv() *Config {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
+
+// Validator struct is composed of the largest file size and the accepted media types.
+type Validator struct {
+	maxSize int64
+	allowed map[string]bool
+}
+
+// NewValidator function is returning a Validator, accepting a Config.
+func NewValidator(config *Config) *Validator {
+	allowed := make(map[string]bool, len(config.AllowedTypes))
+	for _, allowedType := range config.AllowedTypes {
+		allowed[mediaType(allowedType)] = true
+	}
+	return &Validator{maxSize: config.MaxSize, allowed: allowed}
+}
+
+// MaxSize method is returning the largest file size accepted.
+func (v *Validator) MaxSize() int64 {
+	return v.maxSize
+}
+
+// Validate method is returning an error, accepting a detected MIME type and a file size.
+// The parameters of the MIME type, such as a charset, are not compared.
+func (v *Validator) Validate(mimeType string, size int64) error {
+	if size == 0 {
+		return constants.ErrFileEmpty
+	}
+	if size > v.maxSize {
+		return errors.Join(constants.ErrFileTooLarge, fmt.Errorf("larger than %d bytes", v.maxSize))
+	}
+	if !v.allowed[mediaType(mimeType)] {
+		return errors.Join(constants.ErrFileType, fmt.Errorf("type %q", mimeType))
+	}
+	return nil
+}
+
+// ValidateText method is returning an error, accepting a detected MIME type and a file size.
+// A text file such as a log is accepted as plain text whatever the accepted media types are.
+func (v *Validator) ValidateText(mimeType string, size int64) error {
+	if size == 0 {
+		return constants.ErrFileEmpty
+	}
+	if size > v.maxSize {
+		return errors.Join(constants.ErrFileTooLarge, fmt.Errorf("larger than %d bytes", v.maxSize))
+	}
+	if !IsText(mimeType) {
+		return errors.Join(constants.ErrFileType, fmt.Errorf("type %q is not plain text", mimeType))
+	}
+	return nil
+}
+
+// IsText function is returning whether a MIME type is plain text, accepting a MIME type.
+func IsText(mimeType string) bool {
+	return mediaType(mimeType) == "text/plain"
+}
+
+// DetectType function is returning a MIME type sniffed from the content, accepting the leading bytes of a file.
+// The file name and the type claimed by the client are never trusted.
+func DetectType(head []byte) string {
+	return http.DetectContentType(head)
+}
+
+// mediaType function is returning a lower cased media type without parameters, accepting a MIME type.
+func mediaType(mimeType string) string {
+	media, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(mimeType))
+	}
+	return media
+}
@@ -0,0 +1,55 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	validator := NewValidator(&Config{MaxSize: 16, AllowedTypes: []string{"image/png", "text/plain"}})
+
+	t.Run("허용된 형식 케이스", func(t *testing.T) {
+		err := validator.Validate(DetectType([]byte("\x89PNG\r\n\x1a\n")), 8)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		err = validator.Validate(DetectType([]byte("connection refused")), 16)
+		assert.NoError(t, err, "문자 집합이 붙은 형식도 허용되었습니다.")
+	})
+
+	t.Run("허용되지 않은 형식 케이스", func(t *testing.T) {
+		err := validator.Validate(DetectType([]byte("<html></html>")), 13)
+		assert.ErrorIs(t, err, constants.ErrFileType)
+	})
+
+	t.Run("빈 파일 케이스", func(t *testing.T) {
+		err := validator.Validate("text/plain", 0)
+		assert.ErrorIs(t, err, constants.ErrFileEmpty)
+	})
+
+	t.Run("최대 크기 초과 케이스", func(t *testing.T) {
+		err := validator.Validate("text/plain", 17)
+		assert.ErrorIs(t, err, constants.ErrFileTooLarge)
+	})
+}
+
+func TestValidator_ValidateText(t *testing.T) {
+	validator := NewValidator(&Config{MaxSize: 16, AllowedTypes: []string{"image/png"}})
+
+	t.Run("텍스트 로그 케이스", func(t *testing.T) {
+		err := validator.ValidateText(DetectType([]byte("exit status 0\n")), 14)
+		assert.NoError(t, err, "허용 형식에 없어도 텍스트는 로그로 받습니다.")
+	})
+
+	t.Run("텍스트가 아닌 로그 케이스", func(t *testing.T) {
+		err := validator.ValidateText(DetectType([]byte("\x89PNG\r\n\x1a\n")), 8)
+		assert.ErrorIs(t, err, constants.ErrFileType)
+	})
+
+	t.Run("최대 크기 초과 케이스", func(t *testing.T) {
+		err := validator.ValidateText("text/plain", 17)
+		assert.ErrorIs(t, err, constants.ErrFileTooLarge)
+	})
+}
@@ -0,0 +1,26 @@
+// Package notify is a package for handling reminder notification processes.
+package notify
+
+import (
+	"log"
+	"time"
+
+	"github.com/Netflix/go-env"
+)
+
+// Config struct is composed of a scan interval, a due soon window and a repeat interval.
+type Config struct {
+	Interval time.Duration `env:"REMINDER_INTERVAL,default=1h"`
+	DueSoon  time.Duration `env:"REMINDER_DUE_SOON,default=72h"`
+	Repeat   time.Duration `env:"REMINDER_REPEAT,default=24h"`
+}
+
+// FromEnv method is returning a Config.
+func (c *Config) FromEnv() *Config {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
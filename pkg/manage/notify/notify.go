@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Reminder struct is composed of a proof index, a num, a category, an assignee index, a due date and whether it is overdue.
+type Reminder struct {
+	ProofIdx    int32
+	Num         string
+	Category    string
+	AssigneeIdx int32
+	DueAt       time.Time
+	Overdue     bool
+}
+
+// Notifier interface is defining the delivery of a reminder to the assignee of a proof.
+type Notifier interface {
+	Notify(ctx context.Context, reminder *Reminder) error
+}
+
+// LogNotifier struct is a Notifier writing reminders to a logger.
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier function is returning a LogNotifier, accepting a logger.
+// The standard logger is used when the logger is nil.
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogNotifier{logger: logger}
+}
+
+// Notify method is writing a reminder, accepting a context and a reminder.
+func (n *LogNotifier) Notify(_ context.Context, reminder *Reminder) error {
+	kind := "due soon"
+	if reminder.Overdue {
+		kind = "overdue"
+	}
+	n.logger.Printf("proof %d (%s %s) is %s for user %d, due at %s",
+		reminder.ProofIdx, reminder.Num, reminder.Category, kind, reminder.AssigneeIdx, reminder.DueAt.Format(time.RFC3339))
+	return nil
+}
+
+// MemoryNotifier struct is a Notifier keeping reminders in memory.
+type MemoryNotifier struct {
+	mu        sync.Mutex
+	reminders []*Reminder
+}
+
+// NewMemoryNotifier function is returning a MemoryNotifier.
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+// Notify method is keeping a reminder, accepting a context and a reminder.
+func (n *MemoryNotifier) Notify(_ context.Context, reminder *Reminder) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	copied := *reminder
+	n.reminders = append(n.reminders, &copied)
+	return nil
+}
+
+// Reminders method is returning every reminder kept so far.
+func (n *MemoryNotifier) Reminders() []*Reminder {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]*Reminder(nil), n.reminders...)
+}
@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogNotifier_Notify(t *testing.T) {
+	t.Run("기한 초과 알림 로그 케이스", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		notifier := NewLogNotifier(log.New(buf, "", 0))
+
+		err := notifier.Notify(context.Background(), &Reminder{ProofIdx: 1, Num: "2.1.1", Category: "정책", AssigneeIdx: 3, DueAt: time.Now(), Overdue: true})
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Contains(t, buf.String(), "proof 1 (2.1.1 정책) is overdue for user 3")
+	})
+}
+
+func TestMemoryNotifier_Notify(t *testing.T) {
+	t.Run("알림 보관 케이스", func(t *testing.T) {
+		notifier := NewMemoryNotifier()
+		reminder := &Reminder{ProofIdx: 1}
+
+		err := notifier.Notify(context.Background(), reminder)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		reminder.ProofIdx = 2
+		reminders := notifier.Reminders()
+		assert.Len(t, reminders, 1)
+		assert.Equal(t, int32(1), reminders[0].ProofIdx, "보관된 알림은 이후 변경에 영향을 받지 않습니다.")
+	})
+}
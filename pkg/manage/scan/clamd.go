@@ -0,0 +1,126 @@
+package scan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"security-proof/pkg/constants"
+)
+
+// clamdChunkSize is the size of the chunks a file is streamed in, which is far below the default StreamMaxLength of clamd.
+const clamdChunkSize = 64 << 10
+
+// Clamd struct is a Scanner streaming files to a clamd daemon with the INSTREAM command, composed of a network, an address and a timeout.
+type Clamd struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamd function is returning a Clamd, accepting a network of tcp or unix, an address and a timeout of a scan.
+func NewClamd(network string, address string, timeout time.Duration) *Clamd {
+	return &Clamd{network: network, address: address, timeout: timeout}
+}
+
+// Scan method is returning a Result and an error, accepting a context and a reader.
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileScan, err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// 컨텍스트가 끝나면 연결을 닫아 읽기와 쓰기를 멈춥니다.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	response, err := c.instream(conn, r)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, errors.Join(constants.ErrFileScan, ctx.Err())
+		}
+		return nil, errors.Join(constants.ErrFileScan, err)
+	}
+	return parseClamdResponse(response)
+}
+
+// instream method is returning the response of clamd and an error, accepting a connection and a reader.
+// Each chunk is prefixed with its length, and a zero length chunk ends the stream.
+func (c *Clamd) instream(conn net.Conn, r io.Reader) (string, error) {
+	writer := bufio.NewWriterSize(conn, clamdChunkSize+4)
+	_, err := writer.WriteString("zINSTREAM\x00")
+	if err != nil {
+		return "", err
+	}
+
+	chunk := make([]byte, clamdChunkSize)
+	for {
+		n, readErr := r.Read(chunk)
+		if n > 0 {
+			err = binary.Write(writer, binary.BigEndian, uint32(n))
+			if err != nil {
+				return "", err
+			}
+			_, err = writer.Write(chunk[:n])
+			if err != nil {
+				return "", err
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return "", readErr
+		}
+	}
+
+	err = binary.Write(writer, binary.BigEndian, uint32(0))
+	if err != nil {
+		return "", err
+	}
+	err = writer.Flush()
+	if err != nil {
+		return "", err
+	}
+
+	// z 접두사를 쓴 명령의 응답은 NUL로 끝납니다.
+	response, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return response, nil
+}
+
+// parseClamdResponse function is returning a Result and an error, accepting a response of the INSTREAM command.
+func parseClamdResponse(response string) (*Result, error) {
+	response = strings.TrimSpace(strings.TrimRight(response, "\x00"))
+	result := strings.TrimPrefix(response, "stream: ")
+
+	switch {
+	case result == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		// 크기 제한을 넘거나 clamd가 검사하지 못한 파일은 결과를 알 수 없습니다.
+		return nil, errors.Join(constants.ErrFileScan, fmt.Errorf("clamd: %q", response))
+	}
+}
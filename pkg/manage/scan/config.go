@@ -0,0 +1,36 @@
+// Package scan is a package for handling malware scanning processes of uploaded files.
+package scan
+
+import (
+	"log"
+	"time"
+
+	"github.com/Netflix/go-env"
+)
+
+const (
+	// BackendNone is the backend accepting every file without scanning it.
+	BackendNone = "none"
+	// BackendClamd is the backend scanning files with a clamd daemon.
+	BackendClamd = "clamd"
+)
+
+// Config struct is composed of a backend, the network and the address of a clamd daemon, a timeout of a scan
+// and whether a file is accepted when the scanner is unavailable.
+type Config struct {
+	Backend  string        `env:"SCAN_BACKEND,default=none"`
+	Network  string        `env:"SCAN_CLAMD_NETWORK,default=tcp"`
+	Address  string        `env:"SCAN_CLAMD_ADDRESS,default=127.0.0.1:3310"`
+	Timeout  time.Duration `env:"SCAN_TIMEOUT,default=30s"`
+	FailOpen bool          `env:"SCAN_FAIL_OPEN,default=false"`
+}
+
+// FromEnv method is returning a Config.
+func (c *Config) FromEnv() *Config {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,97 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"security-proof/pkg/constants"
+)
+
+// Result struct is composed of whether a file is infected, the name of the signature found in it
+// and whether it was accepted without a scan because the scanner was unavailable.
+type Result struct {
+	Infected  bool
+	Signature string
+	Skipped   bool
+}
+
+// Scanner interface is defining the malware scan of an uploaded file.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// NewScanner function is returning a Scanner and an error, accepting a Config.
+// Files the scanner could not scan are accepted when the config fails open, and rejected otherwise.
+func NewScanner(config *Config) (Scanner, error) {
+	var scanner Scanner
+	switch strings.ToLower(config.Backend) {
+	case BackendNone:
+		return NewNop(), nil
+	case BackendClamd:
+		scanner = NewClamd(config.Network, config.Address, config.Timeout)
+	default:
+		return nil, errors.Join(constants.ErrFileScan, fmt.Errorf("backend %q", config.Backend))
+	}
+
+	if config.FailOpen {
+		return &failOpen{scanner: scanner}, nil
+	}
+	return scanner, nil
+}
+
+// failOpen struct is a Scanner accepting a file the wrapped Scanner could not scan, so that uploads continue while it is down.
+type failOpen struct {
+	scanner Scanner
+}
+
+// Scan method is returning a Result and nil, accepting a context and a reader.
+func (f *failOpen) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	result, err := f.scanner.Scan(ctx, r)
+	if err != nil {
+		log.Printf("Failed to scan file, accepting it unscanned: %v", err)
+		return &Result{Skipped: true}, nil
+	}
+	return result, nil
+}
+
+// Nop struct is a Scanner accepting every file without scanning it.
+type Nop struct{}
+
+// NewNop function is returning a Nop.
+func NewNop() *Nop {
+	return &Nop{}
+}
+
+// Scan method is returning a clean Result and nil, accepting a context and a reader.
+func (n *Nop) Scan(_ context.Context, _ io.Reader) (*Result, error) {
+	return &Result{}, nil
+}
+
+// EICAR is the standard anti-malware test file, which every scanner reports without it being harmful.
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// Fake struct is a Scanner for testing, reporting files containing the EICAR test file and failing with its error when it is set.
+type Fake struct {
+	Err error
+}
+
+// Scan method is returning a Result and an error, accepting a context and a reader.
+func (f *Fake) Scan(_ context.Context, r io.Reader) (*Result, error) {
+	if f.Err != nil {
+		return nil, errors.Join(constants.ErrFileScan, f.Err)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileScan, err)
+	}
+	if bytes.Contains(data, []byte(EICAR)) {
+		return &Result{Infected: true, Signature: "Eicar-Test-Signature"}, nil
+	}
+	return &Result{}, nil
+}
@@ -0,0 +1,128 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+// serveClamd function is returning the address of a fake clamd daemon, accepting a test and a network.
+// The daemon answers INSTREAM commands, reporting streams containing the EICAR test file, and the chunk sizes it received are sent to the channel.
+func serveClamd(t *testing.T, network string) (string, <-chan []int) {
+	address := "127.0.0.1:0"
+	if network == "unix" {
+		address = filepath.Join(t.TempDir(), "clamd.sock")
+	}
+	listener, err := net.Listen(network, address)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	chunks := make(chan []int, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			reader := bufio.NewReader(conn)
+			command, _ := reader.ReadString(0)
+			if command != "zINSTREAM\x00" {
+				_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+				_ = conn.Close()
+				continue
+			}
+
+			data := &bytes.Buffer{}
+			sizes := make([]int, 0)
+			for {
+				var size uint32
+				if binary.Read(reader, binary.BigEndian, &size) != nil || size == 0 {
+					break
+				}
+				sizes = append(sizes, int(size))
+				_, _ = io.CopyN(data, reader, int64(size))
+			}
+			chunks <- sizes
+
+			response := "stream: OK\x00"
+			if bytes.Contains(data.Bytes(), []byte(EICAR)) {
+				response = "stream: Eicar-Test-Signature FOUND\x00"
+			}
+			_, _ = conn.Write([]byte(response))
+			_ = conn.Close()
+		}
+	}()
+
+	return listener.Addr().String(), chunks
+}
+
+func TestClamd_Scan(t *testing.T) {
+	for _, network := range []string{"tcp", "unix"} {
+		address, chunks := serveClamd(t, network)
+		clamd := NewClamd(network, address, 5*time.Second)
+
+		t.Run(network+" 깨끗한 파일 케이스", func(t *testing.T) {
+			data := bytes.Repeat([]byte("a"), clamdChunkSize+10)
+			result, err := clamd.Scan(context.Background(), bytes.NewReader(data))
+			assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+			assert.False(t, result.Infected)
+			assert.Equal(t, []int{clamdChunkSize, 10}, <-chunks, "파일이 청크로 나뉘어 전송되었습니다.")
+		})
+
+		t.Run(network+" 감염된 파일 케이스", func(t *testing.T) {
+			result, err := clamd.Scan(context.Background(), strings.NewReader("prefix "+EICAR))
+			assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+			assert.True(t, result.Infected, "EICAR 테스트 파일이 탐지되었습니다.")
+			assert.Equal(t, "Eicar-Test-Signature", result.Signature)
+			<-chunks
+		})
+	}
+}
+
+func TestNewScanner(t *testing.T) {
+	t.Run("스캐너에 연결할 수 없는 케이스", func(t *testing.T) {
+		address := filepath.Join(t.TempDir(), "missing.sock")
+		scanner, err := NewScanner(&Config{Backend: BackendClamd, Network: "unix", Address: address, Timeout: time.Second})
+		assert.NoError(t, err)
+
+		_, err = scanner.Scan(context.Background(), strings.NewReader("data"))
+		assert.ErrorIs(t, err, constants.ErrFileScan, "기본 설정은 검사하지 못한 파일을 거부합니다.")
+	})
+
+	t.Run("검사 실패를 허용하는 케이스", func(t *testing.T) {
+		address := filepath.Join(t.TempDir(), "missing.sock")
+		scanner, err := NewScanner(&Config{Backend: BackendClamd, Network: "unix", Address: address, Timeout: time.Second, FailOpen: true})
+		assert.NoError(t, err)
+
+		result, err := scanner.Scan(context.Background(), strings.NewReader("data"))
+		assert.NoError(t, err, "검사하지 못한 파일을 받아들입니다.")
+		assert.True(t, result.Skipped, "검사하지 않았다는 것이 기록됩니다.")
+	})
+
+	t.Run("알 수 없는 백엔드 케이스", func(t *testing.T) {
+		_, err := NewScanner(&Config{Backend: "unknown"})
+		assert.ErrorIs(t, err, constants.ErrFileScan)
+	})
+}
+
+func TestParseClamdResponse(t *testing.T) {
+	_, err := parseClamdResponse("INSTREAM size limit exceeded. ERROR\x00")
+	assert.ErrorIs(t, err, constants.ErrFileScan, "크기 제한을 넘은 파일은 결과를 알 수 없습니다.")
+
+	_, err = (&Fake{Err: errors.New("down")}).Scan(context.Background(), strings.NewReader(""))
+	assert.ErrorIs(t, err, constants.ErrFileScan)
+}
@@ -0,0 +1,35 @@
+// Package sign is a package for handling detached signature processes.
+package sign
+
+import (
+	"log"
+	"os"
+
+	"github.com/Netflix/go-env"
+)
+
+// Config struct is composed of a private key path.
+type Config struct {
+	KeyPath string `env:"EXPORT_SIGNING_KEY_PATH,default="`
+}
+
+// FromEnv method is returning a PEM encoded private key.
+// The key is nil when no key path is given.
+func (c *Config) FromEnv() []byte {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+
+	if c.KeyPath == "" {
+		return nil
+	}
+
+	key, err := os.ReadFile(c.KeyPath)
+	if err != nil {
+		log.Fatal("Error reading signing key")
+	}
+
+	return key
+}
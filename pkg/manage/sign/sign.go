@@ -0,0 +1,82 @@
+package sign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"log"
+
+	"security-proof/pkg/constants"
+)
+
+// Signer struct is composed of an Ed25519 private key.
+type Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewSigner function is returning a Signer and an error, accepting a PEM encoded PKCS #8 Ed25519 private key.
+// A random key is generated when the key is empty, so that signatures can only be checked with the exported public key.
+func NewSigner(keyPEM []byte) (*Signer, error) {
+	if len(keyPEM) == 0 {
+		log.Printf("No signing key is given, a random key is used")
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSignKey, err)
+		}
+		return &Signer{privateKey: privateKey}, nil
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.Join(constants.ErrSignKey, errors.New("no PEM block"))
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignKey, err)
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.Join(constants.ErrSignKey, errors.New("not an Ed25519 key"))
+	}
+
+	return &Signer{privateKey: privateKey}, nil
+}
+
+// Sign method is returning a signature, accepting a data.
+func (s *Signer) Sign(data []byte) []byte {
+	return ed25519.Sign(s.privateKey, data)
+}
+
+// PublicKeyPEM method is returning the PEM encoded PKIX public key of the signer.
+func (s *Signer) PublicKeyPEM() []byte {
+	der, err := x509.MarshalPKIXPublicKey(s.privateKey.Public())
+	if err != nil {
+		// Ed25519 공개키는 항상 인코딩할 수 있습니다.
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// Verify function is returning whether a signature is valid, accepting a PEM encoded public key, a data and a signature.
+func Verify(publicKeyPEM []byte, data []byte, signature []byte) bool {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return false
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return false
+	}
+
+	return ed25519.Verify(publicKey, data, signature)
+}
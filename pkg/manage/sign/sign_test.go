@@ -0,0 +1,40 @@
+package sign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+func TestSigner_Sign(t *testing.T) {
+	t.Run("PEM 키 서명 검증 케이스", func(t *testing.T) {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		assert.NoError(t, err)
+
+		signer, err := NewSigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		signature := signer.Sign([]byte("manifest"))
+		assert.True(t, Verify(signer.PublicKeyPEM(), []byte("manifest"), signature), "서명이 검증되었습니다.")
+		assert.False(t, Verify(signer.PublicKeyPEM(), []byte("tampered"), signature), "변조된 데이터는 검증되지 않습니다.")
+	})
+
+	t.Run("임시 키 서명 케이스", func(t *testing.T) {
+		signer, err := NewSigner(nil)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.True(t, Verify(signer.PublicKeyPEM(), []byte("manifest"), signer.Sign([]byte("manifest"))))
+	})
+
+	t.Run("잘못된 키 케이스", func(t *testing.T) {
+		_, err := NewSigner([]byte("not a key"))
+		assert.ErrorIs(t, err, constants.ErrSignKey)
+	})
+}
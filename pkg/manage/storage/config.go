@@ -0,0 +1,98 @@
+// Package storage is a package for handling evidence file storage processes.
+package storage
+
+import (
+	"log"
+	"time"
+
+	"github.com/Netflix/go-env"
+)
+
+// Backend constants is the supported storage backend.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// GC mode constants is what is done with an orphaned file.
+const (
+	GCModeQuarantine = "quarantine"
+	GCModeDelete     = "delete"
+)
+
+// QuarantinePrefix is the key prefix orphaned files are moved under, which is never collected.
+const QuarantinePrefix = "quarantine/"
+
+// Config struct is composed of a backend, a local root path, an S3 compatible endpoint, region, bucket and credentials, and master keys.
+// Files are encrypted at rest when a keyring file or a master key is given.
+type Config struct {
+	Backend     string `env:"STORAGE_BACKEND,default=local"`
+	Path        string `env:"SECURITY_PROOF_FILE_PATH,default=savedproofs"`
+	S3Endpoint  string `env:"STORAGE_S3_ENDPOINT,default=http://127.0.0.1:9000"`
+	S3Region    string `env:"STORAGE_S3_REGION,default=us-east-1"`
+	S3Bucket    string `env:"STORAGE_S3_BUCKET,default=security-proof"`
+	S3AccessKey string `env:"STORAGE_S3_ACCESS_KEY"`
+	S3SecretKey string `env:"STORAGE_S3_SECRET_KEY"`
+	S3PathStyle bool   `env:"STORAGE_S3_PATH_STYLE,default=true"`
+	KeyringFile string `env:"STORAGE_KEYRING_FILE"`
+	MasterKey   string `env:"STORAGE_MASTER_KEY"`
+	MasterKeyID string `env:"STORAGE_MASTER_KEY_ID,default=env"`
+}
+
+// FromEnv method is returning a Config.
+func (c *Config) FromEnv() *Config {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
+
+// GCConfig struct is composed of a collection interval, which disables the job when it is 0,
+// a grace period before an unreferenced file is collected and a mode of quarantine or delete.
+type GCConfig struct {
+	Interval time.Duration `env:"STORAGE_GC_INTERVAL,default=24h"`
+	Grace    time.Duration `env:"STORAGE_GC_GRACE,default=168h"`
+	Mode     string        `env:"STORAGE_GC_MODE,default=quarantine"`
+}
+
+// FromEnv method is returning a GCConfig.
+func (c *GCConfig) FromEnv() *GCConfig {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
+
+// RetentionConfig struct is composed of a purge interval of expired evidence, which disables the job when it is 0.
+type RetentionConfig struct {
+	Interval time.Duration `env:"RETENTION_PURGE_INTERVAL,default=24h"`
+}
+
+// FromEnv method is returning a RetentionConfig.
+func (c *RetentionConfig) FromEnv() *RetentionConfig {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
+
+// IntegrityConfig struct is composed of an integrity check interval of confirmed evidence, which disables the job when it is 0.
+type IntegrityConfig struct {
+	Interval time.Duration `env:"INTEGRITY_SCAN_INTERVAL,default=24h"`
+}
+
+// FromEnv method is returning an IntegrityConfig.
+func (c *IntegrityConfig) FromEnv() *IntegrityConfig {
+	_, err := env.UnmarshalFromEnviron(c)
+	if err != nil {
+		log.Fatal("Error unmarshalling environment variables")
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,511 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strings"
+
+	"security-proof/pkg/constants"
+)
+
+// Encrypted file layout constants.
+// A file is a header of the magic and a random nonce prefix, followed by segments sealed with AES-256-GCM.
+const (
+	encryptedMagic       = "SPENC001"
+	encryptedPrefixSize  = 7
+	encryptedHeaderSize  = int64(len(encryptedMagic) + encryptedPrefixSize)
+	encryptedSegmentSize = 64 << 10
+	encryptedTagSize     = 16
+	dataKeySuffix        = ".dek"
+	dataKeyMaxSize       = 4 << 10
+)
+
+// Encrypted struct is composed of a Storage holding the encrypted files and a Keyring wrapping their data keys.
+// Every file has its own data key, kept wrapped next to the file under the key with the ".dek" suffix,
+// so that rotating the master key rewrites only the data keys.
+type Encrypted struct {
+	inner   Storage
+	keyring *Keyring
+}
+
+// NewEncrypted function is returning an Encrypted, accepting a Storage and a Keyring.
+func NewEncrypted(inner Storage, keyring *Keyring) *Encrypted {
+	return &Encrypted{inner: inner, keyring: keyring}
+}
+
+// Put method is returning an error, accepting a context, a key, a reader and a size.
+// The data key of a file being replaced is reused, so the file and its data key never disagree.
+func (e *Encrypted) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if strings.HasSuffix(key, dataKeySuffix) {
+		return errors.Join(constants.ErrFilePath, fmt.Errorf("key %q uses a reserved suffix", key))
+	}
+
+	dataKey, err := e.dataKey(ctx, key)
+	created := false
+	if errors.Is(err, constants.ErrItemNotFound) {
+		dataKey, err = e.createDataKey(ctx, key)
+		created = true
+	}
+	if err != nil {
+		return err
+	}
+
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return errors.Join(constants.ErrStorageEncrypt, err)
+	}
+
+	prefix := make([]byte, encryptedPrefixSize)
+	_, err = rand.Read(prefix)
+	if err != nil {
+		return errors.Join(constants.ErrStorageEncrypt, err)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(encryptSegments(pipeWriter, r, aead, prefix))
+	}()
+
+	encryptedSize := int64(-1)
+	if size >= 0 {
+		encryptedSize = encryptedSizeOf(size)
+	}
+	err = e.inner.Put(ctx, key, pipeReader, encryptedSize)
+	// 저장이 중간에 실패해도 암호화 고루틴이 끝나도록 읽기 쪽을 닫습니다.
+	_ = pipeReader.Close()
+	if err != nil {
+		if created {
+			_ = e.inner.Delete(ctx, key+dataKeySuffix)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Get method is returning a decrypting reader and an error, accepting a context and a key.
+// A file saved before the encryption is returned as it is, and the reader can seek when the inner reader can.
+func (e *Encrypted) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	dataKey, err := e.dataKey(ctx, key)
+	if errors.Is(err, constants.ErrItemNotFound) {
+		// 암호화 이전에 저장된 파일은 데이터 키가 없으므로 그대로 읽습니다.
+		return e.inner.Get(ctx, key)
+	} else if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageDecrypt, err)
+	}
+
+	reader, err := e.inner.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDecryptReader(reader, aead)
+}
+
+// Stat method is returning an Object with the plaintext size and an error, accepting a context and a key.
+func (e *Encrypted) Stat(ctx context.Context, key string) (*Object, error) {
+	object, err := e.inner.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = e.inner.Stat(ctx, key+dataKeySuffix)
+	if errors.Is(err, constants.ErrItemNotFound) {
+		return object, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	object.Size, err = plaintextSizeOf(object.Size)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileStat, err)
+	}
+	return object, nil
+}
+
+// Delete method is returning an error, accepting a context and a key.
+func (e *Encrypted) Delete(ctx context.Context, key string) error {
+	err := e.inner.Delete(ctx, key)
+	if err != nil {
+		return err
+	}
+	return e.inner.Delete(ctx, key+dataKeySuffix)
+}
+
+// List method is returning Objects with the plaintext sizes and an error, accepting a context and a key prefix.
+// The data keys are not listed.
+func (e *Encrypted) List(ctx context.Context, prefix string) ([]*Object, error) {
+	objects, err := e.inner.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := make(map[string]bool)
+	for _, object := range objects {
+		if key, ok := strings.CutSuffix(object.Key, dataKeySuffix); ok {
+			encrypted[key] = true
+		}
+	}
+
+	files := make([]*Object, 0, len(objects)-len(encrypted))
+	for _, object := range objects {
+		if strings.HasSuffix(object.Key, dataKeySuffix) {
+			continue
+		}
+		if encrypted[object.Key] {
+			object.Size, err = plaintextSizeOf(object.Size)
+			if err != nil {
+				return nil, errors.Join(constants.ErrFileList, fmt.Errorf("key %q: %w", object.Key, err))
+			}
+		}
+		files = append(files, object)
+	}
+
+	return files, nil
+}
+
+// Rotate method is returning the number of re-wrapped data keys and an error, accepting a context.
+// Every data key wrapped by a master key other than the primary one is wrapped again by the primary one.
+// The encrypted files are not rewritten.
+func (e *Encrypted) Rotate(ctx context.Context) (int, error) {
+	objects, err := e.inner.List(ctx, "")
+	if err != nil {
+		return 0, errors.Join(constants.ErrStorageRotate, err)
+	}
+
+	rotated := 0
+	for _, object := range objects {
+		key, ok := strings.CutSuffix(object.Key, dataKeySuffix)
+		if !ok {
+			continue
+		}
+
+		wrapped, err := e.readDataKey(ctx, key)
+		if err != nil {
+			return rotated, errors.Join(constants.ErrStorageRotate, err)
+		}
+		if wrapped.KeyID == e.keyring.Primary() {
+			continue
+		}
+
+		dataKey, err := e.keyring.unwrap(wrapped, []byte(key))
+		if err != nil {
+			return rotated, errors.Join(constants.ErrStorageRotate, fmt.Errorf("key %q: %w", key, err))
+		}
+
+		err = e.writeDataKey(ctx, key, dataKey)
+		if err != nil {
+			return rotated, errors.Join(constants.ErrStorageRotate, err)
+		}
+		rotated++
+	}
+
+	return rotated, nil
+}
+
+// dataKey method is returning an unwrapped data key and an error, accepting a context and a key.
+func (e *Encrypted) dataKey(ctx context.Context, key string) ([]byte, error) {
+	wrapped, err := e.readDataKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := e.keyring.unwrap(wrapped, []byte(key))
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageDecrypt, err)
+	}
+	return dataKey, nil
+}
+
+// createDataKey method is returning a new data key and an error, accepting a context and a key.
+func (e *Encrypted) createDataKey(ctx context.Context, key string) ([]byte, error) {
+	dataKey := make([]byte, masterKeySize)
+	_, err := rand.Read(dataKey)
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageEncrypt, err)
+	}
+
+	err = e.writeDataKey(ctx, key, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return dataKey, nil
+}
+
+// readDataKey method is returning a wrappedKey and an error, accepting a context and a key.
+func (e *Encrypted) readDataKey(ctx context.Context, key string) (*wrappedKey, error) {
+	reader, err := e.inner.Get(ctx, key+dataKeySuffix)
+	if err != nil {
+		return nil, err
+	}
+	defer closeReader(reader)
+
+	data, err := io.ReadAll(io.LimitReader(reader, dataKeyMaxSize))
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileRead, err)
+	}
+
+	wrapped := &wrappedKey{}
+	err = json.Unmarshal(data, wrapped)
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageDecrypt, err)
+	}
+	return wrapped, nil
+}
+
+// writeDataKey method is returning an error, accepting a context, a key and a data key wrapped by the primary master key.
+// The data key is bound to the key of its file, so it cannot be moved to another file.
+func (e *Encrypted) writeDataKey(ctx context.Context, key string, dataKey []byte) error {
+	wrapped, err := e.keyring.wrap(dataKey, []byte(key))
+	if err != nil {
+		return errors.Join(constants.ErrStorageEncrypt, err)
+	}
+
+	data, err := json.Marshal(wrapped)
+	if err != nil {
+		return errors.Join(constants.ErrStorageEncrypt, err)
+	}
+
+	return e.inner.Put(ctx, key+dataKeySuffix, bytes.NewReader(data), int64(len(data)))
+}
+
+// closeReader function is closing a reader, logging the error.
+func closeReader(reader io.Closer) {
+	if err := reader.Close(); err != nil {
+		log.Printf("Failed to close file: %v", err)
+	}
+}
+
+// encryptSegments function is returning an error, accepting a writer, a plaintext reader, an AEAD and a nonce prefix.
+// The final segment is sealed with its own nonce, so a truncated file fails to decrypt.
+func encryptSegments(w io.Writer, r io.Reader, aead cipher.AEAD, prefix []byte) error {
+	_, err := w.Write(append([]byte(encryptedMagic), prefix...))
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(r)
+	plain := make([]byte, encryptedSegmentSize)
+	sealed := make([]byte, 0, encryptedSegmentSize+encryptedTagSize)
+	for segment := uint32(0); ; segment++ {
+		n, err := io.ReadFull(reader, plain)
+		last := false
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			last = true
+		} else if err != nil {
+			return err
+		} else if _, err = reader.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+
+		sealed = aead.Seal(sealed[:0], segmentNonce(prefix, segment, last), plain[:n], nil)
+		_, err = w.Write(sealed)
+		if err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+		if segment == math.MaxUint32 {
+			return errors.Join(constants.ErrStorageEncrypt, errors.New("file is too large"))
+		}
+	}
+}
+
+// segmentNonce function is returning a nonce, accepting a nonce prefix, a segment index and whether the segment is the final one.
+func segmentNonce(prefix []byte, segment uint32, last bool) []byte {
+	nonce := make([]byte, encryptedPrefixSize+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[encryptedPrefixSize:], segment)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// encryptedSizeOf function is returning the encrypted size, accepting a plaintext size.
+func encryptedSizeOf(size int64) int64 {
+	segments := (size + encryptedSegmentSize - 1) / encryptedSegmentSize
+	if segments == 0 {
+		segments = 1
+	}
+	return encryptedHeaderSize + size + segments*encryptedTagSize
+}
+
+// plaintextSizeOf function is returning the plaintext size and an error, accepting an encrypted size.
+func plaintextSizeOf(size int64) (int64, error) {
+	body := size - encryptedHeaderSize
+	if body < encryptedTagSize {
+		return 0, errors.Join(constants.ErrStorageDecrypt, errors.New("encrypted file is too short"))
+	}
+
+	segments := (body + encryptedSegmentSize + encryptedTagSize - 1) / (encryptedSegmentSize + encryptedTagSize)
+	return body - segments*encryptedTagSize, nil
+}
+
+// decryptReader struct is a reader decrypting the segments of an encrypted file one at a time.
+type decryptReader struct {
+	src     io.ReadCloser
+	reader  *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	buf     []byte
+	plain   []byte
+	segment uint32
+	last    bool
+	offset  int64
+}
+
+// seekableDecryptReader struct is a decryptReader over a seekable file.
+type seekableDecryptReader struct {
+	*decryptReader
+}
+
+// newDecryptReader function is returning a reader and an error, accepting an encrypted file reader and an AEAD.
+func newDecryptReader(src io.ReadCloser, aead cipher.AEAD) (io.ReadCloser, error) {
+	reader := &decryptReader{
+		src:    src,
+		reader: bufio.NewReader(src),
+		aead:   aead,
+		buf:    make([]byte, encryptedSegmentSize+encryptedTagSize),
+	}
+
+	header := make([]byte, encryptedHeaderSize)
+	_, err := io.ReadFull(reader.reader, header)
+	if err != nil || string(header[:len(encryptedMagic)]) != encryptedMagic {
+		closeReader(src)
+		return nil, errors.Join(constants.ErrStorageDecrypt, errors.New("invalid encrypted file header"))
+	}
+	reader.prefix = header[len(encryptedMagic):]
+
+	if _, ok := src.(io.Seeker); ok {
+		return &seekableDecryptReader{decryptReader: reader}, nil
+	}
+	return reader, nil
+}
+
+// Read method is returning the number of decrypted bytes read and an error, accepting a buffer.
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.last {
+			return 0, io.EOF
+		}
+		err := d.readSegment()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	d.offset += int64(n)
+	return n, nil
+}
+
+// Close method is returning an error of closing the encrypted file.
+func (d *decryptReader) Close() error {
+	return d.src.Close()
+}
+
+// readSegment method is returning an error, decrypting the next segment.
+func (d *decryptReader) readSegment() error {
+	n, err := io.ReadFull(d.reader, d.buf)
+	if errors.Is(err, io.EOF) {
+		// 마지막 세그먼트 없이 파일이 끝났다면 잘린 파일입니다.
+		return errors.Join(constants.ErrStorageDecrypt, io.ErrUnexpectedEOF)
+	} else if errors.Is(err, io.ErrUnexpectedEOF) {
+		d.last = true
+	} else if err != nil {
+		return errors.Join(constants.ErrFileRead, err)
+	} else if _, err = d.reader.Peek(1); errors.Is(err, io.EOF) {
+		d.last = true
+	} else if err != nil {
+		return errors.Join(constants.ErrFileRead, err)
+	}
+
+	plain, err := d.aead.Open(d.buf[:0], segmentNonce(d.prefix, d.segment, d.last), d.buf[:n], nil)
+	if err != nil {
+		return errors.Join(constants.ErrStorageDecrypt, err)
+	}
+
+	d.plain = plain
+	d.segment++
+	return nil
+}
+
+// Seek method is returning a new plaintext offset and an error, accepting an offset and a whence.
+// Only the segment holding the offset is decrypted.
+func (s *seekableDecryptReader) Seek(offset int64, whence int) (int64, error) {
+	seeker := s.src.(io.Seeker)
+
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = s.offset + offset
+	case io.SeekEnd:
+		encryptedSize, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, errors.Join(constants.ErrFileRead, err)
+		}
+		size, err := plaintextSizeOf(encryptedSize)
+		if err != nil {
+			return 0, err
+		}
+		target = size + offset
+	default:
+		return 0, errors.Join(constants.ErrFileRead, errors.New("invalid whence"))
+	}
+	if target < 0 {
+		return 0, errors.Join(constants.ErrFileRead, errors.New("negative position"))
+	}
+
+	// 세그먼트 경계는 앞 세그먼트의 끝으로 보고 찾아야 파일 끝으로도 이동할 수 있습니다.
+	segment, within := target/encryptedSegmentSize, target%encryptedSegmentSize
+	if segment > 0 && within == 0 {
+		segment, within = segment-1, encryptedSegmentSize
+	}
+	if segment > math.MaxUint32 {
+		return 0, errors.Join(constants.ErrFileRead, errors.New("position is too large"))
+	}
+
+	_, err := seeker.Seek(encryptedHeaderSize+segment*(encryptedSegmentSize+encryptedTagSize), io.SeekStart)
+	if err != nil {
+		return 0, errors.Join(constants.ErrFileRead, err)
+	}
+	s.reader.Reset(s.src)
+	s.segment = uint32(segment)
+	s.last = false
+	s.plain = nil
+
+	err = s.readSegment()
+	if err != nil {
+		return 0, err
+	}
+	if within > int64(len(s.plain)) {
+		return 0, errors.Join(constants.ErrFileRead, errors.New("position is beyond the end"))
+	}
+
+	s.plain = s.plain[within:]
+	s.offset = target
+	return target, nil
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+// testMasterKey function is returning a base64 encoded master key filled with a byte.
+func testMasterKey(fill byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, masterKeySize))
+}
+
+func newTestEncrypted(t *testing.T, inner Storage) *Encrypted {
+	keyring, err := NewKeyring(&Config{MasterKey: testMasterKey(1), MasterKeyID: "2025"})
+	assert.NoError(t, err)
+	return NewEncrypted(inner, keyring)
+}
+
+func TestEncrypted(t *testing.T) {
+	t.Run("메모리 저장소", func(t *testing.T) {
+		testStorage(t, newTestEncrypted(t, NewMemory()))
+	})
+
+	t.Run("로컬 저장소", func(t *testing.T) {
+		local, err := NewLocal(t.TempDir())
+		assert.NoError(t, err)
+		testStorage(t, newTestEncrypted(t, local))
+	})
+
+	ctx := context.Background()
+	inner := NewMemory()
+	encrypted := newTestEncrypted(t, inner)
+
+	t.Run("평문이 저장되지 않는 케이스", func(t *testing.T) {
+		err := encrypted.Put(ctx, "secret", strings.NewReader("10.0.0.0/8"), 10)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		reader, err := inner.Get(ctx, "secret")
+		assert.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "10.0.0.0/8", "저장소에는 암호문만 남았습니다.")
+		assert.Equal(t, encryptedSizeOf(10), int64(len(data)))
+
+		objects, err := encrypted.List(ctx, "secret")
+		assert.NoError(t, err)
+		assert.Len(t, objects, 1, "데이터 키는 목록에 나오지 않습니다.")
+		assert.Equal(t, int64(10), objects[0].Size, "평문 크기가 조회되었습니다.")
+	})
+
+	t.Run("여러 세그먼트 탐색 케이스", func(t *testing.T) {
+		plain := bytes.Repeat([]byte("0123456789abcdef"), encryptedSegmentSize/8+3)
+		err := encrypted.Put(ctx, "large", bytes.NewReader(plain), -1)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		reader, err := encrypted.Get(ctx, "large")
+		assert.NoError(t, err)
+		seeker, ok := reader.(io.ReadSeeker)
+		assert.True(t, ok, "탐색할 수 있는 저장소의 파일은 복호화하면서도 탐색할 수 있습니다.")
+
+		end, err := seeker.Seek(0, io.SeekEnd)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(plain)), end)
+
+		offset := int64(encryptedSegmentSize - 5)
+		_, err = seeker.Seek(offset, io.SeekStart)
+		assert.NoError(t, err)
+		part := make([]byte, 10)
+		_, err = io.ReadFull(seeker, part)
+		assert.NoError(t, err)
+		assert.Equal(t, plain[offset:offset+10], part, "세그먼트 경계를 넘어 읽었습니다.")
+
+		_, err = seeker.Seek(0, io.SeekStart)
+		assert.NoError(t, err)
+		data, err := io.ReadAll(seeker)
+		assert.NoError(t, err)
+		assert.Equal(t, plain, data)
+		assert.NoError(t, reader.Close())
+	})
+
+	t.Run("잘린 암호문 케이스", func(t *testing.T) {
+		reader, err := inner.Get(ctx, "large")
+		assert.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		err = inner.Put(ctx, "large", bytes.NewReader(data[:encryptedHeaderSize+encryptedSegmentSize+encryptedTagSize]), -1)
+		assert.NoError(t, err)
+
+		reader, err = encrypted.Get(ctx, "large")
+		assert.NoError(t, err)
+		_, err = io.ReadAll(reader)
+		assert.ErrorIs(t, err, constants.ErrStorageDecrypt, "잘린 파일은 복호화되지 않습니다.")
+	})
+
+	t.Run("암호화 이전 파일 조회 케이스", func(t *testing.T) {
+		err := inner.Put(ctx, "legacy", strings.NewReader("legacy"), 6)
+		assert.NoError(t, err)
+
+		reader, err := encrypted.Get(ctx, "legacy")
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "legacy", string(data), "데이터 키가 없는 파일은 그대로 조회되었습니다.")
+	})
+
+	t.Run("예약된 키 저장 케이스", func(t *testing.T) {
+		err := encrypted.Put(ctx, "secret.dek", strings.NewReader("x"), 1)
+		assert.ErrorIs(t, err, constants.ErrFilePath)
+	})
+}
+
+func TestEncrypted_Rotate(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemory()
+
+	old := NewEncrypted(inner, &Keyring{primary: "old", keys: map[string][]byte{"old": bytes.Repeat([]byte{1}, masterKeySize)}})
+	err := old.Put(ctx, "1/evidence", strings.NewReader("evidence"), 8)
+	assert.NoError(t, err)
+
+	before, err := inner.Get(ctx, "1/evidence")
+	assert.NoError(t, err)
+	ciphertext, err := io.ReadAll(before)
+	assert.NoError(t, err)
+
+	keyring := &Keyring{primary: "new", keys: map[string][]byte{
+		"old": bytes.Repeat([]byte{1}, masterKeySize),
+		"new": bytes.Repeat([]byte{2}, masterKeySize),
+	}}
+	rotating := NewEncrypted(inner, keyring)
+
+	t.Run("데이터 키 재암호화 케이스", func(t *testing.T) {
+		count, err := rotating.Rotate(ctx)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, 1, count)
+
+		count, err = rotating.Rotate(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, count, "이미 기본 키로 감싼 데이터 키는 건너뜁니다.")
+	})
+
+	t.Run("파일 유지 케이스", func(t *testing.T) {
+		after, err := inner.Get(ctx, "1/evidence")
+		assert.NoError(t, err)
+		data, err := io.ReadAll(after)
+		assert.NoError(t, err)
+		assert.Equal(t, ciphertext, data, "암호화된 파일은 다시 쓰지 않았습니다.")
+	})
+
+	t.Run("이전 키 제거 후 조회 케이스", func(t *testing.T) {
+		current := NewEncrypted(inner, &Keyring{primary: "new", keys: map[string][]byte{"new": keyring.keys["new"]}})
+
+		reader, err := current.Get(ctx, "1/evidence")
+		assert.NoError(t, err, "새 기본 키만으로 조회되었습니다.")
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "evidence", string(data))
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	t.Run("키링 파일 케이스", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "keyring.json")
+		err := os.WriteFile(file, []byte(`{"primary":"2025","keys":{"2024":"`+testMasterKey(1)+`","2025":"`+testMasterKey(2)+`"}}`), 0o600)
+		assert.NoError(t, err)
+
+		keyring, err := NewKeyring(&Config{KeyringFile: file, MasterKey: testMasterKey(3), MasterKeyID: "env"})
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Equal(t, "2025", keyring.Primary(), "키링 파일의 기본 키가 우선합니다.")
+		assert.Len(t, keyring.keys, 3)
+	})
+
+	t.Run("마스터 키 미설정 케이스", func(t *testing.T) {
+		keyring, err := NewKeyring(&Config{MasterKeyID: "env"})
+		assert.NoError(t, err)
+		assert.Nil(t, keyring, "키가 없으면 암호화하지 않습니다.")
+	})
+
+	t.Run("잘못된 키 길이 케이스", func(t *testing.T) {
+		_, err := NewKeyring(&Config{MasterKey: base64.StdEncoding.EncodeToString([]byte("short")), MasterKeyID: "env"})
+		assert.ErrorIs(t, err, constants.ErrStorageKey)
+	})
+
+	t.Run("암호화 저장소 생성 케이스", func(t *testing.T) {
+		storage, err := NewStorage(&Config{Backend: BackendLocal, Path: t.TempDir(), MasterKey: testMasterKey(1), MasterKeyID: "env"})
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.IsType(t, &Encrypted{}, storage)
+	})
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"security-proof/pkg/constants"
+)
+
+// masterKeySize is the size of a master key and a data key, which is AES-256.
+const masterKeySize = 32
+
+// Keyring struct is composed of master keys by id and the id of the primary key wrapping new data keys.
+// Older keys are kept so that data keys wrapped before a rotation can still be unwrapped.
+type Keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// keyringFile struct is the JSON layout of a keyring file, whose keys are base64 encoded.
+type keyringFile struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// wrappedKey struct is composed of the id of the wrapping master key and a sealed data key.
+type wrappedKey struct {
+	KeyID string `json:"key_id"`
+	Key   []byte `json:"key"`
+}
+
+// NewKeyring function is returning a Keyring and an error, accepting a Config.
+// The keyring file is read first and the master key of the environment is added to it.
+// A nil Keyring is returned when neither is given, which leaves the files unencrypted.
+func NewKeyring(config *Config) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string][]byte)}
+
+	if config.KeyringFile != "" {
+		data, err := os.ReadFile(config.KeyringFile)
+		if err != nil {
+			return nil, errors.Join(constants.ErrStorageKey, err)
+		}
+
+		file := &keyringFile{}
+		err = json.Unmarshal(data, file)
+		if err != nil {
+			return nil, errors.Join(constants.ErrStorageKey, err)
+		}
+
+		for id, encoded := range file.Keys {
+			err = keyring.add(id, encoded)
+			if err != nil {
+				return nil, err
+			}
+		}
+		keyring.primary = file.Primary
+	}
+
+	if config.MasterKey != "" {
+		err := keyring.add(config.MasterKeyID, config.MasterKey)
+		if err != nil {
+			return nil, err
+		}
+		// 키링 파일에 기본 키가 없을 때만 환경 변수의 키가 기본 키가 됩니다.
+		if keyring.primary == "" {
+			keyring.primary = config.MasterKeyID
+		}
+	}
+
+	if len(keyring.keys) == 0 {
+		return nil, nil
+	}
+	if _, ok := keyring.keys[keyring.primary]; !ok {
+		return nil, errors.Join(constants.ErrStorageKey, fmt.Errorf("primary key %q not found", keyring.primary))
+	}
+
+	return keyring, nil
+}
+
+// Primary method is returning the id of the primary master key.
+func (k *Keyring) Primary() string {
+	return k.primary
+}
+
+// add method is returning an error, accepting a key id and a base64 encoded master key.
+func (k *Keyring) add(id string, encoded string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errors.Join(constants.ErrStorageKey, errors.New("empty key id"))
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return errors.Join(constants.ErrStorageKey, fmt.Errorf("key %q: %w", id, err))
+	}
+	if len(key) != masterKeySize {
+		return errors.Join(constants.ErrStorageKey, fmt.Errorf("key %q must be %d bytes", id, masterKeySize))
+	}
+
+	if existing, ok := k.keys[id]; ok && string(existing) != string(key) {
+		return errors.Join(constants.ErrStorageKey, fmt.Errorf("key %q is defined twice", id))
+	}
+	k.keys[id] = key
+	return nil
+}
+
+// wrap method is returning a wrappedKey and an error, accepting a data key and an associated data.
+// The data key is sealed by the primary master key, bound to the associated data.
+func (k *Keyring) wrap(dataKey []byte, associated []byte) (*wrappedKey, error) {
+	aead, err := newGCM(k.keys[k.primary])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrappedKey{KeyID: k.primary, Key: aead.Seal(nonce, nonce, dataKey, associated)}, nil
+}
+
+// unwrap method is returning a data key and an error, accepting a wrappedKey and an associated data.
+func (k *Keyring) unwrap(wrapped *wrappedKey, associated []byte) ([]byte, error) {
+	masterKey, ok := k.keys[wrapped.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q not found", wrapped.KeyID)
+	}
+
+	aead, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped.Key) < aead.NonceSize() {
+		return nil, errors.New("wrapped key is too short")
+	}
+
+	nonce, sealed := wrapped.Key[:aead.NonceSize()], wrapped.Key[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, associated)
+}
+
+// newGCM function is returning an AES-GCM AEAD and an error, accepting a key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
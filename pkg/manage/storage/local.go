@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"security-proof/pkg/constants"
+)
+
+// localTempPrefix is the prefix of a file being written, which is hidden from List.
+const localTempPrefix = ".tmp-"
+
+// Local struct is composed of a root directory holding the files.
+type Local struct {
+	root string
+}
+
+// NewLocal function is returning a Local and an error, accepting a root directory.
+// The root directory is created when it does not exist.
+func NewLocal(root string) (*Local, error) {
+	absRoot, err := filepath.Abs(filepath.Clean(root))
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageConfig, err)
+	}
+
+	err = os.MkdirAll(absRoot, 0o750)
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageConfig, err)
+	}
+
+	return &Local{root: absRoot}, nil
+}
+
+// Put method is returning an error, accepting a context, a key, a reader and a size.
+// The file is written next to its destination first and renamed into place, so a reader never sees a partial file.
+func (l *Local) Put(_ context.Context, key string, r io.Reader, size int64) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filePath)
+	err = os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+
+	file, err := os.CreateTemp(dir, localTempPrefix+"*")
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+	defer func() {
+		// 이름이 바뀐 뒤에는 임시 파일이 없으므로 에러를 무시합니다.
+		_ = os.Remove(file.Name())
+	}()
+
+	written, err := io.Copy(file, r)
+	if err != nil {
+		_ = file.Close()
+		return errors.Join(constants.ErrFileSave, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+
+	if size >= 0 && written != size {
+		return errors.Join(constants.ErrFileSave, constants.ErrFileSize)
+	}
+
+	err = os.Rename(file.Name(), filePath)
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+
+	return nil
+}
+
+// Get method is returning a file reader and an error, accepting a context and a key.
+// The reader is an *os.File, so it can seek.
+func (l *Local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath) // #nosec G304
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Join(constants.ErrFileRead, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrFileRead, err)
+	}
+
+	return file, nil
+}
+
+// Stat method is returning an Object and an error, accepting a context and a key.
+func (l *Local) Stat(_ context.Context, key string) (*Object, error) {
+	filePath, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Join(constants.ErrFileStat, constants.ErrItemNotFound)
+	} else if err != nil {
+		return nil, errors.Join(constants.ErrFileStat, err)
+	}
+
+	return &Object{Key: key, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Delete method is returning an error, accepting a context and a key.
+func (l *Local) Delete(_ context.Context, key string) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Join(constants.ErrFileDelete, err)
+	}
+
+	return nil
+}
+
+// List method is returning Objects and an error, accepting a context and a key prefix.
+func (l *Local) List(_ context.Context, prefix string) ([]*Object, error) {
+	objects := make([]*Object, 0)
+	err := filepath.WalkDir(l.root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), localTempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.root, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// 목록을 만드는 중에 삭제된 파일은 건너뜁니다.
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		objects = append(objects, &Object{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileList, err)
+	}
+
+	return objects, nil
+}
+
+// path method is returning a file path and an error, accepting a key.
+// An absolute path inside the root is accepted as well, as the files saved before the storage existed are recorded that way.
+func (l *Local) path(key string) (string, error) {
+	if filepath.IsAbs(key) {
+		return isSafePath(l.root, key)
+	}
+
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return isSafePath(l.root, filepath.Join(l.root, filepath.FromSlash(cleaned)))
+}
+
+// isSafePath function is returning a safe path and an error, accepting a base path and a requested path.
+func isSafePath(basePath string, requestedPath string) (string, error) {
+	absRequestedPath, err := filepath.Abs(filepath.Clean(requestedPath))
+	if err != nil {
+		return "", errors.Join(constants.ErrFilePath, err)
+	}
+
+	rel, err := filepath.Rel(basePath, absRequestedPath)
+	if err != nil {
+		return "", errors.Join(constants.ErrFilePath, err)
+	}
+
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Join(constants.ErrFilePath, constants.ErrFilePathTraversal)
+	}
+
+	return absRequestedPath, nil
+}
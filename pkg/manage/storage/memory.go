@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"security-proof/pkg/constants"
+)
+
+// Memory struct is composed of files kept in memory, which is used for testing.
+type Memory struct {
+	mu    sync.RWMutex
+	files map[string]*memoryFile
+}
+
+// memoryFile struct is composed of a data and a modified time.
+type memoryFile struct {
+	data    []byte
+	modTime time.Time
+}
+
+// memoryReader struct is a seekable reader of a memory file.
+type memoryReader struct {
+	*bytes.Reader
+}
+
+// Close method is returning nil.
+func (m *memoryReader) Close() error {
+	return nil
+}
+
+// NewMemory function is returning an empty Memory.
+func NewMemory() *Memory {
+	return &Memory{files: make(map[string]*memoryFile)}
+}
+
+// Put method is returning an error, accepting a context, a key, a reader and a size.
+func (m *Memory) Put(_ context.Context, key string, r io.Reader, size int64) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+	if size >= 0 && int64(len(data)) != size {
+		return errors.Join(constants.ErrFileSave, constants.ErrFileSize)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[key] = &memoryFile{data: data, modTime: time.Now()}
+	return nil
+}
+
+// Get method is returning a seekable reader and an error, accepting a context and a key.
+func (m *Memory) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	file, err := m.file(key)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileRead, err)
+	}
+	return &memoryReader{Reader: bytes.NewReader(file.data)}, nil
+}
+
+// Stat method is returning an Object and an error, accepting a context and a key.
+func (m *Memory) Stat(_ context.Context, key string) (*Object, error) {
+	file, err := m.file(key)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileStat, err)
+	}
+	return &Object{Key: key, Size: int64(len(file.data)), ModTime: file.modTime}, nil
+}
+
+// Delete method is returning an error, accepting a context and a key.
+func (m *Memory) Delete(_ context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.files, key)
+	return nil
+}
+
+// List method is returning Objects and an error, accepting a context and a key prefix.
+func (m *Memory) List(_ context.Context, prefix string) ([]*Object, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	objects := make([]*Object, 0)
+	for key, file := range m.files {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, &Object{Key: key, Size: int64(len(file.data)), ModTime: file.modTime})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	return objects, nil
+}
+
+// file method is returning a memory file and an error, accepting a key.
+func (m *Memory) file(key string) (*memoryFile, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	file, ok := m.files[key]
+	if !ok {
+		return nil, constants.ErrItemNotFound
+	}
+	return file, nil
+}
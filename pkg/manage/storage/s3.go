@@ -0,0 +1,440 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"security-proof/pkg/constants"
+)
+
+// S3 signature version 4 constants.
+const (
+	s3Algorithm        = "AWS4-HMAC-SHA256"
+	s3Service          = "s3"
+	s3TimeFormat       = "20060102T150405Z"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// s3ErrorBodySize is the largest part of an error response kept in an error message.
+const s3ErrorBodySize = 1 << 10
+
+// S3 struct is composed of an HTTP client and the endpoint, region, bucket and credentials of an S3 compatible service.
+type S3 struct {
+	client    *http.Client
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	now       func() time.Time
+}
+
+// NewS3 function is returning an S3 and an error, accepting a Config and an HTTP client.
+// A default client is used when the client is nil.
+func NewS3(config *Config, client *http.Client) (*S3, error) {
+	if config.S3Bucket == "" || config.S3AccessKey == "" || config.S3SecretKey == "" {
+		return nil, errors.Join(constants.ErrStorageConfig, errors.New("bucket and credentials are required"))
+	}
+
+	endpoint, err := url.Parse(config.S3Endpoint)
+	if err != nil {
+		return nil, errors.Join(constants.ErrStorageConfig, err)
+	}
+	if (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, errors.Join(constants.ErrStorageConfig, fmt.Errorf("endpoint %q", config.S3Endpoint))
+	}
+
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return &S3{
+		client:    client,
+		endpoint:  endpoint,
+		region:    config.S3Region,
+		bucket:    config.S3Bucket,
+		accessKey: config.S3AccessKey,
+		secretKey: config.S3SecretKey,
+		pathStyle: config.S3PathStyle,
+		now:       time.Now,
+	}, nil
+}
+
+// Put method is returning an error, accepting a context, a key, a reader and a size.
+// A reader of an unknown size is spooled to a temporary file first, as the service needs the content length.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	if size < 0 {
+		spooled, err := spool(r)
+		if err != nil {
+			return errors.Join(constants.ErrFileSave, err)
+		}
+		defer closeSpool(spooled)
+
+		info, err := spooled.Stat()
+		if err != nil {
+			return errors.Join(constants.ErrFileSave, err)
+		}
+		r, size = spooled, info.Size()
+	}
+	if size == 0 {
+		// 길이가 0인 본문은 길이를 모르는 본문으로 처리되므로 빈 본문을 사용합니다.
+		r = http.NoBody
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, objectURL, r, s3UnsignedPayload)
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+	req.ContentLength = size
+
+	res, err := s.do(req)
+	if err != nil {
+		return errors.Join(constants.ErrFileSave, err)
+	}
+	closeBody(res)
+
+	return nil
+}
+
+// Get method is returning a body reader and an error, accepting a context and a key.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodGet, objectURL, nil, s3EmptyPayloadHash)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileRead, err)
+	}
+
+	res, err := s.do(req)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileRead, err)
+	}
+
+	return res.Body, nil
+}
+
+// Stat method is returning an Object and an error, accepting a context and a key.
+func (s *S3) Stat(ctx context.Context, key string) (*Object, error) {
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodHead, objectURL, nil, s3EmptyPayloadHash)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileStat, err)
+	}
+
+	res, err := s.do(req)
+	if err != nil {
+		return nil, errors.Join(constants.ErrFileStat, err)
+	}
+	closeBody(res)
+
+	object := &Object{Key: key, Size: res.ContentLength}
+	modTime, err := http.ParseTime(res.Header.Get("Last-Modified"))
+	if err == nil {
+		object.ModTime = modTime
+	}
+
+	return object, nil
+}
+
+// Delete method is returning an error, accepting a context and a key.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodDelete, objectURL, nil, s3EmptyPayloadHash)
+	if err != nil {
+		return errors.Join(constants.ErrFileDelete, err)
+	}
+
+	res, err := s.do(req)
+	if errors.Is(err, constants.ErrItemNotFound) {
+		return nil
+	} else if err != nil {
+		return errors.Join(constants.ErrFileDelete, err)
+	}
+	closeBody(res)
+
+	return nil
+}
+
+// s3ListResult struct is the XML response of a ListObjectsV2 request.
+type s3ListResult struct {
+	IsTruncated           bool
+	NextContinuationToken string
+	Contents              []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+}
+
+// List method is returning Objects and an error, accepting a context and a key prefix.
+// Every page of the result is read, following the continuation token.
+func (s *S3) List(ctx context.Context, prefix string) ([]*Object, error) {
+	objects := make([]*Object, 0)
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		bucketURL := s.bucketURL()
+		bucketURL.RawQuery = canonicalQuery(query)
+
+		req, err := s.newRequest(ctx, http.MethodGet, bucketURL, nil, s3EmptyPayloadHash)
+		if err != nil {
+			return nil, errors.Join(constants.ErrFileList, err)
+		}
+
+		res, err := s.do(req)
+		if err != nil {
+			return nil, errors.Join(constants.ErrFileList, err)
+		}
+
+		result := &s3ListResult{}
+		err = xml.NewDecoder(res.Body).Decode(result)
+		closeBody(res)
+		if err != nil {
+			return nil, errors.Join(constants.ErrFileList, err)
+		}
+
+		for _, content := range result.Contents {
+			objects = append(objects, &Object{Key: content.Key, Size: content.Size, ModTime: content.LastModified})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// objectURL method is returning the URL of an object and an error, accepting a key.
+func (s *S3) objectURL(key string) (*url.URL, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	objectURL := s.bucketURL()
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + cleaned
+	objectURL.RawPath = strings.TrimSuffix(objectURL.RawPath, "/") + "/" + s3Escape(cleaned, false)
+	return objectURL, nil
+}
+
+// bucketURL method is returning the URL of the bucket.
+// The bucket is the first path segment in path style, and a subdomain of the endpoint otherwise.
+func (s *S3) bucketURL() *url.URL {
+	bucketURL := *s.endpoint
+	base := strings.TrimSuffix(bucketURL.Path, "/")
+	if s.pathStyle {
+		bucketURL.Path = base + "/" + s.bucket
+		bucketURL.RawPath = s3Escape(base, false) + "/" + s3Escape(s.bucket, true)
+	} else {
+		bucketURL.Host = s.bucket + "." + bucketURL.Host
+		bucketURL.Path = base + "/"
+		bucketURL.RawPath = s3Escape(base, false) + "/"
+	}
+	return &bucketURL
+}
+
+// newRequest method is returning a signed request and an error, accepting a context, a method, a URL, a body and a payload hash.
+func (s *S3) newRequest(ctx context.Context, method string, requestURL *url.URL, body io.Reader, payloadHash string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sign(req, payloadHash, s.now())
+	return req, nil
+}
+
+// do method is returning a successful response and an error, accepting a request.
+// A missing object is reported as ErrItemNotFound.
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		closeBody(res)
+		return nil, constants.ErrItemNotFound
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, s3ErrorBodySize))
+		closeBody(res)
+		return nil, fmt.Errorf("s3 %s %s: %s %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(message)))
+	}
+
+	return res, nil
+}
+
+// sign method is setting the signature version 4 headers of a request, accepting a request, a payload hash and a signing time.
+// The host, the content type, the range and every x-amz header are signed.
+func (s *S3) sign(req *http.Request, payloadHash string, signedAt time.Time) {
+	amzDate := signedAt.UTC().Format(s3TimeFormat)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			trimmed := make([]string, len(values))
+			for i, value := range values {
+				trimmed[i] = strings.Join(strings.Fields(value), " ")
+			}
+			headers[lower] = strings.Join(trimmed, ",")
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	canonicalHeaders := &strings.Builder{}
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	uri := req.URL.EscapedPath()
+	if uri == "" {
+		uri = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uri,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/" + s3Service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 function is returning an HMAC-SHA256 digest, accepting a key and a data.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery function is returning a query string sorted and encoded for signing, accepting query values.
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(name, true)+"="+s3Escape(value, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape function is returning a URI encoded string, accepting a string and whether a slash is encoded.
+// Only the unreserved characters of RFC 3986 are left as they are.
+func s3Escape(value string, encodeSlash bool) string {
+	escaped := &strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			escaped.WriteByte(c)
+			continue
+		}
+		_, _ = fmt.Fprintf(escaped, "%%%02X", c)
+	}
+	return escaped.String()
+}
+
+// spool function is returning a temporary file holding the data and an error, accepting a reader.
+// The file is positioned at its start.
+func spool(r io.Reader) (*os.File, error) {
+	file, err := os.CreateTemp("", "security-proof-spool-*")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(file, r)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		closeSpool(file)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// closeSpool function is closing and removing a temporary file, accepting the file.
+func closeSpool(file *os.File) {
+	if err := file.Close(); err != nil {
+		log.Printf("Failed to close spool file: %v", err)
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		log.Printf("Failed to remove spool file: %v", err)
+	}
+}
+
+// closeBody function is draining and closing a response body, accepting a response.
+func closeBody(res *http.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	if err := res.Body.Close(); err != nil {
+		log.Printf("Failed to close response body: %v", err)
+	}
+}
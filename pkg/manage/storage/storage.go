@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"security-proof/pkg/constants"
+)
+
+// Storage interface is defining a blob store holding evidence files by key.
+// Keys are slash separated relative paths such as "sha256/9f/9f86d0...".
+type Storage interface {
+	Putter
+	Getter
+	Stater
+	Deleter
+	Lister
+}
+
+// Putter interface is defining a storage writing a file.
+// The size is -1 when it is not known in advance.
+type Putter interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+}
+
+// Getter interface is defining a storage reading a file as a stream.
+// The returned reader also implements io.Seeker when the backend can seek.
+type Getter interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// Stater interface is defining a storage describing a file.
+type Stater interface {
+	Stat(ctx context.Context, key string) (*Object, error)
+}
+
+// Deleter interface is defining a storage deleting a file.
+// Deleting a missing file is not an error.
+type Deleter interface {
+	Delete(ctx context.Context, key string) error
+}
+
+// Lister interface is defining a storage listing files under a key prefix in key order.
+type Lister interface {
+	List(ctx context.Context, prefix string) ([]*Object, error)
+}
+
+// Object struct is composed of a key, a size and a modified time of a stored file.
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// NewStorage function is returning a Storage and an error, accepting a Config.
+// The Storage encrypts the files when master keys are configured.
+func NewStorage(config *Config) (Storage, error) {
+	var storage Storage
+	var err error
+	switch strings.ToLower(config.Backend) {
+	case BackendLocal:
+		storage, err = NewLocal(config.Path)
+	case BackendS3:
+		storage, err = NewS3(config, nil)
+	default:
+		return nil, errors.Join(constants.ErrStorageBackend, fmt.Errorf("backend %q", config.Backend))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keyring, err := NewKeyring(config)
+	if err != nil {
+		return nil, err
+	}
+	if keyring == nil {
+		return storage, nil
+	}
+	return NewEncrypted(storage, keyring), nil
+}
+
+// DigestKey function is returning a content addressed key, accepting a SHA-256 hex digest.
+// The first two characters are used as a directory so that no directory holds every file.
+func DigestKey(digest string) string {
+	if len(digest) < 2 {
+		return path.Join("sha256", digest)
+	}
+	return path.Join("sha256", digest[:2], digest)
+}
+
+// ThumbnailKey function is returning the key of a thumbnail, accepting the SHA-256 hex digest of the original file.
+func ThumbnailKey(digest string) string {
+	if len(digest) < 2 {
+		return path.Join("thumbnail", digest)
+	}
+	return path.Join("thumbnail", digest[:2], digest)
+}
+
+// RecordedKey function is returning the key of a recorded path, accepting the local root path and the recorded path.
+// Files saved before the storage existed are recorded with the root in front, either absolute or relative to the working directory,
+// so the root is trimmed. A key or a path outside of the root is returned as it is.
+func RecordedKey(root string, recorded string) string {
+	if root == "" || recorded == "" {
+		return recorded
+	}
+
+	cleanedRoot := filepath.Clean(root)
+	cleaned := filepath.Clean(recorded)
+	if !filepath.IsAbs(cleaned) && !strings.HasPrefix(cleaned, cleanedRoot+string(filepath.Separator)) {
+		return recorded
+	}
+
+	absRoot, err := filepath.Abs(cleanedRoot)
+	if err != nil {
+		return recorded
+	}
+	absRecorded, err := filepath.Abs(cleaned)
+	if err != nil {
+		return recorded
+	}
+
+	rel, err := filepath.Rel(absRoot, absRecorded)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return recorded
+	}
+	return filepath.ToSlash(rel)
+}
+
+// cleanKey function is returning a cleaned key and an error, accepting a key.
+// A key must stay relative so that it cannot point outside of the storage.
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(key, "\\", "/"))
+	if key == "" || cleaned == "." || strings.HasPrefix(cleaned, "/") || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", errors.Join(constants.ErrFilePath, constants.ErrFilePathTraversal)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"security-proof/pkg/constants"
+)
+
+// testStorage function is running the common storage cases, accepting a test and a Storage.
+func testStorage(t *testing.T, storage Storage) {
+	ctx := context.Background()
+
+	t.Run("파일 저장 및 조회 케이스", func(t *testing.T) {
+		err := storage.Put(ctx, "1/1_1700000000_1", strings.NewReader("first"), 5)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		reader, err := storage.Get(ctx, "1/1_1700000000_1")
+		assert.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.NoError(t, reader.Close())
+		assert.Equal(t, "first", string(data), "저장한 내용이 조회되었습니다.")
+
+		object, err := storage.Stat(ctx, "1/1_1700000000_1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), object.Size)
+	})
+
+	t.Run("크기를 모르는 파일 저장 케이스", func(t *testing.T) {
+		err := storage.Put(ctx, "1/1_1700000000_2", strings.NewReader("second"), -1)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		object, err := storage.Stat(ctx, "1/1_1700000000_2")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), object.Size)
+	})
+
+	t.Run("빈 파일 저장 케이스", func(t *testing.T) {
+		err := storage.Put(ctx, "2/empty", strings.NewReader(""), 0)
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		object, err := storage.Stat(ctx, "2/empty")
+		assert.NoError(t, err)
+		assert.Zero(t, object.Size)
+	})
+
+	t.Run("접두어 목록 조회 케이스", func(t *testing.T) {
+		objects, err := storage.List(ctx, "1/")
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.Len(t, objects, 2, "접두어가 같은 파일만 조회되었습니다.")
+		assert.Equal(t, "1/1_1700000000_1", objects[0].Key, "키 순서로 조회되었습니다.")
+	})
+
+	t.Run("파일 삭제 케이스", func(t *testing.T) {
+		err := storage.Delete(ctx, "1/1_1700000000_2")
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+
+		_, err = storage.Get(ctx, "1/1_1700000000_2")
+		assert.ErrorIs(t, err, constants.ErrItemNotFound, "삭제한 파일은 조회되지 않습니다.")
+
+		err = storage.Delete(ctx, "1/1_1700000000_2")
+		assert.NoError(t, err, "없는 파일 삭제는 에러가 아닙니다.")
+	})
+
+	t.Run("없는 파일 조회 케이스", func(t *testing.T) {
+		_, err := storage.Stat(ctx, "missing")
+		assert.ErrorIs(t, err, constants.ErrItemNotFound)
+	})
+
+	t.Run("경로 조작 케이스", func(t *testing.T) {
+		err := storage.Put(ctx, "../escape", strings.NewReader("x"), 1)
+		assert.ErrorIs(t, err, constants.ErrFilePathTraversal, "저장소 밖의 키는 거부되었습니다.")
+	})
+}
+
+func TestLocal(t *testing.T) {
+	root := t.TempDir()
+	local, err := NewLocal(root)
+	assert.NoError(t, err)
+
+	testStorage(t, local)
+
+	t.Run("크기 불일치 케이스", func(t *testing.T) {
+		err := local.Put(context.Background(), "short", strings.NewReader("abc"), 5)
+		assert.ErrorIs(t, err, constants.ErrFileSize)
+
+		_, err = os.Stat(filepath.Join(root, "short"))
+		assert.True(t, os.IsNotExist(err), "잘못된 파일은 남지 않았습니다.")
+	})
+
+	t.Run("절대 경로로 기록된 파일 조회 케이스", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(root, "legacy"), []byte("legacy"), 0o600)
+		assert.NoError(t, err)
+
+		reader, err := local.Get(context.Background(), filepath.Join(root, "legacy"))
+		assert.NoError(t, err, "저장소 안의 절대 경로는 조회되었습니다.")
+		assert.NoError(t, reader.Close())
+
+		_, err = local.Get(context.Background(), filepath.Join(t.TempDir(), "legacy"))
+		assert.ErrorIs(t, err, constants.ErrFilePathTraversal, "저장소 밖의 절대 경로는 거부되었습니다.")
+	})
+
+	t.Run("조회 결과 탐색 케이스", func(t *testing.T) {
+		reader, err := local.Get(context.Background(), "1/1_1700000000_1")
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, reader.Close()) }()
+
+		_, ok := reader.(io.Seeker)
+		assert.True(t, ok, "로컬 파일은 탐색할 수 있습니다.")
+	})
+}
+
+func TestMemory(t *testing.T) {
+	testStorage(t, NewMemory())
+}
+
+func TestNewStorage(t *testing.T) {
+	t.Run("로컬 저장소 생성 케이스", func(t *testing.T) {
+		storage, err := NewStorage(&Config{Backend: BackendLocal, Path: t.TempDir()})
+		assert.NoError(t, err, "에러가 발생하지 않았습니다.")
+		assert.IsType(t, &Local{}, storage)
+	})
+
+	t.Run("S3 인증 정보 누락 케이스", func(t *testing.T) {
+		_, err := NewStorage(&Config{Backend: BackendS3, S3Endpoint: "http://127.0.0.1:9000", S3Bucket: "proof"})
+		assert.ErrorIs(t, err, constants.ErrStorageConfig)
+	})
+
+	t.Run("지원하지 않는 저장소 케이스", func(t *testing.T) {
+		_, err := NewStorage(&Config{Backend: "ftp"})
+		assert.ErrorIs(t, err, constants.ErrStorageBackend)
+	})
+}
+
+func TestDigestKey(t *testing.T) {
+	digest := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	assert.Equal(t, "sha256/9f/"+digest, DigestKey(digest), "다이제스트 앞 두 글자가 디렉터리가 되었습니다.")
+
+	_, err := cleanKey(DigestKey(digest))
+	assert.NoError(t, err, "다이제스트 키는 저장소 안의 키입니다.")
+
+	assert.Equal(t, "thumbnail/9f/"+digest, ThumbnailKey(digest), "썸네일은 원본과 다른 디렉터리에 저장됩니다.")
+}
+
+func TestRecordedKey(t *testing.T) {
+	cases := map[string]struct {
+		root     string
+		recorded string
+		expected string
+	}{
+		"저장소 키":         {root: "/srv/savedproofs", recorded: "sha256/9f/9f86", expected: "sha256/9f/9f86"},
+		"이전 절대 경로":      {root: "/srv/savedproofs", recorded: "/srv/savedproofs/1_1700000000_1", expected: "1_1700000000_1"},
+		"정리되지 않은 절대 경로": {root: "/srv/savedproofs/", recorded: "/srv/savedproofs//1_1700000000_1", expected: "1_1700000000_1"},
+		"이전 상대 경로":      {root: "savedproofs", recorded: "savedproofs/1_1700000000_1", expected: "1_1700000000_1"},
+		"루트 밖의 절대 경로":   {root: "/srv/savedproofs", recorded: "/srv/other/1_1700000000_1", expected: "/srv/other/1_1700000000_1"},
+		"루트와 이름만 같은 경로": {root: "/srv/savedproofs", recorded: "/srv/savedproofs2/1", expected: "/srv/savedproofs2/1"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, RecordedKey(c.root, c.recorded))
+		})
+	}
+}
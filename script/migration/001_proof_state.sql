@@ -0,0 +1,19 @@
+-- 증적 라이프사이클 상태 컬럼과 상태 전이 이력 테이블을 추가합니다.
+-- 0 draft, 1 assigned, 2 uploaded, 3 in review, 4 rejected, 5 confirmed, 6 expired, 7 reopened
+ALTER TABLE proof.proof ADD COLUMN state integer NOT NULL DEFAULT 0;
+
+UPDATE proof.proof SET state = 5 WHERE confirm = 1;
+UPDATE proof.proof SET state = 2 WHERE confirm = 0 AND uploaded_at IS NOT NULL;
+UPDATE proof.proof SET state = 1 WHERE confirm = 0 AND uploaded_at IS NULL AND coalesce(uploaded_user_idx, 0) <> 0;
+
+CREATE TABLE proof.proof_state_history
+(
+    idx        serial PRIMARY KEY,
+    proof_idx  integer     NOT NULL REFERENCES proof.proof (idx) ON DELETE CASCADE,
+    from_state integer     NOT NULL,
+    to_state   integer     NOT NULL,
+    user_idx   integer     NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX proof_state_history_proof_idx ON proof.proof_state_history (proof_idx);
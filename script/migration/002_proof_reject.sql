@@ -0,0 +1,12 @@
+-- 관리자가 반려한 증적의 반려 사유와 재업로드 시점을 기록합니다.
+CREATE TABLE proof.proof_reject
+(
+    idx               serial PRIMARY KEY,
+    proof_idx         integer     NOT NULL REFERENCES proof.proof (idx) ON DELETE CASCADE,
+    reason            text        NOT NULL CHECK (length(trim(reason)) > 0),
+    rejected_user_idx integer     NOT NULL,
+    rejected_at       timestamptz NOT NULL DEFAULT now(),
+    resolved_at       timestamptz
+);
+
+CREATE INDEX proof_reject_proof_idx ON proof.proof_reject (proof_idx);
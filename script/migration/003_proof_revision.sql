@@ -0,0 +1,21 @@
+-- 증적 업로드마다 변경되지 않는 리비전을 기록합니다.
+CREATE TABLE proof.proof_revision
+(
+    idx               serial PRIMARY KEY,
+    proof_idx         integer     NOT NULL REFERENCES proof.proof (idx) ON DELETE CASCADE,
+    revision          integer     NOT NULL,
+    first_image_path  text,
+    first_image_hash  text,
+    second_image_path text,
+    second_image_hash text,
+    uploaded_user_idx integer     NOT NULL,
+    uploaded_at       timestamptz NOT NULL DEFAULT now(),
+    token_id          integer,
+    UNIQUE (proof_idx, revision)
+);
+
+-- 기존 업로드는 첫 번째 리비전으로 옮깁니다. 해시는 확정 시점에 계산되므로 비워 둡니다.
+INSERT INTO proof.proof_revision (proof_idx, revision, first_image_path, second_image_path, uploaded_user_idx, uploaded_at, token_id)
+SELECT idx, 1, first_image_path, second_image_path, uploaded_user_idx, uploaded_at, token_id
+FROM proof.proof
+WHERE uploaded_at IS NOT NULL AND uploaded_user_idx IS NOT NULL;
@@ -0,0 +1,32 @@
+-- 증적 하나에 여러 개의 첨부 파일을 순서대로 기록합니다.
+CREATE TABLE proof.proof_attachment
+(
+    idx          serial PRIMARY KEY,
+    proof_idx    integer     NOT NULL REFERENCES proof.proof (idx) ON DELETE CASCADE,
+    revision_idx integer     NOT NULL REFERENCES proof.proof_revision (idx) ON DELETE CASCADE,
+    position     integer     NOT NULL,
+    label        text        NOT NULL,
+    mime_type    text        NOT NULL,
+    path         text        NOT NULL,
+    hash         text        NOT NULL,
+    size         bigint      NOT NULL DEFAULT 0,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (revision_idx, position)
+);
+
+-- 기존 리비전의 첫 번째, 두 번째 이미지를 첨부 파일로 옮깁니다.
+INSERT INTO proof.proof_attachment (proof_idx, revision_idx, position, label, mime_type, path, hash, created_at)
+SELECT proof_idx, idx, 1, 'first', 'application/octet-stream', first_image_path, coalesce(first_image_hash, ''), uploaded_at
+FROM proof.proof_revision
+WHERE first_image_path IS NOT NULL;
+
+INSERT INTO proof.proof_attachment (proof_idx, revision_idx, position, label, mime_type, path, hash, created_at)
+SELECT proof_idx, idx, 2, 'second', 'application/octet-stream', second_image_path, coalesce(second_image_hash, ''), uploaded_at
+FROM proof.proof_revision
+WHERE second_image_path IS NOT NULL;
+
+ALTER TABLE proof.proof_revision
+    DROP COLUMN first_image_path,
+    DROP COLUMN first_image_hash,
+    DROP COLUMN second_image_path,
+    DROP COLUMN second_image_hash;
@@ -0,0 +1,28 @@
+-- 심사 주기(예: 연도별 ISMS-P 인증 심사)를 관리합니다.
+CREATE TABLE proof.assessment_cycle
+(
+    idx              serial PRIMARY KEY,
+    name             text        NOT NULL,
+    started_user_idx integer     NOT NULL,
+    started_at       timestamptz NOT NULL DEFAULT now(),
+    ended_at         timestamptz
+);
+
+-- 진행 중인 심사 주기는 하나만 존재합니다.
+CREATE UNIQUE INDEX assessment_cycle_active_idx ON proof.assessment_cycle ((ended_at IS NULL)) WHERE ended_at IS NULL;
+
+-- 기존 증적은 최초 심사 주기로 묶습니다.
+INSERT INTO proof.assessment_cycle (name, started_user_idx, started_at)
+SELECT 'initial', coalesce(min(created_user_idx), 0), coalesce(min(created_at), now())
+FROM proof.proof;
+
+ALTER TABLE proof.proof
+    ADD COLUMN cycle_idx integer REFERENCES proof.assessment_cycle (idx);
+
+UPDATE proof.proof
+SET cycle_idx = (SELECT min(idx) FROM proof.assessment_cycle);
+
+ALTER TABLE proof.proof
+    ALTER COLUMN cycle_idx SET NOT NULL;
+
+CREATE INDEX proof_cycle_idx ON proof.proof (cycle_idx);
@@ -0,0 +1,21 @@
+-- 인증 기준(ISMS-P, ISO 27001 등)의 통제 항목 카탈로그를 관리합니다.
+CREATE TABLE proof.control
+(
+    idx               serial PRIMARY KEY,
+    framework         text NOT NULL,
+    control_id        text NOT NULL,
+    title             text NOT NULL,
+    parent_control_id text,
+    UNIQUE (framework, control_id)
+);
+
+-- 증적과 통제 항목은 다대다로 매핑됩니다.
+CREATE TABLE proof.proof_control
+(
+    idx         serial PRIMARY KEY,
+    proof_idx   integer NOT NULL REFERENCES proof.proof (idx) ON DELETE CASCADE,
+    control_idx integer NOT NULL REFERENCES proof.control (idx) ON DELETE CASCADE,
+    UNIQUE (proof_idx, control_idx)
+);
+
+CREATE INDEX proof_control_control_idx ON proof.proof_control (control_idx);
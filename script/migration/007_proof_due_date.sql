@@ -0,0 +1,7 @@
+-- 증적 제출 기한과 마지막 알림 시각을 기록합니다.
+ALTER TABLE proof.proof
+    ADD COLUMN due_at      timestamptz,
+    ADD COLUMN reminded_at timestamptz;
+
+-- 스케줄러는 진행 중인 주기에서 기한이 가까운 증적을 찾습니다.
+CREATE INDEX proof_cycle_due_at_idx ON proof.proof (cycle_idx, due_at) WHERE due_at IS NOT NULL;
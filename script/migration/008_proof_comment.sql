@@ -0,0 +1,14 @@
+-- 증적별로 엔지니어와 관리자가 주고받은 댓글을 기록합니다.
+-- 삭제된 댓글은 deleted_at 으로만 표시하여 기록을 남깁니다.
+CREATE TABLE proof.proof_comment
+(
+    idx        serial PRIMARY KEY,
+    proof_idx  integer     NOT NULL REFERENCES proof.proof (idx) ON DELETE CASCADE,
+    user_idx   integer     NOT NULL,
+    body       text        NOT NULL CHECK (length(trim(body)) > 0),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    edited_at  timestamptz,
+    deleted_at timestamptz
+);
+
+CREATE INDEX proof_comment_proof_idx ON proof.proof_comment (proof_idx) WHERE deleted_at IS NULL;
@@ -0,0 +1,43 @@
+-- 첨부 파일 내용은 SHA-256 다이제스트 하나당 한 번만 저장합니다.
+CREATE TABLE proof.evidence_blob
+(
+    digest     text PRIMARY KEY CHECK (digest ~ '^[0-9a-f]{64}$'),
+    path       text        NOT NULL,
+    mime_type  text        NOT NULL,
+    size       bigint      NOT NULL,
+    ref_count  integer     NOT NULL DEFAULT 0 CHECK (ref_count >= 0),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- 참조 수는 증적 리비전의 첨부 파일이 추가되거나 삭제될 때(증적 삭제의 CASCADE 포함) 함께 바뀝니다.
+CREATE FUNCTION proof.evidence_blob_ref_count() RETURNS trigger AS
+$$
+BEGIN
+    IF tg_op = 'INSERT' THEN
+        UPDATE proof.evidence_blob SET ref_count = ref_count + 1 WHERE digest = new.hash;
+        RETURN new;
+    END IF;
+    UPDATE proof.evidence_blob SET ref_count = ref_count - 1 WHERE digest = old.hash;
+    RETURN old;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER proof_attachment_blob_ref_count
+    AFTER INSERT OR DELETE
+    ON proof.proof_attachment
+    FOR EACH ROW
+EXECUTE FUNCTION proof.evidence_blob_ref_count();
+
+-- 해시가 기록된 기존 첨부 파일은 처음 저장된 경로를 그대로 블롭으로 등록합니다.
+INSERT INTO proof.evidence_blob (digest, path, mime_type, size, ref_count, created_at)
+SELECT DISTINCT ON (hash) hash,
+                          path,
+                          mime_type,
+                          size,
+                          count(*) OVER (PARTITION BY hash),
+                          created_at
+FROM proof.proof_attachment
+WHERE hash ~ '^[0-9a-f]{64}$'
+ORDER BY hash, created_at, idx;
+
+CREATE INDEX evidence_blob_unreferenced_idx ON proof.evidence_blob (created_at) WHERE ref_count = 0;
@@ -0,0 +1,6 @@
+-- 이미지 첨부 파일의 미리보기 썸네일 경로를 기록합니다. 이미지가 아니거나 아직 만들지 않았다면 비어 있습니다.
+ALTER TABLE proof.proof_attachment
+    ADD COLUMN thumbnail_path text;
+
+-- 기존 파일의 썸네일을 채울 때 비어 있는 첨부 파일만 찾습니다.
+CREATE INDEX proof_attachment_thumbnail_missing_idx ON proof.proof_attachment (idx) WHERE thumbnail_path IS NULL;
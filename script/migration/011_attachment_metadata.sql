@@ -0,0 +1,8 @@
+-- 이미지 첨부 파일에서 읽은 촬영 시각과 기기 정보입니다. 오래된 스크린샷을 검토자가 알아볼 수 있도록 남깁니다.
+ALTER TABLE proof.proof_attachment
+    ADD COLUMN captured_at      timestamptz,
+    ADD COLUMN device_make      text,
+    ADD COLUMN device_model     text,
+    ADD COLUMN software         text,
+    -- 저장된 사본에서 위치 정보를 지웠는지 여부입니다. 해시는 위치 정보를 지운 사본의 해시입니다.
+    ADD COLUMN location_removed boolean NOT NULL DEFAULT false;
@@ -0,0 +1,26 @@
+-- 참조가 모두 사라진 시각을 남겨, 유예 기간이 지난 블롭만 정리합니다.
+ALTER TABLE proof.evidence_blob
+    ADD COLUMN orphaned_at timestamptz;
+
+UPDATE proof.evidence_blob
+SET orphaned_at = now()
+WHERE ref_count = 0;
+
+-- 다시 참조되면 정리 대상에서 빠지도록 시각을 지웁니다.
+CREATE OR REPLACE FUNCTION proof.evidence_blob_ref_count() RETURNS trigger AS
+$$
+BEGIN
+    IF tg_op = 'INSERT' THEN
+        UPDATE proof.evidence_blob SET ref_count = ref_count + 1, orphaned_at = NULL WHERE digest = new.hash;
+        RETURN new;
+    END IF;
+    UPDATE proof.evidence_blob
+    SET ref_count   = ref_count - 1,
+        orphaned_at = CASE WHEN ref_count = 1 THEN now() ELSE orphaned_at END
+    WHERE digest = old.hash;
+    RETURN old;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX proof.evidence_blob_unreferenced_idx;
+CREATE INDEX evidence_blob_orphaned_idx ON proof.evidence_blob (orphaned_at) WHERE ref_count = 0;
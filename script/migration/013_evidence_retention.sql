@@ -0,0 +1,42 @@
+-- 법적 보존 중인 증적은 삭제하거나 덮어쓸 수 없고, 정리된 증적은 해시와 체인 기록만 남깁니다.
+ALTER TABLE proof.proof
+    ADD COLUMN legal_hold          boolean NOT NULL DEFAULT false,
+    ADD COLUMN legal_hold_reason   text,
+    ADD COLUMN legal_hold_user_idx integer,
+    ADD COLUMN legal_hold_at       timestamptz,
+    ADD COLUMN purged_at           timestamptz;
+
+-- 보관 기간은 심사 주기가 끝난 시점부터 셉니다.
+-- 심사 주기 정책이 분류 정책보다, 분류 정책이 기본 정책(분류와 심사 주기가 모두 없는 정책)보다 우선합니다.
+CREATE TABLE proof.retention_policy
+(
+    idx              serial PRIMARY KEY,
+    category         text,
+    cycle_idx        integer REFERENCES proof.assessment_cycle (idx) ON DELETE CASCADE,
+    retention_days   integer     NOT NULL CHECK (retention_days > 0),
+    created_user_idx integer     NOT NULL,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    CHECK (category IS NULL OR cycle_idx IS NULL)
+);
+
+CREATE UNIQUE INDEX retention_policy_category_idx ON proof.retention_policy (category) WHERE category IS NOT NULL;
+CREATE UNIQUE INDEX retention_policy_cycle_idx ON proof.retention_policy (cycle_idx) WHERE cycle_idx IS NOT NULL;
+CREATE UNIQUE INDEX retention_policy_default_idx ON proof.retention_policy ((true)) WHERE category IS NULL AND cycle_idx IS NULL;
+
+-- 정리된 첨부 파일의 해시와 리비전의 체인 토큰을 남깁니다. 증적이 지워지지 않도록 CASCADE를 걸지 않습니다.
+CREATE TABLE proof.purged_evidence
+(
+    idx         serial PRIMARY KEY,
+    proof_idx   integer     NOT NULL REFERENCES proof.proof (idx),
+    revision    integer     NOT NULL,
+    position    integer     NOT NULL,
+    label       text        NOT NULL,
+    mime_type   text        NOT NULL,
+    hash        text        NOT NULL,
+    size        bigint      NOT NULL,
+    token_id    integer,
+    uploaded_at timestamptz NOT NULL,
+    purged_at   timestamptz NOT NULL
+);
+
+CREATE INDEX purged_evidence_purged_at_idx ON proof.purged_evidence (purged_at);
@@ -0,0 +1,8 @@
+-- 명령 출력이나 감사 로그 같은 텍스트 증적은 리비전의 첨부 파일로 저장하고, 리비전마다 하나만 둡니다.
+ALTER TABLE proof.proof_attachment
+    ADD COLUMN is_log     boolean NOT NULL DEFAULT false,
+    -- 텍스트 첨부 파일의 줄 수입니다. 로그를 나눠 읽을 때 전체 줄 수로 씁니다.
+    ADD COLUMN line_count integer;
+
+CREATE UNIQUE INDEX proof_attachment_log_idx ON proof.proof_attachment (revision_idx) WHERE is_log;
+
@@ -0,0 +1,4 @@
+-- 업로드할 때 첨부 파일 해시로 계산한 다이제스트를 리비전에 남깁니다. 확정할 때 다시 계산한 값과 비교해 업로드 이후의 변조를 찾습니다.
+-- 기존 리비전은 다이제스트 없이 두고 확정할 때 계산합니다.
+ALTER TABLE proof.proof_revision
+    ADD COLUMN digest text CHECK (digest ~ '^[0-9a-f]{64}$');
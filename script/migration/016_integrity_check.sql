@@ -0,0 +1,20 @@
+-- 확정된 증적의 파일을 주기적으로 다시 해시해 확정할 때 기록된 해시, 체인 기록과 비교한 결과를 검사마다 남깁니다.
+-- target은 비교한 대상으로 첨부 파일(file), 리비전 다이제스트(digest), 체인 기록(chain) 중 하나입니다.
+-- 첨부 파일은 항상 위치가 있고, 첨부 파일별 해시가 기록된 이전 체인 기록은 비교한 첨부 파일의 위치가 있습니다.
+CREATE TABLE proof.integrity_check
+(
+    idx           serial PRIMARY KEY,
+    proof_idx     integer     NOT NULL REFERENCES proof.proof (idx) ON DELETE CASCADE,
+    revision_idx  integer     NOT NULL REFERENCES proof.proof_revision (idx) ON DELETE CASCADE,
+    position      integer,
+    target        text        NOT NULL CHECK (target IN ('file', 'digest', 'chain')),
+    status        text        NOT NULL CHECK (status IN ('ok', 'mismatch', 'missing')),
+    expected_hash text        NOT NULL,
+    actual_hash   text,
+    checked_at    timestamptz NOT NULL,
+    CHECK (target <> 'file' OR position IS NOT NULL),
+    CHECK (target <> 'digest' OR position IS NULL)
+);
+
+-- 대시보드는 마지막 검사 결과만 읽습니다.
+CREATE INDEX integrity_check_checked_at_idx ON proof.integrity_check (checked_at);
@@ -0,0 +1,3 @@
+-- 다음 심사 주기로 복제된 증적이 어느 증적에서 복제되었는지 기록합니다.
+ALTER TABLE proof.proof
+    ADD COLUMN source_proof_idx integer REFERENCES proof.proof (idx) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+-- 담당자가 없는 증적은 가져온 증적과 같이 0 대신 NULL로 기록합니다.
+UPDATE proof.proof SET uploaded_user_idx = NULL WHERE uploaded_user_idx = 0;